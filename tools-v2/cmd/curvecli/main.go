@@ -23,9 +23,11 @@
 package main
 
 import (
+	"os"
+
 	cli "github.com/opencurve/curve/tools-v2/pkg/cli"
 )
 
 func main() {
-	cli.Execute()
+	os.Exit(cli.Execute())
 }
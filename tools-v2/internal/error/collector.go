@@ -0,0 +1,176 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package cmderror
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrorEntry is an error happened in the command and where it comes from
+type ErrorEntry struct {
+	Err *CmdError `json:"error"`
+	// the host which returns the error
+	Addr string `json:"addr,omitempty"`
+	// rpc func name or http suburi
+	Rpc string `json:"rpc,omitempty"`
+	// the error happened in the Retry-th retry, 0 means the first call
+	Retry int32 `json:"retry,omitempty"`
+}
+
+// Collector keeps the errors of one command run,
+// it can be used by multiple goroutines.
+// The methods of a nil Collector do nothing.
+type Collector struct {
+	mutex   sync.Mutex
+	entries []ErrorEntry
+}
+
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+func (c *Collector) Add(entry ErrorEntry) {
+	if c == nil || entry.Err == nil {
+		return
+	}
+	c.mutex.Lock()
+	c.entries = append(c.entries, entry)
+	c.mutex.Unlock()
+}
+
+func (c *Collector) AddError(err *CmdError) {
+	c.Add(ErrorEntry{Err: err})
+}
+
+// Contains reports whether err has been added
+func (c *Collector) Contains(err *CmdError) bool {
+	if c == nil {
+		return false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, entry := range c.entries {
+		if entry.Err == err {
+			return true
+		}
+	}
+	return false
+}
+
+// Entries returns the copy of errors in the order they are added
+func (c *Collector) Entries() []ErrorEntry {
+	if c == nil {
+		return nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	ret := make([]ErrorEntry, len(c.entries))
+	copy(ret, c.entries)
+	return ret
+}
+
+const (
+	ERROR_TREE_NONE = "(none)"
+)
+
+type errorGroup struct {
+	name     string
+	children []*errorGroup
+	entries  []ErrorEntry
+}
+
+func (g *errorGroup) child(name string) *errorGroup {
+	if name == "" {
+		name = ERROR_TREE_NONE
+	}
+	for _, c := range g.children {
+		if c.name == name {
+			return c
+		}
+	}
+	c := &errorGroup{name: name}
+	g.children = append(g.children, c)
+	return c
+}
+
+// ErrorTree groups the errors by host and then rpc, like:
+//
+//	errors:
+//	└── 127.0.0.1:6700
+//	    └── ListClusterFsInfo
+//	        ├── rpc call is fail and will be retried[1/1], ...
+//	        └── [retry 1] rpc call is fail, ...
+func ErrorTree(entries []ErrorEntry) string {
+	root := &errorGroup{name: "errors:"}
+	for _, entry := range entries {
+		rpc := root.child(entry.Addr).child(entry.Rpc)
+		rpc.entries = append(rpc.entries, entry)
+	}
+	var sb strings.Builder
+	sb.WriteString(root.name + "\n")
+	for i, host := range root.children {
+		writeErrorGroup(&sb, host, "", i == len(root.children)-1)
+	}
+	return sb.String()
+}
+
+func writeErrorGroup(sb *strings.Builder, g *errorGroup, prefix string, last bool) {
+	branch, indent := "├── ", "│   "
+	if last {
+		branch, indent = "└── ", "    "
+	}
+	sb.WriteString(prefix + branch + g.name + "\n")
+	prefix += indent
+	for i, c := range g.children {
+		writeErrorGroup(sb, c, prefix, i == len(g.children)-1 && len(g.entries) == 0)
+	}
+	for i, entry := range g.entries {
+		branch := "├── "
+		if i == len(g.entries)-1 {
+			branch = "└── "
+		}
+		line := fmt.Sprintf("%s (code %d)", entry.Err.Message, entry.Err.Code)
+		if entry.Retry > 0 {
+			line = fmt.Sprintf("[retry %d] %s", entry.Retry, line)
+		}
+		sb.WriteString(prefix + branch + line + "\n")
+	}
+}
+
+type collectorKey struct{}
+
+// NewContext returns a copy of ctx whose errors are added to c
+func NewContext(ctx context.Context, c *Collector) context.Context {
+	return context.WithValue(ctx, collectorKey{}, c)
+}
+
+// FromContext returns the Collector in ctx, nil if there is none
+func FromContext(ctx context.Context) *Collector {
+	if ctx == nil {
+		return nil
+	}
+	c, _ := ctx.Value(collectorKey{}).(*Collector)
+	return c
+}
@@ -0,0 +1,83 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package cmderror
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCollector(t *testing.T) {
+	Convey("TestCollector", t, func() {
+		Convey("add concurrently", func() {
+			c := NewCollector()
+			var wg sync.WaitGroup
+			for i := 0; i < 100; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					c.Add(ErrorEntry{Err: ErrRpcCall(), Addr: "127.0.0.1:6700", Rpc: "ListClusterFsInfo", Retry: int32(i)})
+				}(i)
+			}
+			wg.Wait()
+			So(len(c.Entries()), ShouldEqual, 100)
+		})
+
+		Convey("nil collector", func() {
+			var c *Collector
+			c.AddError(ErrRpcCall())
+			So(c.Entries(), ShouldBeNil)
+			So(c.Contains(ErrRpcCall()), ShouldBeFalse)
+		})
+
+		Convey("context", func() {
+			So(FromContext(context.Background()), ShouldBeNil)
+			c := NewCollector()
+			ctx := NewContext(context.Background(), c)
+			err := ErrRpcCall()
+			FromContext(ctx).AddError(err)
+			So(c.Contains(err), ShouldBeTrue)
+			So(c.Contains(ErrRpcCall()), ShouldBeFalse)
+		})
+
+		Convey("error tree", func() {
+			err := ErrRpcCall()
+			err.Format("down", "ListClusterFsInfo", "timeout")
+			tree := ErrorTree([]ErrorEntry{
+				{Err: err, Addr: "127.0.0.1:6700", Rpc: "ListClusterFsInfo"},
+				{Err: err, Addr: "127.0.0.1:6700", Rpc: "ListClusterFsInfo", Retry: 1},
+				{Err: err},
+			})
+			So(tree, ShouldEqual, "errors:\n"+
+				"├── 127.0.0.1:6700\n"+
+				"│   └── ListClusterFsInfo\n"+
+				"│       ├── rpc call is fail, the addr is: down, the func is ListClusterFsInfo, the error is: timeout (code 10001)\n"+
+				"│       └── [retry 1] rpc call is fail, the addr is: down, the func is ListClusterFsInfo, the error is: timeout (code 10001)\n"+
+				"└── (none)\n"+
+				"    └── (none)\n"+
+				"        └── rpc call is fail, the addr is: down, the func is ListClusterFsInfo, the error is: timeout (code 10001)\n")
+		})
+	})
+}
@@ -23,10 +23,13 @@
 package cmderror
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/copyset"
 	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/mds"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/metaserver"
 	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/topology"
 )
 
@@ -44,91 +47,170 @@ const (
 	CODE_UNKNOWN     = 10 * CODE_BASE_LINE
 )
 
+// The exit codes of curve, they are stable and can be used by scripts:
+//
+//	0   success
+//	1   generic failure, such as invalid flags or unknown command
+//	3   unreachable, the cluster can not be connected, such as rpc or http
+//	    transport errors, dial failures and no mds leader
+//	4   partial, the result is printed but part of it fails
+//	5   not found, the fs, mountpoint, topology or copyset does not exist
+//	6   aborted, the user declines the confirmation
+//	7   rpc result, the rpc succeeds but the server returns an error status
+//	8   http result, the http response is unreadable or not as expected
+//	9   internal, other errors in the tool
+//	124 timeout, the command exceeds --cmdtimeout
+//	130 interrupted, the command is canceled by SIGINT or SIGTERM
+const (
+	EXIT_SUCCESS     = 0
+	EXIT_FAILURE     = 1
+	EXIT_UNREACHABLE = 3
+	EXIT_PARTIAL     = 4
+	EXIT_NOT_FOUND   = 5
+	EXIT_ABORTED     = 6
+	EXIT_RPC_RESULT  = 7
+	EXIT_HTTP_RESULT = 8
+	EXIT_INTERNAL    = 9
+	EXIT_TIMEOUT     = 124
+	EXIT_INTERRUPTED = 130
+)
+
 type CmdError struct {
 	Code    int    `json:"code"`    // exit code
 	Message string `json:"message"` // exit message
+	// overrides the exit code derived from Code if it is not 0
+	exitCode int
 }
 
-var (
-	AllError []*CmdError
-)
-
-func init() {
-	AllError = make([]*CmdError, 0)
+func (ce *CmdError) Error() string {
+	return ce.Message
 }
 
 func (ce *CmdError) ToError() error {
-	return fmt.Errorf(ce.Message)
+	return ce
+}
+
+// WithExitCode sets the exit code which overrides the one derived from Code
+func (ce *CmdError) WithExitCode(exitCode int) *CmdError {
+	ce.exitCode = exitCode
+	return ce
+}
+
+// ExitCode returns the exit code of the process if the command fails with ce
+func (ce *CmdError) ExitCode() int {
+	if ce.exitCode != 0 {
+		return ce.exitCode
+	}
+	switch ce.TypeCode() {
+	case CODE_SUCCESS:
+		return EXIT_SUCCESS
+	case CODE_RPC, CODE_HTTP:
+		return EXIT_UNREACHABLE
+	case CODE_RPC_RESULT:
+		return EXIT_RPC_RESULT
+	case CODE_HTTP_RESULT:
+		return EXIT_HTTP_RESULT
+	case CODE_INTERNAL:
+		return EXIT_INTERNAL
+	default:
+		return EXIT_FAILURE
+	}
+}
+
+// ExitCode returns the exit code of the process if the command fails with err,
+// the error which is not a CmdError is a generic failure.
+func ExitCode(err error) int {
+	if err == nil {
+		return EXIT_SUCCESS
+	}
+	var cmdErr *CmdError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.ExitCode()
+	}
+	return EXIT_FAILURE
+}
+
+// ToCmdError returns the CmdError in err, the error which is not a CmdError
+// is an unknown one, nil is success.
+func ToCmdError(err error) *CmdError {
+	if err == nil {
+		return ErrSuccess()
+	}
+	var cmdErr *CmdError
+	if errors.As(err, &cmdErr) {
+		return cmdErr
+	}
+	return &CmdError{
+		Code:    CODE_UNKNOWN,
+		Message: err.Error(),
+	}
+}
+
+// PartialCmdError returns the copy of err for the result
+// which is got partially, part of it fails with err
+func PartialCmdError(err *CmdError) *CmdError {
+	ret := *err
+	if code := err.ExitCode(); code != EXIT_SUCCESS && code != EXIT_TIMEOUT && code != EXIT_INTERRUPTED {
+		ret.exitCode = EXIT_PARTIAL
+	}
+	return &ret
 }
 
 func NewSucessCmdError() *CmdError {
-	ret := &CmdError{
+	return &CmdError{
 		Code:    CODE_SUCCESS,
 		Message: "success",
 	}
-	AllError = append(AllError, ret)
-	return ret
 }
 
 func NewInternalCmdError(code int, message string) *CmdError {
 	if code == 0 {
 		return NewSucessCmdError()
 	}
-	ret := &CmdError{
+	return &CmdError{
 		Code:    CODE_INTERNAL + code,
 		Message: message,
 	}
-
-	AllError = append(AllError, ret)
-	return ret
 }
 
 func NewRpcError(code int, message string) *CmdError {
 	if code == 0 {
 		return NewSucessCmdError()
 	}
-	ret := &CmdError{
+	return &CmdError{
 		Code:    CODE_RPC + code,
 		Message: message,
 	}
-	AllError = append(AllError, ret)
-	return ret
 }
 
 func NewRpcReultCmdError(code int, message string) *CmdError {
 	if code == 0 {
 		return NewSucessCmdError()
 	}
-	ret := &CmdError{
+	return &CmdError{
 		Code:    CODE_RPC_RESULT + code,
 		Message: message,
 	}
-	AllError = append(AllError, ret)
-	return ret
 }
 
 func NewHttpError(code int, message string) *CmdError {
 	if code == 0 {
 		return NewSucessCmdError()
 	}
-	ret := &CmdError{
+	return &CmdError{
 		Code:    CODE_HTTP + code,
 		Message: message,
 	}
-	AllError = append(AllError, ret)
-	return ret
 }
 
 func NewHttpResultCmdError(code int, message string) *CmdError {
 	if code == 0 {
 		return NewSucessCmdError()
 	}
-	ret := &CmdError{
+	return &CmdError{
 		Code:    CODE_HTTP_RESULT + code,
 		Message: message,
 	}
-	AllError = append(AllError, ret)
-	return ret
 }
 
 func (cmd CmdError) TypeCode() int {
@@ -176,23 +258,37 @@ func MostImportantCmdError(err []*CmdError) *CmdError {
 	return ret
 }
 
-// keep the most important wrong id, all wrong message will be kept
+// keep the most important wrong id, all wrong message will be kept,
+// it is partial if some of err are success
 func MergeCmdError(err []*CmdError) CmdError {
-	if len(err) == 0 {
-		return *NewSucessCmdError()
-	}
 	var ret CmdError
 	ret.Code = CODE_UNKNOWN
-	ret.Message = ""
+	var messages []string
+	// CODE_UNKNOWN is also the code of the error which is not a CmdError
+	failed := false
+	partial := false
 	for _, e := range err {
-		if e.Code == CODE_SUCCESS {
+		if e == nil {
+			continue
+		} else if e.Code == CODE_SUCCESS {
+			partial = true
 			continue
-		} else if e.Code < ret.Code {
+		} else if !failed || e.Code < ret.Code {
 			ret.Code = e.Code
+			ret.exitCode = e.exitCode
 		}
-		ret.Message = e.Message + "\n" + ret.Message
+		failed = true
+		if e.Message != "" {
+			messages = append([]string{e.Message}, messages...)
+		}
+	}
+	if !failed {
+		return *NewSucessCmdError()
+	}
+	ret.Message = strings.Join(messages, "\n")
+	if partial {
+		return *PartialCmdError(&ret)
 	}
-	ret.Message = ret.Message[:len(ret.Message)-1]
 	return ret
 }
 
@@ -207,10 +303,10 @@ var (
 		return NewInternalCmdError(2, "data: %s is not as expected, the error is: %s")
 	}
 	ErrHttpClient = func() *CmdError {
-		return NewInternalCmdError(3, "http client gets error: %s")
+		return NewInternalCmdError(3, "http client gets error: %s").WithExitCode(EXIT_UNREACHABLE)
 	}
 	ErrRpcDial = func() *CmdError {
-		return NewInternalCmdError(4, "dial to rpc server %s failed, the error is: %s")
+		return NewInternalCmdError(4, "dial to rpc server %s failed, the error is: %s").WithExitCode(EXIT_UNREACHABLE)
 	}
 	ErrUnmarshalJson = func() *CmdError {
 		return NewInternalCmdError(5, "unmarshal json error, the json is %s, the error is %s")
@@ -249,7 +345,7 @@ var (
 		return NewInternalCmdError(16, "marshal %s to json error, the error is: %s")
 	}
 	ErrCopysetKey = func() *CmdError {
-		return NewInternalCmdError(17, "copyset key [%d] not found in %s!")
+		return NewInternalCmdError(17, "copyset key [%d] not found in %s!").WithExitCode(EXIT_NOT_FOUND)
 	}
 	ErrQueryCopyset = func() *CmdError {
 		return NewInternalCmdError(18, "query copyset failed! the error is: %s")
@@ -278,6 +374,39 @@ var (
 	ErrCheckPoolTopology = func() *CmdError {
 		return NewInternalCmdError(26, "pool[%s] is not in cluster nor in json file")
 	}
+	ErrMdsLeader = func() *CmdError {
+		return NewInternalCmdError(27, "no leader found in mds[%s]").WithExitCode(EXIT_UNREACHABLE)
+	}
+	ErrCmdCanceled = func() *CmdError {
+		return NewInternalCmdError(28, "the command is canceled, the error is: %s").WithExitCode(EXIT_INTERRUPTED)
+	}
+	ErrCmdTimeout = func() *CmdError {
+		return NewInternalCmdError(29, "the command is timeout, the error is: %s").WithExitCode(EXIT_TIMEOUT)
+	}
+	ErrAborted = func() *CmdError {
+		return NewInternalCmdError(30, "abort %s").WithExitCode(EXIT_ABORTED)
+	}
+	ErrRunSteps = func() *CmdError {
+		return NewInternalCmdError(31, "%d of %d steps failed: %s").WithExitCode(EXIT_FAILURE)
+	}
+	ErrInodeNotFound = func() *CmdError {
+		return NewInternalCmdError(32, "inode[%d] is not on any partition of fs[%d]").WithExitCode(EXIT_NOT_FOUND)
+	}
+	ErrDryRun = func() *CmdError {
+		return NewInternalCmdError(33, "the rpc %s is not sent in dry-run mode")
+	}
+	ErrAuditLog = func() *CmdError {
+		return NewInternalCmdError(34, "write the audit log of %s failed, the error is: %s")
+	}
+	ErrConfigInvalid = func() *CmdError {
+		return NewInternalCmdError(35, "%d problems are found in config file %s").WithExitCode(EXIT_FAILURE)
+	}
+	ErrRecording = func() *CmdError {
+		return NewInternalCmdError(36, "record the %s of %s failed, the error is: %s")
+	}
+	ErrTrace = func() *CmdError {
+		return NewInternalCmdError(37, "write the trace to %s failed, the error is: %s")
+	}
 
 	// http error
 	ErrHttpUnreadableResult = func() *CmdError {
@@ -294,6 +423,9 @@ var (
 	ErrRpcCall = func() *CmdError {
 		return NewRpcReultCmdError(1, "rpc call is fail, the addr is: %s, the func is %s, the error is: %s")
 	}
+	ErrRpcRetry = func() *CmdError {
+		return NewRpcError(1, "rpc call is fail and will be retried[%d/%d], the addr is: %s, the func is %s, the error is: %s")
+	}
 	ErrUmountFs = func(statusCode int) *CmdError {
 		var message string
 		code := mds.FSStatusCode(statusCode)
@@ -309,7 +441,7 @@ var (
 		default:
 			message = fmt.Sprintf("umount from fs failed!, error is %s", code.String())
 		}
-		return NewRpcReultCmdError(statusCode, message)
+		return fsNotFound(NewRpcReultCmdError(statusCode, message), code)
 	}
 	ErrGetFsInfo = func(statusCode int) *CmdError {
 		return NewRpcReultCmdError(statusCode, "get fs info failed: status code is %s")
@@ -330,7 +462,7 @@ var (
 		default:
 			message = fmt.Sprintf("delete fs failed!, error is %s", code.String())
 		}
-		return NewRpcReultCmdError(statusCode, message)
+		return fsNotFound(NewRpcReultCmdError(statusCode, message), code)
 	}
 	ErrCreateFs = func(statusCode int) *CmdError {
 		var message string
@@ -347,6 +479,20 @@ var (
 		}
 		return NewRpcReultCmdError(statusCode, message)
 	}
+	ErrListPartition = func(statusCode int) *CmdError {
+		code := topology.TopoStatusCode(statusCode)
+		message := fmt.Sprintf("list partition failed: status code is %s", code.String())
+		return topoNotFound(NewRpcReultCmdError(statusCode, message), code)
+	}
+	ErrGetInode = func(statusCode int) *CmdError {
+		code := metaserver.MetaStatusCode(statusCode)
+		message := fmt.Sprintf("get inode failed: status code is %s", code.String())
+		ret := NewRpcReultCmdError(statusCode, message)
+		if code == metaserver.MetaStatusCode_NOT_FOUND {
+			ret.exitCode = EXIT_NOT_FOUND
+		}
+		return ret
+	}
 	ErrGetCopysetsInfo = func(statusCode int) *CmdError {
 		code := topology.TopoStatusCode(statusCode)
 		message := fmt.Sprintf("get copysets info failed: status code is %s", code.String())
@@ -363,7 +509,11 @@ var (
 		default:
 			message = fmt.Sprintf("op status: %s in %s", statusCode.String(), addr)
 		}
-		return NewRpcReultCmdError(code, message)
+		ret := NewRpcReultCmdError(code, message)
+		if statusCode == copyset.COPYSET_OP_STATUS_COPYSET_OP_STATUS_COPYSET_NOTEXIST {
+			ret.exitCode = EXIT_NOT_FOUND
+		}
+		return ret
 	}
 	ErrListPool = func(statusCode topology.TopoStatusCode) *CmdError {
 		var message string
@@ -407,7 +557,7 @@ var (
 		default:
 			message = fmt.Sprintf("delete %s err: %s", topoType, statusCode.String())
 		}
-		return NewRpcReultCmdError(code, message)
+		return topoNotFound(NewRpcReultCmdError(code, message), statusCode)
 	}
 	ErrCreateTopology = func(statusCode topology.TopoStatusCode, topoType string) *CmdError {
 		var message string
@@ -421,3 +571,24 @@ var (
 		return NewRpcReultCmdError(code, message)
 	}
 )
+
+func fsNotFound(err *CmdError, code mds.FSStatusCode) *CmdError {
+	switch code {
+	case mds.FSStatusCode_NOT_FOUND, mds.FSStatusCode_MOUNT_POINT_NOT_EXIST:
+		err.exitCode = EXIT_NOT_FOUND
+	}
+	return err
+}
+
+func topoNotFound(err *CmdError, code topology.TopoStatusCode) *CmdError {
+	switch code {
+	case topology.TopoStatusCode_TOPO_METASERVER_NOT_FOUND,
+		topology.TopoStatusCode_TOPO_SERVER_NOT_FOUND,
+		topology.TopoStatusCode_TOPO_ZONE_NOT_FOUND,
+		topology.TopoStatusCode_TOPO_POOL_NOT_FOUND,
+		topology.TopoStatusCode_TOPO_COPYSET_NOT_FOUND,
+		topology.TopoStatusCode_TOPO_PARTITION_NOT_FOUND:
+		err.exitCode = EXIT_NOT_FOUND
+	}
+	return err
+}
@@ -24,8 +24,12 @@ package cmderror
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/mds"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/topology"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -37,7 +41,7 @@ func TestCmdError(t *testing.T) {
 			Code:    0,
 			Message: "123%s",
 		}
-		tmp = tmp.Format("4")
+		tmp.Format("4")
 		tmp_json, err := json.Marshal(tmp)
 		So(err, ShouldBeNil)
 
@@ -50,3 +54,74 @@ func TestCmdError(t *testing.T) {
 		So(string(tmp_json), ShouldEqual, string(cmp_json))
 	})
 }
+
+func TestExitCode(t *testing.T) {
+	Convey("TestExitCode", t, func() {
+		Convey("derived from code", func() {
+			So(ErrSuccess().ExitCode(), ShouldEqual, EXIT_SUCCESS)
+			So(ErrRpcRetry().ExitCode(), ShouldEqual, EXIT_UNREACHABLE)
+			So(ErrHttpStatus(404).ExitCode(), ShouldEqual, EXIT_UNREACHABLE)
+			So(ErrRpcCall().ExitCode(), ShouldEqual, EXIT_RPC_RESULT)
+			So(ErrHttpResultNoExpected().ExitCode(), ShouldEqual, EXIT_HTTP_RESULT)
+			So(ErrUnknownFsType().ExitCode(), ShouldEqual, EXIT_INTERNAL)
+		})
+
+		Convey("overridden", func() {
+			So(ErrRpcDial().ExitCode(), ShouldEqual, EXIT_UNREACHABLE)
+			So(ErrMdsLeader().ExitCode(), ShouldEqual, EXIT_UNREACHABLE)
+			So(ErrAborted().ExitCode(), ShouldEqual, EXIT_ABORTED)
+			So(ErrCmdTimeout().ExitCode(), ShouldEqual, EXIT_TIMEOUT)
+			So(ErrCmdCanceled().ExitCode(), ShouldEqual, EXIT_INTERRUPTED)
+			So(ErrDeleteFs(int(mds.FSStatusCode_NOT_FOUND)).ExitCode(), ShouldEqual, EXIT_NOT_FOUND)
+			So(ErrDeleteFs(int(mds.FSStatusCode_UNKNOWN_ERROR)).ExitCode(), ShouldEqual, EXIT_RPC_RESULT)
+			So(ErrUmountFs(int(mds.FSStatusCode_MOUNT_POINT_NOT_EXIST)).ExitCode(), ShouldEqual, EXIT_NOT_FOUND)
+			So(ErrDeleteTopology(topology.TopoStatusCode_TOPO_POOL_NOT_FOUND, "pool").ExitCode(), ShouldEqual, EXIT_NOT_FOUND)
+		})
+
+		Convey("from error", func() {
+			So(ExitCode(nil), ShouldEqual, EXIT_SUCCESS)
+			So(ExitCode(fmt.Errorf("unknown flag")), ShouldEqual, EXIT_FAILURE)
+			So(ExitCode(ErrAborted().ToError()), ShouldEqual, EXIT_ABORTED)
+			So(ExitCode(fmt.Errorf("get inode failed: %w", ErrRpcDial())), ShouldEqual, EXIT_UNREACHABLE)
+		})
+
+		Convey("partial", func() {
+			So(PartialCmdError(ErrRpcCall()).ExitCode(), ShouldEqual, EXIT_PARTIAL)
+			So(PartialCmdError(ErrCmdTimeout()).ExitCode(), ShouldEqual, EXIT_TIMEOUT)
+			So(PartialCmdError(ErrSuccess()).ExitCode(), ShouldEqual, EXIT_SUCCESS)
+		})
+	})
+}
+
+func TestMergeCmdError(t *testing.T) {
+	Convey("TestMergeCmdError", t, func() {
+		Convey("no error", func() {
+			So(MergeCmdError(nil).Code, ShouldEqual, CODE_SUCCESS)
+			err := MergeCmdError([]*CmdError{ErrSuccess(), nil})
+			So(err.Code, ShouldEqual, CODE_SUCCESS)
+			So(err.ExitCode(), ShouldEqual, EXIT_SUCCESS)
+		})
+
+		Convey("keep the most important code and all messages", func() {
+			errRpc := ErrRpcCall()
+			errRpc.Message = ""
+			err := MergeCmdError([]*CmdError{ErrRpcDial(), errRpc, ErrMdsLeader()})
+			So(err.Code, ShouldEqual, errRpc.Code)
+			So(err.Message, ShouldEqual, ErrMdsLeader().Message+"\n"+ErrRpcDial().Message)
+			So(err.ExitCode(), ShouldEqual, EXIT_RPC_RESULT)
+		})
+
+		Convey("partial", func() {
+			err := MergeCmdError([]*CmdError{ErrSuccess(), ErrMdsLeader()})
+			So(err.Code, ShouldEqual, ErrMdsLeader().Code)
+			So(err.ExitCode(), ShouldEqual, EXIT_PARTIAL)
+		})
+
+		Convey("the error which is not a CmdError", func() {
+			err := MergeCmdError([]*CmdError{ToCmdError(errors.New("x")), nil})
+			So(err.Code, ShouldEqual, CODE_UNKNOWN)
+			So(err.Message, ShouldEqual, "x")
+			So(err.ExitCode(), ShouldNotEqual, EXIT_SUCCESS)
+		})
+	})
+}
@@ -23,17 +23,15 @@
 package cobrautil
 
 import (
+	"fmt"
+
 	"github.com/gookit/color"
 	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/common"
 	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/copyset"
 	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/heartbeat"
 )
 
-type CopysetInfoStatus struct {
-	Info        *heartbeat.CopySetInfo                    `json:"info,omitempty"`
-	Peer2Status map[string]*copyset.CopysetStatusResponse `json:"peer status,omitempty"`
-}
-
 type COPYSET_HEALTH_STATUS int32
 
 const (
@@ -107,9 +105,8 @@ var (
 //
 // For the state available status of copysetStatus,
 // please refer to CopysetState_Avaliable.
-func CheckCopySetHealth(copysetIS *CopysetInfoStatus) (COPYSET_HEALTH_STATUS, []*cmderror.CmdError) {
-	peers := copysetIS.Info.GetPeers()
-	peer2Status := copysetIS.Peer2Status
+func CheckCopySetHealth(info *heartbeat.CopySetInfo, peer2Status map[string]*copyset.CopysetStatusResponse) (COPYSET_HEALTH_STATUS, []*cmderror.CmdError) {
+	peers := info.GetPeers()
 	avalibalePeerNum := 0
 	var errs []*cmderror.CmdError
 	for addr, status := range peer2Status {
@@ -145,3 +142,12 @@ func CheckCopySetHealth(copysetIS *CopysetInfoStatus) (COPYSET_HEALTH_STATUS, []
 		return COPYSET_ERROR, errs
 	}
 }
+
+// PeerString is like the text of proto, e.g. id:1 address:"127.0.0.1:6801:0",
+// the spaces in the String() of proto are not stable
+func PeerString(peer *common.Peer) string {
+	if peer == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("id:%d address:%q", peer.GetId(), peer.GetAddress())
+}
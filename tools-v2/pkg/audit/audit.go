@@ -0,0 +1,203 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+// Package audit keeps the json-lines records of the rpc which change the cluster
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	REDACTED = "******"
+	// the longest line of a record, the request may be large, e.g. create topology
+	MAX_RECORD_SIZE = 16 * 1024 * 1024
+)
+
+// the last part of the flag or field names which are secret, e.g. s3.sk
+var secretNames = []string{"ak", "sk", "password", "secret", "token"}
+
+// Record is one line of the audit log
+type Record struct {
+	Time    time.Time `json:"time"`
+	User    string    `json:"user"`
+	Host    string    `json:"host"`
+	Command string    `json:"command"`
+	Rpc     string    `json:"rpc"`
+	Mds     []string  `json:"mds"`
+	// the request in json, the secrets are redacted
+	Request interface{} `json:"request"`
+	// the status code of the response, empty if the rpc fails
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+var fileMutex sync.Mutex
+
+// NewRecord returns the record with the time, user and host filled
+func NewRecord() Record {
+	record := Record{Time: time.Now()}
+	if u, err := user.Current(); err == nil {
+		record.User = u.Username
+	} else {
+		record.User = os.Getenv("USER")
+	}
+	record.Host, _ = os.Hostname()
+	return record
+}
+
+// IsSecret reports whether the flag or field name is a secret, e.g. s3.sk or password
+func IsSecret(name string) bool {
+	if index := strings.LastIndex(name, "."); index >= 0 {
+		name = name[index+1:]
+	}
+	for _, secret := range secretNames {
+		if strings.EqualFold(name, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact replaces the secret fields in the value decoded from json
+func Redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			if IsSecret(key) {
+				ret[key] = REDACTED
+			} else {
+				ret[key] = Redact(field)
+			}
+		}
+		return ret
+	case []interface{}:
+		ret := make([]interface{}, len(v))
+		for i, item := range v {
+			ret[i] = Redact(item)
+		}
+		return ret
+	default:
+		return value
+	}
+}
+
+// Append writes the record as a line at the end of file,
+// the file and its dir are only accessible by the user.
+func Append(path string, record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	fileMutex.Lock()
+	defer fileMutex.Unlock()
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(line, '\n'))
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	return err
+}
+
+// Read returns the records in file, no record if it does not exist
+func Read(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), MAX_RECORD_SIZE)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("invalid record at %s:%d: %w", path, line, err)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// Filter selects the records, the zero value matches all
+type Filter struct {
+	Since time.Time
+	Until time.Time
+	User  string
+	// the rpc name, e.g. DeleteFs
+	Rpc string
+	// the substring of the command line, e.g. "delete fs"
+	Command string
+}
+
+func (f *Filter) Match(record Record) bool {
+	if !f.Since.IsZero() && record.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && record.Time.After(f.Until) {
+		return false
+	}
+	if f.User != "" && record.User != f.User {
+		return false
+	}
+	if f.Rpc != "" && !strings.EqualFold(record.Rpc, f.Rpc) {
+		return false
+	}
+	return f.Command == "" || strings.Contains(record.Command, f.Command)
+}
+
+// ParseTime parses the time like 2022-08-01T15:04:05+08:00, 2022-08-01 or
+// the duration before now like 24h
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %s, it should be like 24h, 2006-01-02 or %s", value, time.RFC3339)
+}
@@ -0,0 +1,114 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRedact(t *testing.T) {
+	Convey("the secrets are redacted", t, func() {
+		So(IsSecret("s3.sk"), ShouldBeTrue)
+		So(IsSecret("volume.password"), ShouldBeTrue)
+		So(IsSecret("fsname"), ShouldBeFalse)
+
+		request := map[string]interface{}{
+			"fsName": "test",
+			"fsDetail": map[string]interface{}{
+				"s3Info": map[string]interface{}{"ak": "a", "sk": "s", "endpoint": "e"},
+			},
+			"volumes": []interface{}{map[string]interface{}{"password": "p"}},
+		}
+		So(Redact(request), ShouldResemble, map[string]interface{}{
+			"fsName": "test",
+			"fsDetail": map[string]interface{}{
+				"s3Info": map[string]interface{}{"ak": REDACTED, "sk": REDACTED, "endpoint": "e"},
+			},
+			"volumes": []interface{}{map[string]interface{}{"password": REDACTED}},
+		})
+		// the original one is not changed
+		So(request["volumes"].([]interface{})[0].(map[string]interface{})["password"], ShouldEqual, "p")
+	})
+}
+
+func TestAppendRead(t *testing.T) {
+	Convey("the records are appended as lines", t, func() {
+		path := filepath.Join(t.TempDir(), "dir", "audit.log")
+		records, err := Read(path)
+		So(err, ShouldBeNil)
+		So(records, ShouldBeEmpty)
+
+		first := NewRecord()
+		first.Rpc = "DeleteFs"
+		first.Request = map[string]interface{}{"fsName": "test"}
+		So(Append(path, first), ShouldBeNil)
+		second := NewRecord()
+		second.Rpc = "UmountFs"
+		So(Append(path, second), ShouldBeNil)
+
+		info, err := os.Stat(path)
+		So(err, ShouldBeNil)
+		So(info.Mode().Perm(), ShouldEqual, os.FileMode(0600))
+
+		records, err = Read(path)
+		So(err, ShouldBeNil)
+		So(records, ShouldHaveLength, 2)
+		So(records[0].Rpc, ShouldEqual, "DeleteFs")
+		So(records[0].User, ShouldEqual, first.User)
+		So(records[0].Request, ShouldResemble, map[string]interface{}{"fsName": "test"})
+		So(records[1].Rpc, ShouldEqual, "UmountFs")
+	})
+}
+
+func TestFilter(t *testing.T) {
+	Convey("the records are filtered", t, func() {
+		now := time.Date(2022, 8, 1, 15, 0, 0, 0, time.Local)
+		record := Record{
+			Time:    now.Add(-time.Hour),
+			User:    "curve",
+			Rpc:     "DeleteFs",
+			Command: "curve fs delete fs --fsname=test",
+		}
+		So((&Filter{}).Match(record), ShouldBeTrue)
+		So((&Filter{User: "curve", Rpc: "deletefs", Command: "delete fs"}).Match(record), ShouldBeTrue)
+		So((&Filter{User: "root"}).Match(record), ShouldBeFalse)
+		So((&Filter{Command: "umount"}).Match(record), ShouldBeFalse)
+
+		since, err := ParseTime("30m", now)
+		So(err, ShouldBeNil)
+		So((&Filter{Since: since}).Match(record), ShouldBeFalse)
+		until, err := ParseTime("2022-08-01", now)
+		So(err, ShouldBeNil)
+		So((&Filter{Until: until}).Match(record), ShouldBeFalse)
+		since, err = ParseTime("2022-08-01T13:00:00+08:00", now)
+		So(err, ShouldBeNil)
+		So(since.Equal(time.Date(2022, 8, 1, 5, 0, 0, 0, time.UTC)), ShouldBeTrue)
+
+		_, err = ParseTime("yesterday", now)
+		So(err, ShouldNotBeNil)
+	})
+}
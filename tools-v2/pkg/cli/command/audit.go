@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package basecmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/audit"
+	config "github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	STATUS_CODE_FIELD = "statusCode"
+)
+
+type auditKey struct{}
+
+// newAuditContext keeps the command line of the audit records in ctx
+func newAuditContext(ctx context.Context, commandLine string) context.Context {
+	return context.WithValue(ctx, auditKey{}, commandLine)
+}
+
+// auditCommandLine is the command and the flags set by user, the secrets are redacted,
+// e.g. curve fs create fs --fsname=test --s3.sk=******
+func auditCommandLine(cmd *cobra.Command) string {
+	parts := []string{cmd.CommandPath()}
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		value := flag.Value.String()
+		if audit.IsSecret(flag.Name) {
+			value = audit.REDACTED
+		} else if value == "" || strings.ContainsAny(value, " \t\"'") {
+			value = strconv.Quote(value)
+		}
+		parts = append(parts, fmt.Sprintf("--%s=%s", flag.Name, value))
+	})
+	parts = append(parts, cmd.Flags().Args()...)
+	return strings.Join(parts, " ")
+}
+
+// the name of the status code in response, e.g. TOPO_OK
+func responseStatus(response interface{}) string {
+	message, ok := response.(proto.Message)
+	if !ok || message == nil {
+		return ""
+	}
+	reflectMessage := message.ProtoReflect()
+	field := reflectMessage.Descriptor().Fields().ByName(STATUS_CODE_FIELD)
+	if field == nil {
+		return ""
+	}
+	value := reflectMessage.Get(field)
+	if field.Kind() != protoreflect.EnumKind {
+		return value.String()
+	}
+	number := value.Enum()
+	if enumValue := field.Enum().Values().ByNumber(number); enumValue != nil {
+		return string(enumValue.Name())
+	}
+	return strconv.Itoa(int(number))
+}
+
+// auditRpc appends the record of the mutating rpc to the audit log,
+// the failure is warned but does not fail the command
+func auditRpc(ctx context.Context, rpc *Rpc, rpcFunc RpcFunc, response interface{}, err *cmderror.CmdError, duration time.Duration) {
+	record := audit.NewRecord()
+	record.Command, _ = ctx.Value(auditKey{}).(string)
+	record.Rpc = rpc.RpcFuncName
+	record.Mds = rpc.Addrs
+	record.Request = audit.Redact(rpcRequest(rpcFunc))
+	record.Status = responseStatus(response)
+	if err != nil && err.TypeCode() != cmderror.CODE_SUCCESS {
+		record.Error = err.Message
+	}
+	record.Duration = duration.String()
+
+	path, errPath := config.GetAuditLogPath()
+	if errPath == nil {
+		errPath = audit.Append(path, record)
+	}
+	if errPath != nil {
+		errAudit := cmderror.ErrAuditLog()
+		errAudit.Format(rpc.RpcFuncName, errPath.Error())
+		cmderror.FromContext(ctx).Add(cmderror.ErrorEntry{Err: errAudit, Rpc: rpc.RpcFuncName})
+		fmt.Fprintln(os.Stderr, "Warning:", errAudit.Message)
+	}
+}
@@ -0,0 +1,50 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package audit
+
+import (
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/audit/show"
+	"github.com/spf13/cobra"
+)
+
+type AuditCommand struct {
+	basecmd.MidCurveCmd
+}
+
+var _ basecmd.MidCurveCmdFunc = (*AuditCommand)(nil) // check interface
+
+func (auditCmd *AuditCommand) AddSubCommands() {
+	auditCmd.Cmd.AddCommand(
+		show.NewShowCommand(),
+	)
+}
+
+func NewAuditCommand() *cobra.Command {
+	auditCmd := &AuditCommand{
+		basecmd.MidCurveCmd{
+			Use:   "audit",
+			Short: "manage the audit log of the operations which change the cluster",
+		},
+	}
+	return basecmd.NewMidCurveCli(&auditCmd.MidCurveCmd, auditCmd)
+}
@@ -0,0 +1,146 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package show
+
+import (
+	"strings"
+	"time"
+
+	"github.com/liushuochen/gotable"
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/audit"
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ROW_TIME     = "time"
+	ROW_USER     = "user"
+	ROW_HOST     = "host"
+	ROW_COMMAND  = "command"
+	ROW_RPC      = "rpc"
+	ROW_MDS      = "mds"
+	ROW_STATUS   = "status"
+	ROW_DURATION = "duration"
+)
+
+const (
+	showExample = `$ curve audit show --since 24h --rpc DeleteFs
+$ curve audit show --user curve --command "create topology" --format json`
+)
+
+type ShowCommand struct {
+	basecmd.FinalCurveCmd
+	path   string
+	filter audit.Filter
+}
+
+var _ basecmd.FinalCurveCmdFunc = (*ShowCommand)(nil) // check interface
+
+func NewShowCommand() *cobra.Command {
+	showCmd := &ShowCommand{
+		FinalCurveCmd: basecmd.FinalCurveCmd{
+			Use:     "show",
+			Short:   "show the records of the operations which change the cluster",
+			Example: showExample,
+		},
+	}
+	basecmd.NewFinalCurveCli(&showCmd.FinalCurveCmd, showCmd)
+	return showCmd.Cmd
+}
+
+func (sCmd *ShowCommand) AddFlags() {
+	config.AddAuditLogFlag(sCmd.Cmd)
+	config.AddAuditFilterFlags(sCmd.Cmd)
+}
+
+func (sCmd *ShowCommand) Init(cmd *cobra.Command, args []string) error {
+	// --auditlog is bound to the config
+	path, err := config.GetAuditLogPath()
+	if err != nil {
+		return err
+	}
+	sCmd.path = path
+
+	now := time.Now()
+	since, _ := sCmd.Cmd.Flags().GetString(config.AUDIT_SINCE)
+	sCmd.filter.Since, err = audit.ParseTime(since, now)
+	if err != nil {
+		return err
+	}
+	until, _ := sCmd.Cmd.Flags().GetString(config.AUDIT_UNTIL)
+	sCmd.filter.Until, err = audit.ParseTime(until, now)
+	if err != nil {
+		return err
+	}
+	sCmd.filter.User, _ = sCmd.Cmd.Flags().GetString(config.AUDIT_USER)
+	sCmd.filter.Rpc, _ = sCmd.Cmd.Flags().GetString(config.AUDIT_RPC)
+	sCmd.filter.Command, _ = sCmd.Cmd.Flags().GetString(config.AUDIT_COMMAND)
+
+	table, err := gotable.Create(ROW_TIME, ROW_USER, ROW_HOST, ROW_COMMAND, ROW_RPC, ROW_MDS, ROW_STATUS, ROW_DURATION)
+	if err != nil {
+		return err
+	}
+	sCmd.Table = table
+	return nil
+}
+
+func (sCmd *ShowCommand) Print(cmd *cobra.Command, args []string) error {
+	return output.FinalCmdOutput(&sCmd.FinalCurveCmd, sCmd)
+}
+
+func (sCmd *ShowCommand) RunCommand(cmd *cobra.Command, args []string) error {
+	records, err := audit.Read(sCmd.path)
+	if err != nil {
+		return err
+	}
+	result := make([]audit.Record, 0)
+	for _, record := range records {
+		if !sCmd.filter.Match(record) {
+			continue
+		}
+		result = append(result, record)
+		status := record.Status
+		if record.Error != "" {
+			status = record.Error
+		}
+		sCmd.Table.AddRow(map[string]string{
+			ROW_TIME:     record.Time.Local().Format("2006-01-02 15:04:05"),
+			ROW_USER:     record.User,
+			ROW_HOST:     record.Host,
+			ROW_COMMAND:  record.Command,
+			ROW_RPC:      record.Rpc,
+			ROW_MDS:      strings.Join(record.Mds, ","),
+			ROW_STATUS:   status,
+			ROW_DURATION: record.Duration,
+		})
+	}
+	sCmd.Result = result
+	sCmd.Error = cmderror.ErrSuccess()
+	return nil
+}
+
+func (sCmd *ShowCommand) ResultPlainOutput() error {
+	return output.FinalCmdOutputPlain(&sCmd.FinalCurveCmd, sCmd)
+}
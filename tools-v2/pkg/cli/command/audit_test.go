@@ -0,0 +1,83 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package basecmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/opencurve/curve/tools-v2/pkg/audit"
+	config "github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/mds"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestAudit(t *testing.T) {
+	Convey("the command line is redacted", t, func() {
+		root := &cobra.Command{Use: "curve"}
+		cmd := &cobra.Command{Use: "create", Run: func(cmd *cobra.Command, args []string) {}}
+		cmd.Flags().String("fsname", "", "")
+		cmd.Flags().String("s3.sk", "", "")
+		cmd.Flags().String("user", "", "")
+		root.AddCommand(cmd)
+		root.SetArgs([]string{"create", "--fsname", "test", "--s3.sk", "secret", "--user", "a b"})
+		So(root.Execute(), ShouldBeNil)
+		So(auditCommandLine(cmd), ShouldEqual, `curve create --fsname=test --s3.sk=****** --user="a b"`)
+	})
+
+	Convey("the status code of response", t, func() {
+		code := mds.FSStatusCode_NOT_FOUND
+		So(responseStatus(&mds.DeleteFsResponse{StatusCode: &code}), ShouldEqual, "NOT_FOUND")
+		So(responseStatus(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}), ShouldEqual, "")
+		So(responseStatus(nil), ShouldEqual, "")
+	})
+
+	Convey("the mutating rpc is recorded", t, func() {
+		server, addr := startHealthServer()
+		defer server.Stop()
+		path := filepath.Join(t.TempDir(), "audit.log")
+		viper.Set(config.VIPER_GLOBALE_AUDITLOG, path)
+		defer viper.Set(config.VIPER_GLOBALE_AUDITLOG, "")
+
+		ctx := newAuditContext(context.Background(), "curve check --s3.sk=******")
+		rpc := &mutatingHealthRpc{Request: &healthpb.HealthCheckRequest{Service: "curvefs"}}
+		_, err := GetRpcResponse(ctx, NewRpc([]string{addr}, time.Second, 0, "Check"), rpc)
+		So(err.Message, ShouldEqual, "success")
+		_, err = GetRpcResponse(ctx, NewRpc([]string{addr}, time.Second, 0, "Check"), &healthRpc{})
+		So(err.Message, ShouldEqual, "success")
+
+		records, errRead := audit.Read(path)
+		So(errRead, ShouldBeNil)
+		So(records, ShouldHaveLength, 1)
+		So(records[0].Command, ShouldEqual, "curve check --s3.sk=******")
+		So(records[0].Rpc, ShouldEqual, "Check")
+		So(records[0].Mds, ShouldResemble, []string{addr})
+		So(records[0].Request, ShouldResemble, map[string]interface{}{"service": "curvefs"})
+		So(records[0].Error, ShouldBeEmpty)
+		So(records[0].Duration, ShouldNotBeEmpty)
+	})
+}
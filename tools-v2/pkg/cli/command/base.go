@@ -24,23 +24,15 @@ package basecmd
 
 import (
 	"context"
-	"encoding/json"
-	"io/ioutil"
-	"net/http"
-	"strings"
-	"time"
+	"fmt"
 
 	"github.com/liushuochen/gotable/table"
 	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
 	cobrautil "github.com/opencurve/curve/tools-v2/internal/utils"
 	config "github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/pkg/tracing"
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-)
-
-const (
-	CURL_VERSION = "curl/7.54.0"
+	"github.com/spf13/viper"
 )
 
 // FinalCurveCmd is the final executable command,
@@ -49,14 +41,21 @@ const (
 // Error Use to indicate whether the command is wrong
 // and the reason for the execution error
 type FinalCurveCmd struct {
-	Use     string            `json:"-"`
-	Short   string            `json:"-"`
-	Long    string			`json:"-"`
-	Example string            `json:"-"`
-	Error   *cmderror.CmdError `json:"error"`
-	Result  interface{}       `json:"result"`
-	Table   *table.Table      `json:"-"`
-	Cmd     *cobra.Command    `json:"-"`
+	Use      string             `json:"-"`
+	Short    string             `json:"-"`
+	Long     string             `json:"-"`
+	Example  string             `json:"-"`
+	Error    *cmderror.CmdError `json:"error"`
+	Result   interface{}        `json:"result"`
+	ExitCode int                `json:"exitCode"`
+	Table    *table.Table       `json:"-"`
+	Cmd      *cobra.Command     `json:"-"`
+	// all errors happened in this run, they are shown by --showerror
+	Errors *cmderror.Collector `json:"-"`
+	// the table printed by the last run of --watch, the changed rows are highlighted
+	LastTable *table.Table `json:"-"`
+	// the span of this run with --trace-file
+	span *tracing.Span
 }
 
 // FinalCurveCmdFunc is the function type for final command
@@ -84,249 +83,113 @@ type MidCurveCmdFunc interface {
 }
 
 func NewFinalCurveCli(cli *FinalCurveCmd, funcs FinalCurveCmdFunc) *cobra.Command {
+	// the context without the errors of a run
+	var baseCtx context.Context
 	cli.Cmd = &cobra.Command{
-		Use:          cli.Use,
-		Short:        cli.Short,
-		Long:         cli.Long,
-		PreRunE:      funcs.Init,
-		RunE:         funcs.RunCommand,
-		PostRunE:     funcs.Print,
+		Use:     cli.Use,
+		Short:   cli.Short,
+		Long:    cli.Long,
+		Example: cli.Example,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			baseCtx = cmd.Context()
+			cli.newRun(baseCtx)
+			return cli.endRunIfFailed(funcs.Init(cmd, args))
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval := cli.watchInterval(); interval > 0 {
+				return cli.watch(baseCtx, funcs, args, interval)
+			}
+			return cli.endRunIfFailed(cli.runCommand(funcs, args))
+		},
+		PostRunE: func(cmd *cobra.Command, args []string) error {
+			if cli.watchInterval() > 0 {
+				// printed by every run of watch
+				return nil
+			}
+			err := funcs.Print(cmd, args)
+			cli.endRun(err)
+			return err
+		},
 		SilenceUsage: false,
 	}
-	config.AddFormatFlag(cli.Cmd)
 	funcs.AddFlags()
-	return cli.Cmd
-}
-
-func NewMidCurveCli(cli *MidCurveCmd, add MidCurveCmdFunc) *cobra.Command {
-	cli.Cmd = &cobra.Command{
-		Use:   cli.Use,
-		Short: cli.Short,
-		Args:  cobrautil.NoArgs,
+	// the command may add its own format flag, e.g. run uses -f for the file
+	if cli.Cmd.Flags().Lookup(config.FORMAT) == nil {
+		config.AddFormatFlag(cli.Cmd)
 	}
-	add.AddSubCommands()
 	return cli.Cmd
 }
 
-type Metric struct {
-	Addrs   []string
-	SubUri  string
-	timeout time.Duration
-}
-
-type MetricResult struct {
-	Addr  string
-	Key   string
-	Value string
-	Err   *cmderror.CmdError
-}
-
-func NewMetric(addrs []string, subUri string, timeout time.Duration) *Metric {
-	return &Metric{
-		Addrs:   addrs,
-		SubUri:  subUri,
-		timeout: timeout,
-	}
-}
-
-func QueryMetric(m Metric) (string, *cmderror.CmdError) {
-	response := make(chan string, 1)
-	size := len(m.Addrs)
-	if size > config.MaxChannelSize() {
-		size = config.MaxChannelSize()
-	}
-	errs := make(chan *cmderror.CmdError, size)
-	for _, host := range m.Addrs {
-		url := "http://" + host + m.SubUri
-		go httpGet(url, m.timeout, response, errs)
-	}
-	var retStr string
-	var vecErrs []*cmderror.CmdError
-	count := 0
-	for err := range errs {
-		if err.Code != cmderror.CODE_SUCCESS {
-			vecErrs = append(vecErrs, err)
-		} else {
-			retStr = <-response
-			vecErrs = append(vecErrs, cmderror.ErrSuccess())
-			break
-		}
-		count++
-		if count >= len(m.Addrs) {
-			// all host failed
-			break
-		}
+// every run has its own errors, including the nested one
+func (fc *FinalCurveCmd) newRun(ctx context.Context) {
+	fc.Errors = cmderror.NewCollector()
+	ctx = cmderror.NewContext(ctx, fc.Errors)
+	commandLine := auditCommandLine(fc.Cmd)
+	ctx = newAuditContext(ctx, commandLine)
+	if fc.dryRunEnabled() {
+		ctx = NewDryRunContext(ctx)
 	}
-	retErr := cmderror.MostImportantCmdError(vecErrs)
-	return retStr, retErr
+	// the nested commands are traced as the children of this one
+	ctx, fc.span = startSpan(ctx, fc.Cmd.CommandPath(), tracing.SPAN_KIND_INTERNAL)
+	fc.span.SetAttribute("curve.command_line", commandLine)
+	fc.Cmd.SetContext(ctx)
 }
 
-func GetMetricValue(metricRet string) (string, *cmderror.CmdError) {
-	kv := cobrautil.RmWitespaceStr(metricRet)
-	kvVec := strings.Split(kv, ":")
-	if len(kvVec) != 2 {
-		err := cmderror.ErrParseMetric()
-		err.Format(metricRet)
-		return "", err
+// endRun ends the span of the run, err is the error of Init, RunCommand or Print
+func (fc *FinalCurveCmd) endRun(err error) {
+	if fc.span == nil {
+		return
 	}
-	kvVec[1] = strings.Replace(kvVec[1], "\"", "", -1)
-	return kvVec[1], cmderror.ErrSuccess()
-}
-
-func GetKeyValueFromJsonMetric(metricRet string, key string) (string, *cmderror.CmdError) {
-	var data map[string]interface{}
-	if err := json.Unmarshal([]byte(metricRet), &data); err != nil {
-		err := cmderror.ErrParseMetric()
-		err.Format(metricRet)
-		return "", err
+	if err != nil {
+		fc.span.SetError(err.Error())
 	}
-	return data[key].(string), cmderror.ErrSuccess()
+	endSpan(fc.span, fc.Error)
+	fc.span = nil
 }
 
-func httpGet(url string, timeout time.Duration, response chan string, errs chan *cmderror.CmdError) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// in dry-run mode, the result is the mutating rpc which are not sent
+func (fc *FinalCurveCmd) runCommand(funcs FinalCurveCmdFunc, args []string) error {
+	err := funcs.RunCommand(fc.Cmd, args)
 	if err != nil {
-		interErr := cmderror.ErrHttpCreateGetRequest()
-		interErr.Format(err.Error())
-		errs <- interErr
-	}
-	// for get curl url
-	req.Header.Set("User-Agent", CURL_VERSION)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	resp, err := client.Do(req)
-	if err != nil {
-		interErr := cmderror.ErrHttpClient()
-		interErr.Format(err.Error())
-		errs <- interErr
-	} else if resp.StatusCode != http.StatusOK {
-		statusErr := cmderror.ErrHttpStatus(resp.StatusCode)
-		statusErr.Format(url, resp.StatusCode)
-		errs <- statusErr
-	} else {
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			interErr := cmderror.ErrHttpUnreadableResult()
-			interErr.Format(url, err.Error())
-			errs <- interErr
-		}
-		// get response
-		response <- string(body)
-		errs <- cmderror.ErrSuccess()
+		return err
 	}
+	return fc.setDryRunResult()
 }
 
-type Rpc struct {
-	Addrs         []string
-	RpcTimeout    time.Duration
-	RpcRetryTimes int32
-	RpcFuncName   string
-}
-
-func NewRpc(addrs []string, timeout time.Duration, retryTimes int32, funcName string) *Rpc {
-	return &Rpc{
-		Addrs:         addrs,
-		RpcTimeout:    timeout,
-		RpcRetryTimes: retryTimes,
-		RpcFuncName:   funcName,
+// ErrorEntries returns the errors collected in the run and the error of the command
+func (fc *FinalCurveCmd) ErrorEntries() []cmderror.ErrorEntry {
+	entries := fc.Errors.Entries()
+	if fc.Error != nil && fc.Error.TypeCode() != cmderror.CODE_SUCCESS && !fc.Errors.Contains(fc.Error) {
+		entries = append(entries, cmderror.ErrorEntry{Err: fc.Error})
 	}
+	return entries
 }
 
-type RpcFunc interface {
-	NewRpcClient(cc grpc.ClientConnInterface)
-	Stub_Func(ctx context.Context) (interface{}, error)
-}
-
-func GetRpcResponse(rpc *Rpc, rpcFunc RpcFunc) (interface{}, *cmderror.CmdError) {
-	size := len(rpc.Addrs)
-	if size > config.MaxChannelSize() {
-		size = config.MaxChannelSize()
+// ShowErrors prints the errors of the run grouped by host and rpc if --showerror is set,
+// the nested command with noout format prints nothing
+func (fc *FinalCurveCmd) ShowErrors() {
+	if !viper.GetBool(config.VIPER_GLOBALE_SHOWERROR) ||
+		fc.Cmd.Flag(config.FORMAT).Value.String() == config.FORMAT_NOOUT {
+		return
 	}
-	errs := make(chan *cmderror.CmdError, size)
-	response := make(chan interface{}, 1)
-	for _, addr := range rpc.Addrs {
-		go func(addr string) {
-			ctx, cancel := context.WithTimeout(context.Background(), rpc.RpcTimeout)
-			defer cancel()
-			conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-			if err != nil {
-				errDial := cmderror.ErrRpcDial()
-				errDial.Format(addr, err.Error())
-				errs <- errDial
-			}
-			rpcFunc.NewRpcClient(conn)
-			res, err := rpcFunc.Stub_Func(context.Background())
-			if err != nil {
-				// fmt.Println(err)
-				errRpc := cmderror.ErrRpcCall()
-				errRpc.Format(addr, rpc.RpcFuncName, err.Error())
-				errs <- errRpc
-			} else {
-				response <- res
-				errs <- cmderror.ErrSuccess()
-			}
-		}(addr)
-	}
-
-	var ret interface{}
-	var vecErrs []*cmderror.CmdError
-	count := 0
-	for err := range errs {
-		if err.Code != cmderror.CODE_SUCCESS {
-			vecErrs = append(vecErrs, err)
-		} else {
-			ret = <-response
-			vecErrs = append(vecErrs, cmderror.ErrSuccess())
-			break
-		}
-		count++
-		if count >= len(rpc.Addrs) {
-			// all host failed
-			break
-		}
-	}
-	retErr := cmderror.MostImportantCmdError(vecErrs)
-	return ret, retErr
+	fmt.Fprint(fc.Cmd.ErrOrStderr(), cmderror.ErrorTree(fc.ErrorEntries()))
 }
 
-type RpcResult struct {
-	Response interface{}
-	Error *cmderror.CmdError
-}
-
-func GetRpcListResponse(rpcList []*Rpc, rpcFunc []RpcFunc) ([]interface{}, *cmderror.CmdError) {
-	chanSize := len(rpcList)
-	if chanSize > config.MaxChannelSize() {
-		chanSize = config.MaxChannelSize()
-	}
-	results := make(chan RpcResult, chanSize)
-	size := 0
-	for i := range rpcList {
-		size++
-		go func(rpc *Rpc, rpcFunc RpcFunc) {
-			res, err := GetRpcResponse(rpc, rpcFunc)
-			results <- RpcResult{res, err}
-		} (rpcList[i], rpcFunc[i])
+// Print is skipped if Init or RunCommand fails, so show the errors and end the run here
+func (fc *FinalCurveCmd) endRunIfFailed(err error) error {
+	if err != nil {
+		fc.ShowErrors()
+		fc.endRun(err)
 	}
+	return err
+}
 
-	count := 0
-	var retRes []interface{}
-	var vecErrs []*cmderror.CmdError
-	for res := range results {
-		if res.Error.TypeCode() != cmderror.CODE_SUCCESS {
-			// get fail
-			vecErrs = append(vecErrs, res.Error)
-		} else {
-			retRes = append(retRes, res.Response)
-		}
-
-		count++
-		if count >= size {
-			// get all rpc response
-			break
-		}
+func NewMidCurveCli(cli *MidCurveCmd, add MidCurveCmdFunc) *cobra.Command {
+	cli.Cmd = &cobra.Command{
+		Use:   cli.Use,
+		Short: cli.Short,
+		Args:  cobrautil.NoArgs,
 	}
-	retErr := cmderror.MergeCmdError(vecErrs)
-	return retRes, &retErr
+	add.AddSubCommands()
+	return cli.Cmd
 }
@@ -0,0 +1,62 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package config
+
+import (
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/config/currentcontext"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/config/getcontexts"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/config/initconfig"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/config/set"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/config/usecontext"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/config/validate"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/config/view"
+	"github.com/spf13/cobra"
+)
+
+type ConfigCommand struct {
+	basecmd.MidCurveCmd
+}
+
+var _ basecmd.MidCurveCmdFunc = (*ConfigCommand)(nil) // check interface
+
+func (configCmd *ConfigCommand) AddSubCommands() {
+	configCmd.Cmd.AddCommand(
+		initconfig.NewInitCommand(),
+		view.NewViewCommand(),
+		set.NewSetCommand(),
+		validate.NewValidateCommand(),
+		usecontext.NewUseContextCommand(),
+		getcontexts.NewGetContextsCommand(),
+		currentcontext.NewCurrentContextCommand(),
+	)
+}
+
+func NewConfigCommand() *cobra.Command {
+	configCmd := &ConfigCommand{
+		basecmd.MidCurveCmd{
+			Use:   "config",
+			Short: "manage the config file of curve tool",
+		},
+	}
+	return basecmd.NewMidCurveCli(&configCmd.MidCurveCmd, configCmd)
+}
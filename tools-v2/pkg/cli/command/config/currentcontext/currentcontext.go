@@ -0,0 +1,79 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package currentcontext
+
+import (
+	"fmt"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	currentContextExample = `$ curve config current-context
+$ curve config current-context --context staging`
+)
+
+type CurrentContextCommand struct {
+	basecmd.FinalCurveCmd
+}
+
+var _ basecmd.FinalCurveCmdFunc = (*CurrentContextCommand)(nil) // check interface
+
+func NewCurrentContextCommand() *cobra.Command {
+	currentCmd := &CurrentContextCommand{
+		FinalCurveCmd: basecmd.FinalCurveCmd{
+			Use:     "current-context",
+			Short:   "show the context in use",
+			Example: currentContextExample,
+		},
+	}
+	return basecmd.NewFinalCurveCli(&currentCmd.FinalCurveCmd, currentCmd)
+}
+
+func (cCmd *CurrentContextCommand) AddFlags() {
+}
+
+func (cCmd *CurrentContextCommand) Init(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (cCmd *CurrentContextCommand) Print(cmd *cobra.Command, args []string) error {
+	return output.FinalCmdOutput(&cCmd.FinalCurveCmd, cCmd)
+}
+
+func (cCmd *CurrentContextCommand) RunCommand(cmd *cobra.Command, args []string) error {
+	cCmd.Result = config.ActiveContext()
+	cCmd.Error = cmderror.ErrSuccess()
+	return nil
+}
+
+// the name only, empty if no context is used
+func (cCmd *CurrentContextCommand) ResultPlainOutput() error {
+	if name := config.ActiveContext(); name != "" {
+		fmt.Fprintln(cCmd.Cmd.OutOrStdout(), name)
+	}
+	return nil
+}
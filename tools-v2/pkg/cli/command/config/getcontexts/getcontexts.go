@@ -0,0 +1,116 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package getcontexts
+
+import (
+	"github.com/liushuochen/gotable"
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	ROW_CURRENT         = "current"
+	ROW_NAME            = "name"
+	ROW_CURVEFS_MDSADDR = "curvefs mds addr"
+	ROW_CURVEBS_MDSADDR = "curvebs mds addr"
+)
+
+const (
+	getContextsExample = `$ curve config get-contexts`
+)
+
+// Context is a context in config file
+type Context struct {
+	Name           string `json:"name"`
+	Current        bool   `json:"current"`
+	CurvefsMdsAddr string `json:"curvefsMdsAddr"`
+	CurvebsMdsAddr string `json:"curvebsMdsAddr"`
+}
+
+type GetContextsCommand struct {
+	basecmd.FinalCurveCmd
+}
+
+var _ basecmd.FinalCurveCmdFunc = (*GetContextsCommand)(nil) // check interface
+
+func NewGetContextsCommand() *cobra.Command {
+	getCmd := &GetContextsCommand{
+		FinalCurveCmd: basecmd.FinalCurveCmd{
+			Use:     "get-contexts",
+			Short:   "list the contexts in config file",
+			Example: getContextsExample,
+		},
+	}
+	return basecmd.NewFinalCurveCli(&getCmd.FinalCurveCmd, getCmd)
+}
+
+func (gCmd *GetContextsCommand) AddFlags() {
+}
+
+func (gCmd *GetContextsCommand) Init(cmd *cobra.Command, args []string) error {
+	table, err := gotable.Create(ROW_CURRENT, ROW_NAME, ROW_CURVEFS_MDSADDR, ROW_CURVEBS_MDSADDR)
+	if err != nil {
+		return err
+	}
+	gCmd.Table = table
+	return nil
+}
+
+func (gCmd *GetContextsCommand) Print(cmd *cobra.Command, args []string) error {
+	return output.FinalCmdOutput(&gCmd.FinalCurveCmd, gCmd)
+}
+
+func (gCmd *GetContextsCommand) RunCommand(cmd *cobra.Command, args []string) error {
+	active := config.ActiveContext()
+	result := make([]Context, 0)
+	for _, name := range config.ContextNames() {
+		prefix := config.VIPER_CONTEXTS + "." + name + "."
+		context := Context{
+			Name:           name,
+			Current:        name == active,
+			CurvefsMdsAddr: viper.GetString(prefix + config.VIPER_CURVEFS_MDSADDR),
+			CurvebsMdsAddr: viper.GetString(prefix + config.VIPER_CURVEBS_MDSADDR),
+		}
+		result = append(result, context)
+		current := ""
+		if context.Current {
+			current = "*"
+		}
+		gCmd.Table.AddRow(map[string]string{
+			ROW_CURRENT:         current,
+			ROW_NAME:            context.Name,
+			ROW_CURVEFS_MDSADDR: context.CurvefsMdsAddr,
+			ROW_CURVEBS_MDSADDR: context.CurvebsMdsAddr,
+		})
+	}
+	gCmd.Result = result
+	gCmd.Error = cmderror.ErrSuccess()
+	return nil
+}
+
+func (gCmd *GetContextsCommand) ResultPlainOutput() error {
+	return output.FinalCmdOutputPlain(&gCmd.FinalCurveCmd, gCmd)
+}
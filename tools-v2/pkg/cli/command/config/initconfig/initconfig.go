@@ -0,0 +1,105 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+package initconfig
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/liushuochen/gotable"
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ROW_FILE = "file"
+)
+
+const (
+	initExample = `$ curve config init
+$ curve config init -c ./curve.yaml --force`
+)
+
+type InitCommand struct {
+	basecmd.FinalCurveCmd
+	path string
+}
+
+var _ basecmd.FinalCurveCmdFunc = (*InitCommand)(nil) // check interface
+
+func NewInitCommand() *cobra.Command {
+	initCmd := &InitCommand{
+		FinalCurveCmd: basecmd.FinalCurveCmd{
+			Use:     "init",
+			Short:   "write the template config to $HOME/.curve/curve.yaml or the file given by --conf",
+			Example: initExample,
+		},
+	}
+	return basecmd.NewFinalCurveCli(&initCmd.FinalCurveCmd, initCmd)
+}
+
+func (iCmd *InitCommand) AddFlags() {
+	config.AddConfigForceFlag(iCmd.Cmd)
+}
+
+func (iCmd *InitCommand) Init(cmd *cobra.Command, args []string) error {
+	path, err := config.GetUserConfigFilePath()
+	if err != nil {
+		return err
+	}
+	iCmd.path = path
+	force, _ := iCmd.Cmd.Flags().GetBool(config.CONFIG_FORCE)
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("config file %s already exists, use --%s to overwrite it", path, config.CONFIG_FORCE)
+	}
+
+	table, err := gotable.Create(ROW_FILE)
+	if err != nil {
+		return err
+	}
+	iCmd.Table = table
+	return nil
+}
+
+func (iCmd *InitCommand) Print(cmd *cobra.Command, args []string) error {
+	return output.FinalCmdOutput(&iCmd.FinalCurveCmd, iCmd)
+}
+
+func (iCmd *InitCommand) RunCommand(cmd *cobra.Command, args []string) error {
+	if err := config.WriteConfigFile(iCmd.path, config.TemplateConfig()); err != nil {
+		return err
+	}
+	iCmd.Table.AddRow(map[string]string{
+		ROW_FILE: iCmd.path,
+	})
+	iCmd.Result = map[string]string{
+		ROW_FILE: iCmd.path,
+	}
+	iCmd.Error = cmderror.ErrSuccess()
+	return nil
+}
+
+func (iCmd *InitCommand) ResultPlainOutput() error {
+	return output.FinalCmdOutputPlain(&iCmd.FinalCurveCmd, iCmd)
+}
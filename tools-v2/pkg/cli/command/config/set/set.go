@@ -0,0 +1,135 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+package set
+
+import (
+	"fmt"
+
+	"github.com/liushuochen/gotable"
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/audit"
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/pkg/output"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ROW_KEY   = "key"
+	ROW_VALUE = "value"
+	ROW_FILE  = "file"
+)
+
+const (
+	setExample = `$ curve config set curvefs.mdsAddr 127.0.0.1:6700,127.0.0.1:6701
+$ curve config set global.rpcTimeout 10s
+$ curve config set contexts.prod.curvefs.mdsAddr 10.0.0.1:6700`
+)
+
+type SetCommand struct {
+	basecmd.FinalCurveCmd
+	key   string
+	value interface{}
+	path  string
+}
+
+var _ basecmd.FinalCurveCmdFunc = (*SetCommand)(nil) // check interface
+
+func NewSetCommand() *cobra.Command {
+	setCmd := &SetCommand{
+		FinalCurveCmd: basecmd.FinalCurveCmd{
+			Use:     "set KEY VALUE",
+			Short:   "set the value of key in config file",
+			Example: setExample,
+		},
+	}
+	basecmd.NewFinalCurveCli(&setCmd.FinalCurveCmd, setCmd)
+	setCmd.Cmd.Args = cobra.ExactArgs(2)
+	return setCmd.Cmd
+}
+
+func (sCmd *SetCommand) AddFlags() {
+}
+
+func (sCmd *SetCommand) Init(cmd *cobra.Command, args []string) error {
+	sCmd.key = args[0]
+	known, ok := config.SettingKey(sCmd.key)
+	if !ok {
+		return fmt.Errorf("unknown key %s, see curve config view for the keys", sCmd.key)
+	}
+	sCmd.value = parseValue(args[1])
+	if err := config.ValidateValue(known, sCmd.value); err != nil {
+		return err
+	}
+	path, err := config.GetConfigFilePath()
+	if err != nil {
+		return err
+	}
+	sCmd.path = path
+
+	table, err := gotable.Create(ROW_KEY, ROW_VALUE, ROW_FILE)
+	if err != nil {
+		return err
+	}
+	sCmd.Table = table
+	return nil
+}
+
+func (sCmd *SetCommand) Print(cmd *cobra.Command, args []string) error {
+	return output.FinalCmdOutput(&sCmd.FinalCurveCmd, sCmd)
+}
+
+func (sCmd *SetCommand) RunCommand(cmd *cobra.Command, args []string) error {
+	if err := config.SetConfigFileValue(sCmd.path, sCmd.key, sCmd.value); err != nil {
+		return err
+	}
+	value := fmt.Sprint(sCmd.value)
+	if audit.IsSecret(sCmd.key) {
+		value = audit.REDACTED
+	}
+	row := map[string]string{
+		ROW_KEY:   sCmd.key,
+		ROW_VALUE: value,
+		ROW_FILE:  sCmd.path,
+	}
+	sCmd.Table.AddRow(row)
+	sCmd.Result = row
+	sCmd.Error = cmderror.ErrSuccess()
+	return nil
+}
+
+func (sCmd *SetCommand) ResultPlainOutput() error {
+	return output.FinalCmdOutputPlain(&sCmd.FinalCurveCmd, sCmd)
+}
+
+// the scalar is kept in its yaml type, e.g. true or 1, others are strings
+func parseValue(text string) interface{} {
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(text), &value); err != nil || value == nil {
+		return text
+	}
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return text
+	}
+	return value
+}
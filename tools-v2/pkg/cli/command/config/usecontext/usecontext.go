@@ -0,0 +1,109 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package usecontext
+
+import (
+	"fmt"
+
+	"github.com/liushuochen/gotable"
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ROW_CONTEXT = "context"
+	ROW_FILE    = "file"
+)
+
+const (
+	useContextExample = `$ curve config use-context prod`
+)
+
+type UseContextCommand struct {
+	basecmd.FinalCurveCmd
+	name string
+	path string
+}
+
+var _ basecmd.FinalCurveCmdFunc = (*UseContextCommand)(nil) // check interface
+
+func NewUseContextCommand() *cobra.Command {
+	useCmd := &UseContextCommand{
+		FinalCurveCmd: basecmd.FinalCurveCmd{
+			Use:     "use-context CONTEXT",
+			Short:   "set the current context in config file",
+			Example: useContextExample,
+		},
+	}
+	basecmd.NewFinalCurveCli(&useCmd.FinalCurveCmd, useCmd)
+	useCmd.Cmd.Args = cobra.ExactArgs(1)
+	return useCmd.Cmd
+}
+
+func (uCmd *UseContextCommand) AddFlags() {
+}
+
+func (uCmd *UseContextCommand) Init(cmd *cobra.Command, args []string) error {
+	uCmd.name = args[0]
+	if config.ContextSettings(uCmd.name) == nil {
+		return fmt.Errorf("context %s is not found, the contexts are %v", uCmd.name, config.ContextNames())
+	}
+	path, err := config.GetConfigFilePath()
+	if err != nil {
+		return err
+	}
+	uCmd.path = path
+
+	table, err := gotable.Create(ROW_CONTEXT, ROW_FILE)
+	if err != nil {
+		return err
+	}
+	uCmd.Table = table
+	return nil
+}
+
+func (uCmd *UseContextCommand) Print(cmd *cobra.Command, args []string) error {
+	return output.FinalCmdOutput(&uCmd.FinalCurveCmd, uCmd)
+}
+
+func (uCmd *UseContextCommand) RunCommand(cmd *cobra.Command, args []string) error {
+	if err := config.SetConfigFileValue(uCmd.path, config.VIPER_CURRENT_CONTEXT, uCmd.name); err != nil {
+		return err
+	}
+	uCmd.Table.AddRow(map[string]string{
+		ROW_CONTEXT: uCmd.name,
+		ROW_FILE:    uCmd.path,
+	})
+	uCmd.Result = map[string]string{
+		config.VIPER_CURRENT_CONTEXT: uCmd.name,
+		"file":                       uCmd.path,
+	}
+	uCmd.Error = cmderror.ErrSuccess()
+	return nil
+}
+
+func (uCmd *UseContextCommand) ResultPlainOutput() error {
+	return output.FinalCmdOutputPlain(&uCmd.FinalCurveCmd, uCmd)
+}
@@ -0,0 +1,109 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+package validate
+
+import (
+	"fmt"
+
+	"github.com/liushuochen/gotable"
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ROW_KEY     = "key"
+	ROW_PROBLEM = "problem"
+)
+
+const (
+	validateExample = `$ curve config validate
+$ curve config validate -c ./curve.yaml`
+)
+
+type ValidateCommand struct {
+	basecmd.FinalCurveCmd
+	path string
+}
+
+var _ basecmd.FinalCurveCmdFunc = (*ValidateCommand)(nil) // check interface
+
+func NewValidateCommand() *cobra.Command {
+	validateCmd := &ValidateCommand{
+		FinalCurveCmd: basecmd.FinalCurveCmd{
+			Use:     "validate",
+			Short:   "check the unknown keys, addresses and durations in config file",
+			Example: validateExample,
+		},
+	}
+	return basecmd.NewFinalCurveCli(&validateCmd.FinalCurveCmd, validateCmd)
+}
+
+func (vCmd *ValidateCommand) AddFlags() {
+}
+
+func (vCmd *ValidateCommand) Init(cmd *cobra.Command, args []string) error {
+	path, err := config.GetConfigFilePath()
+	if err != nil {
+		return err
+	}
+	vCmd.path = path
+
+	table, err := gotable.Create(ROW_KEY, ROW_PROBLEM)
+	if err != nil {
+		return err
+	}
+	vCmd.Table = table
+	return nil
+}
+
+func (vCmd *ValidateCommand) Print(cmd *cobra.Command, args []string) error {
+	return output.FinalCmdOutput(&vCmd.FinalCurveCmd, vCmd)
+}
+
+func (vCmd *ValidateCommand) RunCommand(cmd *cobra.Command, args []string) error {
+	problems, err := config.ValidateConfigFile(vCmd.path)
+	if err != nil {
+		return err
+	}
+	for _, problem := range problems {
+		vCmd.Table.AddRow(map[string]string{
+			ROW_KEY:     problem.Key,
+			ROW_PROBLEM: problem.Problem,
+		})
+	}
+	vCmd.Result = problems
+	vCmd.Error = cmderror.ErrSuccess()
+	if len(problems) > 0 {
+		vCmd.Error = cmderror.ErrConfigInvalid()
+		vCmd.Error.Format(len(problems), vCmd.path)
+	}
+	return nil
+}
+
+func (vCmd *ValidateCommand) ResultPlainOutput() error {
+	if len(vCmd.Table.Row) == 0 {
+		fmt.Fprintf(vCmd.Cmd.OutOrStdout(), "config file %s is valid\n", vCmd.path)
+	}
+	return output.FinalCmdOutputPlain(&vCmd.FinalCurveCmd, vCmd)
+}
@@ -0,0 +1,131 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/liushuochen/gotable"
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/audit"
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	ROW_KEY    = "key"
+	ROW_VALUE  = "value"
+	ROW_SOURCE = "source"
+)
+
+const (
+	viewExample = `$ curve config view
+$ curve config view --context prod --filter source=file`
+)
+
+// Setting is the effective value of a key and where it comes from
+type Setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+type ViewCommand struct {
+	basecmd.FinalCurveCmd
+}
+
+var _ basecmd.FinalCurveCmdFunc = (*ViewCommand)(nil) // check interface
+
+func NewViewCommand() *cobra.Command {
+	viewCmd := &ViewCommand{
+		FinalCurveCmd: basecmd.FinalCurveCmd{
+			Use:     "view",
+			Short:   "show the effective config and where each value comes from: flag, env, file or default",
+			Example: viewExample,
+		},
+	}
+	return basecmd.NewFinalCurveCli(&viewCmd.FinalCurveCmd, viewCmd)
+}
+
+func (vCmd *ViewCommand) AddFlags() {
+}
+
+func (vCmd *ViewCommand) Init(cmd *cobra.Command, args []string) error {
+	table, err := gotable.Create(ROW_KEY, ROW_VALUE, ROW_SOURCE)
+	if err != nil {
+		return err
+	}
+	vCmd.Table = table
+	return nil
+}
+
+func (vCmd *ViewCommand) Print(cmd *cobra.Command, args []string) error {
+	return output.FinalCmdOutput(&vCmd.FinalCurveCmd, vCmd)
+}
+
+func (vCmd *ViewCommand) RunCommand(cmd *cobra.Command, args []string) error {
+	result := make([]Setting, 0)
+	for _, key := range config.KnownKeys() {
+		setting := Setting{
+			Key:    key,
+			Value:  valueString(viper.Get(key)),
+			Source: config.GetValueSource(vCmd.Cmd, key),
+		}
+		if audit.IsSecret(key) && setting.Value != "" {
+			setting.Value = audit.REDACTED
+		}
+		result = append(result, setting)
+		vCmd.Table.AddRow(map[string]string{
+			ROW_KEY:    setting.Key,
+			ROW_VALUE:  setting.Value,
+			ROW_SOURCE: setting.Source,
+		})
+	}
+	vCmd.Result = result
+	vCmd.Error = cmderror.ErrSuccess()
+	return nil
+}
+
+func (vCmd *ViewCommand) ResultPlainOutput() error {
+	return output.FinalCmdOutputPlain(&vCmd.FinalCurveCmd, vCmd)
+}
+
+// the slices are joined by comma like the flags
+func valueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(v, ",")
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -23,6 +23,7 @@
 package copyset
 
 import (
+	"context"
 	"fmt"
 	"sort"
 
@@ -31,7 +32,9 @@ import (
 	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
 	cobrautil "github.com/opencurve/curve/tools-v2/internal/utils"
 	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/fsclient"
 	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/query/copyset"
+	"github.com/opencurve/curve/tools-v2/pkg/client"
 	"github.com/opencurve/curve/tools-v2/pkg/config"
 	"github.com/opencurve/curve/tools-v2/pkg/output"
 	"github.com/spf13/cobra"
@@ -45,7 +48,7 @@ const (
 
 type CopysetCommand struct {
 	basecmd.FinalCurveCmd
-	key2Copyset       *map[uint64]*cobrautil.CopysetInfoStatus
+	key2Copyset       *map[uint64]*client.CopysetStatus
 	copysetKey2Status *map[uint64]cobrautil.COPYSET_HEALTH_STATUS
 }
 
@@ -62,33 +65,26 @@ func NewCopysetCommand() *cobra.Command {
 	return copysetCmd.Cmd
 }
 
-func NewCheckCopysetCommand() *CopysetCommand {
-	copysetCmd := &CopysetCommand{
-		FinalCurveCmd: basecmd.FinalCurveCmd{
-			Use:   "copyset",
-			Short: "check copysets health in curvefs",
-		},
-	}
-	basecmd.NewFinalCurveCli(&copysetCmd.FinalCurveCmd, copysetCmd)
-	return copysetCmd
-}
-
-func GetCopysetsStatus(caller *cobra.Command, copysetIds string, poolIds string) (interface{}, *table.Table, *cmderror.CmdError) {
-	checkCopyset := NewCheckCopysetCommand()
-	checkCopyset.Cmd.SetArgs([]string{
-		fmt.Sprintf("--%s", config.CURVEFS_COPYSETID), copysetIds,
-		fmt.Sprintf("--%s", config.CURVEFS_POOLID), poolIds,
-		fmt.Sprintf("--%s", config.FORMAT), config.FORMAT_NOOUT,
+// GetCopysetsStatus checks the health of the copysets by their status on the peers
+func GetCopysetsStatus(ctx context.Context, fsClient *client.Client, keys []client.CopysetKey) (interface{}, *table.Table, *cmderror.CmdError) {
+	key2Copyset, err := fsClient.GetCopysetStatus(ctx, client.CopysetOptions{
+		Copysets:   keys,
+		PeerStatus: true,
 	})
-	cobrautil.AlignFlags(caller, checkCopyset.Cmd, []string{config.RPCRETRYTIMES, config.RPCTIMEOUT, config.CURVEFS_MDSADDR})
-	checkCopyset.Cmd.SilenceUsage = true
-	err := checkCopyset.Cmd.Execute()
-	if err != nil {
+	if key2Copyset == nil {
 		retErr := cmderror.ErrCheckCopyset()
 		retErr.Format(err.Error())
-		return checkCopyset.Result, checkCopyset.Table, retErr
+		return nil, nil, retErr.WithExitCode(fsclient.ToCmdError(err).ExitCode())
+	}
+	table, errCheck := checkCopysets(key2Copyset, fsclient.ToCmdError(err))
+	if table == nil {
+		return nil, nil, errCheck
+	}
+	result, errResult := cobrautil.TableToResult(table)
+	if errResult != nil {
+		return nil, nil, cmderror.ToCmdError(errResult)
 	}
-	return checkCopyset.Result, checkCopyset.Table, checkCopyset.Error
+	return result, table, errCheck
 }
 
 func (cCmd *CopysetCommand) AddFlags() {
@@ -102,15 +98,11 @@ func (cCmd *CopysetCommand) AddFlags() {
 func (cCmd *CopysetCommand) Init(cmd *cobra.Command, args []string) error {
 	var queryCopysetErr *cmderror.CmdError
 	cCmd.key2Copyset, queryCopysetErr = copyset.QueryCopysetInfoStatus(cCmd.Cmd)
-	if queryCopysetErr.TypeCode() != cmderror.CODE_SUCCESS {
+	if cCmd.key2Copyset == nil {
 		return queryCopysetErr.ToError()
 	}
+	// some of the copysets are got, the others are checked as not exist
 	cCmd.Error = queryCopysetErr
-	table, err := gotable.Create(ROW_COPYSETKEY, ROW_STATUS, ROW_EXPLAIN)
-	if err != nil {
-		return err
-	}
-	cCmd.Table = table
 	copysetKey2Status := make(map[uint64]cobrautil.COPYSET_HEALTH_STATUS)
 	cCmd.copysetKey2Status = &copysetKey2Status
 	return nil
@@ -121,15 +113,35 @@ func (cCmd *CopysetCommand) Print(cmd *cobra.Command, args []string) error {
 }
 
 func (cCmd *CopysetCommand) RunCommand(cmd *cobra.Command, args []string) error {
+	table, errCheck := checkCopysets(*cCmd.key2Copyset, cCmd.Error)
+	if table == nil {
+		return errCheck.ToError()
+	}
+	cCmd.Table = table
+	cCmd.Error = errCheck
+	var err error
+	cCmd.Result, err = cobrautil.TableToResult(cCmd.Table)
+	return err
+}
+
+// checkCopysets returns the health of copysets in the table, queryErr is the error of getting them
+func checkCopysets(key2Copyset map[uint64]*client.CopysetStatus, queryErr *cmderror.CmdError) (*table.Table, *cmderror.CmdError) {
+	table, err := gotable.Create(ROW_COPYSETKEY, ROW_STATUS, ROW_EXPLAIN)
+	if err != nil {
+		return nil, cmderror.ToCmdError(err)
+	}
 	rows := make([]map[string]string, 0)
 	var errs []*cmderror.CmdError
-	for k, v := range *cCmd.key2Copyset {
+	if queryErr.TypeCode() != cmderror.CODE_SUCCESS {
+		errs = append(errs, queryErr)
+	}
+	for k, v := range key2Copyset {
 		row := make(map[string]string)
 		row[ROW_COPYSETKEY] = fmt.Sprintf("%d", k)
 		if v == nil {
 			row[ROW_STATUS] = cobrautil.CopysetHealthStatus_Str[int32(cobrautil.COPYSET_NOTEXIST)]
 		} else {
-			status, errsCheck := cobrautil.CheckCopySetHealth(v)
+			status, errsCheck := cobrautil.CheckCopySetHealth(v.Info, v.Peer2Status)
 			row[ROW_STATUS] = cobrautil.CopysetHealthStatus_Str[int32(status)]
 			if status != cobrautil.COPYSET_OK {
 				explain := "|"
@@ -143,14 +155,11 @@ func (cCmd *CopysetCommand) RunCommand(cmd *cobra.Command, args []string) error
 		rows = append(rows, row)
 	}
 	retErr := cmderror.MergeCmdError(errs)
-	cCmd.Error = &retErr
 	sort.Slice(rows, func(i, j int) bool {
 		return rows[i][ROW_COPYSETKEY] < rows[j][ROW_COPYSETKEY]
 	})
-	cCmd.Table.AddRows(rows)
-	var err error
-	cCmd.Result, err = cobrautil.TableToResult(cCmd.Table)
-	return err
+	table.AddRows(rows)
+	return table, &retErr
 }
 
 func (cCmd *CopysetCommand) ResultPlainOutput() error {
@@ -0,0 +1,312 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+// Package completion completes the flags of curvefs commands by the
+// objects in cluster, e.g. the fs names for --fsname
+package completion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/fsclient"
+	"github.com/opencurve/curve/tools-v2/pkg/client"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// the cluster is queried with the short timeouts when completing
+	COMPLETION_RPC_TIMEOUT = 500 * time.Millisecond
+	COMPLETION_TIMEOUT     = 2 * time.Second
+	// the objects got are cached in GetCacheDir for a while
+	COMPLETION_CACHE_TTL = 30 * time.Second
+	COMPLETION_CACHE_DIR = "completion"
+)
+
+type CompletionFunc = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// the flags completed by the objects in cluster
+var flagCompletions = map[string]CompletionFunc{
+	config.CURVEFS_FSNAME:     FsNames,
+	config.CURVEFS_FSID:       FsIds,
+	config.CURVEFS_MOUNTPOINT: Mountpoints,
+	config.CURVEFS_POOLID:     PoolIds,
+	config.CURVEFS_COPYSETID:  CopysetIds,
+}
+
+// RegisterFlagCompletions registers the completion of the flags of cmd and
+// its subcommands, except the create commands as their values are new
+func RegisterFlagCompletions(cmd *cobra.Command) {
+	if cmd.Name() == "create" {
+		return
+	}
+	for name, completionFunc := range flagCompletions {
+		if cmd.Flags().Lookup(name) != nil {
+			cobra.CheckErr(cmd.RegisterFlagCompletionFunc(name, completionFunc))
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		RegisterFlagCompletions(sub)
+	}
+}
+
+type fsEntry struct {
+	Id          uint32   `json:"id"`
+	Name        string   `json:"name"`
+	Mountpoints []string `json:"mountpoints"`
+}
+
+type poolEntry struct {
+	Id   uint32 `json:"id"`
+	Name string `json:"name"`
+}
+
+type copysetEntry struct {
+	PoolId    uint32 `json:"poolId"`
+	CopysetId uint32 `json:"copysetId"`
+}
+
+// FsNames completes --fsname
+func FsNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var fsList []fsEntry
+	if err := listFs(cmd, &fsList); err != nil {
+		return failed(err)
+	}
+	var candidates []string
+	for _, fs := range fsList {
+		candidates = append(candidates, candidate(fs.Name, fmt.Sprintf("fs id %d", fs.Id)))
+	}
+	return complete(cmd, config.CURVEFS_FSNAME, toComplete, candidates)
+}
+
+// FsIds completes --fsid
+func FsIds(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var fsList []fsEntry
+	if err := listFs(cmd, &fsList); err != nil {
+		return failed(err)
+	}
+	var candidates []string
+	for _, fs := range fsList {
+		candidates = append(candidates, candidate(fmt.Sprint(fs.Id), fs.Name))
+	}
+	return complete(cmd, config.CURVEFS_FSID, toComplete, candidates)
+}
+
+// Mountpoints completes --mountpoint, only the ones of --fsname if it is given
+func Mountpoints(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var fsList []fsEntry
+	if err := listFs(cmd, &fsList); err != nil {
+		return failed(err)
+	}
+	fsName := ""
+	if cmd.Flag(config.CURVEFS_FSNAME) != nil {
+		fsName = config.GetFlagString(cmd, config.CURVEFS_FSNAME)
+	}
+	var candidates []string
+	for _, fs := range fsList {
+		if fsName != "" && fs.Name != fsName {
+			continue
+		}
+		for _, mountpoint := range fs.Mountpoints {
+			candidates = append(candidates, candidate(mountpoint, fs.Name))
+		}
+	}
+	return complete(cmd, config.CURVEFS_MOUNTPOINT, toComplete, candidates)
+}
+
+// PoolIds completes --poolid
+func PoolIds(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var pools []poolEntry
+	err := cached(cmd, "pool", &pools, func(ctx context.Context, fsClient *client.Client) error {
+		topology, err := fsClient.ListTopology(ctx)
+		if err != nil {
+			return err
+		}
+		for _, pool := range topology.GetPools().GetPoolInfos() {
+			pools = append(pools, poolEntry{Id: pool.GetPoolID(), Name: pool.GetPoolName()})
+		}
+		return nil
+	})
+	if err != nil {
+		return failed(err)
+	}
+	var candidates []string
+	for _, pool := range pools {
+		candidates = append(candidates, candidate(fmt.Sprint(pool.Id), pool.Name))
+	}
+	return complete(cmd, config.CURVEFS_POOLID, toComplete, candidates)
+}
+
+// CopysetIds completes --copysetid, the nth one is in the nth pool of --poolid if it is given
+func CopysetIds(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var copysets []copysetEntry
+	err := cached(cmd, "copyset", &copysets, func(ctx context.Context, fsClient *client.Client) error {
+		infos, err := fsClient.ListCopyset(ctx)
+		if err != nil {
+			return err
+		}
+		for _, info := range infos {
+			copysets = append(copysets, copysetEntry{PoolId: info.GetPoolId(), CopysetId: info.GetCopysetId()})
+		}
+		return nil
+	})
+	if err != nil {
+		return failed(err)
+	}
+	poolId := ""
+	if cmd.Flag(config.CURVEFS_POOLID) != nil {
+		poolIds := config.GetFlagStringSlice(cmd, config.CURVEFS_POOLID)
+		if index := strings.Count(toComplete, ","); index < len(poolIds) {
+			poolId = poolIds[index]
+		}
+	}
+	var candidates []string
+	for _, copyset := range copysets {
+		pool := fmt.Sprint(copyset.PoolId)
+		if poolId != "" && pool != poolId {
+			continue
+		}
+		candidates = append(candidates, candidate(fmt.Sprint(copyset.CopysetId), "pool "+pool))
+	}
+	return complete(cmd, config.CURVEFS_COPYSETID, toComplete, candidates)
+}
+
+func listFs(cmd *cobra.Command, fsList *[]fsEntry) error {
+	return cached(cmd, "fs", fsList, func(ctx context.Context, fsClient *client.Client) error {
+		fsInfos, err := fsClient.ListFs(ctx)
+		if err != nil {
+			return err
+		}
+		for _, fsInfo := range fsInfos {
+			fs := fsEntry{Id: fsInfo.GetFsId(), Name: fsInfo.GetFsName()}
+			for _, mountpoint := range fsInfo.GetMountpoints() {
+				// the same format as umount fs --mountpoint
+				fs.Mountpoints = append(fs.Mountpoints, fmt.Sprintf("%s:%d:%s",
+					mountpoint.GetHostname(), mountpoint.GetPort(), mountpoint.GetPath()))
+			}
+			*fsList = append(*fsList, fs)
+		}
+		return nil
+	})
+}
+
+// cached gets value from the cache of the cluster, or by query and caches it
+func cached(cmd *cobra.Command, kind string, value interface{}, query func(context.Context, *client.Client) error) error {
+	options, errCmd := fsclient.Options(cmd)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		return errCmd.ToError()
+	}
+	path, err := cachePath(kind, options.MdsAddrs)
+	if err == nil && readCache(path, value) {
+		return nil
+	}
+
+	options.RpcTimeout = COMPLETION_RPC_TIMEOUT
+	options.RpcRetryTimes = 0
+	fsClient, err := client.New(options)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, COMPLETION_TIMEOUT)
+	defer cancel()
+	if err := query(ctx, fsClient); err != nil {
+		return err
+	}
+	if path != "" {
+		writeCache(path, value)
+	}
+	return nil
+}
+
+// the cache file of the objects of kind in the cluster of addrs
+func cachePath(kind string, addrs []string) (string, error) {
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(strings.Join(addrs, ",")))
+	name := fmt.Sprintf("%s-%s.json", kind, hex.EncodeToString(sum[:8]))
+	return filepath.Join(dir, COMPLETION_CACHE_DIR, name), nil
+}
+
+func readCache(path string, value interface{}) bool {
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > COMPLETION_CACHE_TTL {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	return err == nil && json.Unmarshal(data, value) == nil
+}
+
+// the cache is only an optimization, the failure is ignored
+func writeCache(path string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0600)
+}
+
+// the value with its description shown by zsh and fish
+func candidate(value string, description string) string {
+	if description == "" {
+		return value
+	}
+	return value + "\t" + description
+}
+
+// complete returns the candidates with the prefix toComplete, the values
+// before the last comma are kept for the slice flag, e.g. --fsid 1,2
+func complete(cmd *cobra.Command, flagName string, toComplete string, candidates []string) ([]string, cobra.ShellCompDirective) {
+	prefix := ""
+	if flag := cmd.Flag(flagName); flag != nil && strings.HasSuffix(flag.Value.Type(), "Slice") {
+		if index := strings.LastIndex(toComplete, ","); index >= 0 {
+			prefix, toComplete = toComplete[:index+1], toComplete[index+1:]
+		}
+	}
+	ret := make([]string, 0, len(candidates))
+	for _, value := range candidates {
+		if strings.HasPrefix(value, toComplete) {
+			ret = append(ret, prefix+value)
+		}
+	}
+	return ret, cobra.ShellCompDirectiveNoFileComp
+}
+
+func failed(err error) ([]string, cobra.ShellCompDirective) {
+	cobra.CompDebugln(err.Error(), true)
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,106 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+package completion
+
+import (
+	"testing"
+
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/cobra"
+)
+
+const fakeMdsAddr = "127.0.0.1:1"
+
+func newCommand() *cobra.Command {
+	cmd := &cobra.Command{Use: "test", Run: func(cmd *cobra.Command, args []string) {}}
+	config.AddFsMdsAddrFlag(cmd)
+	config.AddFsNameOptionFlag(cmd)
+	config.AddFsIdSliceOptionFlag(cmd)
+	config.AddMountpointFlag(cmd)
+	config.AddPoolidSliceRequiredFlag(cmd)
+	config.AddCopysetidSliceRequiredFlag(cmd)
+	cmd.Flags().Set(config.CURVEFS_MDSADDR, fakeMdsAddr)
+	return cmd
+}
+
+// the objects are got from the cache, the mds is unreachable
+func writeFakeCache(kind string, value interface{}) {
+	path, err := cachePath(kind, []string{fakeMdsAddr})
+	So(err, ShouldBeNil)
+	writeCache(path, value)
+}
+
+func TestCompletion(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	Convey("the cluster is unreachable", t, func() {
+		values, directive := FsNames(newCommand(), nil, "")
+		So(values, ShouldBeEmpty)
+		So(directive, ShouldEqual, cobra.ShellCompDirectiveNoFileComp)
+	})
+
+	Convey("the fs are completed", t, func() {
+		writeFakeCache("fs", []fsEntry{
+			{Id: 1, Name: "test1", Mountpoints: []string{"host1:9000:/mnt/a"}},
+			{Id: 2, Name: "test2", Mountpoints: []string{"host2:9000:/mnt/b"}},
+			{Id: 10, Name: "prod"},
+		})
+		cmd := newCommand()
+		values, _ := FsNames(cmd, nil, "te")
+		So(values, ShouldResemble, []string{"test1\tfs id 1", "test2\tfs id 2"})
+		values, _ = FsIds(cmd, nil, "2,1")
+		So(values, ShouldResemble, []string{"2,1\ttest1", "2,10\tprod"})
+		values, _ = Mountpoints(cmd, nil, "")
+		So(values, ShouldHaveLength, 2)
+		cmd.Flags().Set(config.CURVEFS_FSNAME, "test2")
+		values, _ = Mountpoints(cmd, nil, "")
+		So(values, ShouldResemble, []string{"host2:9000:/mnt/b\ttest2"})
+	})
+
+	Convey("the copysets are completed by pool", t, func() {
+		writeFakeCache("pool", []poolEntry{{Id: 1, Name: "pool1"}, {Id: 2, Name: "pool2"}})
+		writeFakeCache("copyset", []copysetEntry{
+			{PoolId: 1, CopysetId: 1}, {PoolId: 1, CopysetId: 2}, {PoolId: 2, CopysetId: 3},
+		})
+		cmd := newCommand()
+		values, _ := PoolIds(cmd, nil, "")
+		So(values, ShouldResemble, []string{"1\tpool1", "2\tpool2"})
+		values, _ = CopysetIds(cmd, nil, "")
+		So(values, ShouldHaveLength, 3)
+		cmd.Flags().Set(config.CURVEFS_POOLID, "1,2")
+		values, _ = CopysetIds(cmd, nil, "1,")
+		So(values, ShouldResemble, []string{"1,3\tpool 2"})
+	})
+
+	Convey("the flags of commands are registered", t, func() {
+		root := &cobra.Command{Use: "fs"}
+		umount := &cobra.Command{Use: "umount"}
+		create := &cobra.Command{Use: "create"}
+		umount.AddCommand(newCommand())
+		create.AddCommand(newCommand())
+		root.AddCommand(umount, create)
+		RegisterFlagCompletions(root)
+		// the flag can be registered only once
+		So(umount.Commands()[0].RegisterFlagCompletionFunc(config.CURVEFS_MOUNTPOINT, Mountpoints), ShouldNotBeNil)
+		So(create.Commands()[0].RegisterFlagCompletionFunc(config.CURVEFS_FSNAME, FsNames), ShouldBeNil)
+	})
+}
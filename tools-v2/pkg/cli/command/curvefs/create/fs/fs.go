@@ -38,6 +38,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
 type CreateFsRpc struct {
@@ -59,7 +60,15 @@ func (cfRpc *CreateFsRpc) Stub_Func(ctx context.Context) (interface{}, error) {
 	return cfRpc.mdsClient.CreateFs(ctx, cfRpc.Request)
 }
 
-var _ basecmd.RpcFunc = (*CreateFsRpc)(nil) // check interface
+func (cfRpc *CreateFsRpc) IsMutating() bool {
+	return true
+}
+
+func (cfRpc *CreateFsRpc) GetRequest() proto.Message {
+	return cfRpc.Request
+}
+
+var _ basecmd.MutatingRpcFunc = (*CreateFsRpc)(nil) // check interface
 
 var _ basecmd.FinalCurveCmdFunc = (*FsCommand)(nil) // check interface
 
@@ -77,6 +86,8 @@ func NewFsCommand() *cobra.Command {
 func (fCmd *FsCommand) AddFlags() {
 	config.AddRpcRetryTimesFlag(fCmd.Cmd)
 	config.AddRpcTimeoutFlag(fCmd.Cmd)
+	config.AddRpcRetryMutatingFlag(fCmd.Cmd)
+	config.AddDryRunFlag(fCmd.Cmd)
 	config.AddFsMdsAddrFlag(fCmd.Cmd)
 	config.AddFsNameRequiredFlag(fCmd.Cmd)
 	config.AddUserOptionFlag(fCmd.Cmd)
@@ -105,7 +116,7 @@ func (fCmd *FsCommand) AddFlags() {
 func (fCmd *FsCommand) Init(cmd *cobra.Command, args []string) error {
 	addrs, addrErr := config.GetFsMdsAddrSlice(fCmd.Cmd)
 	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
+		return addrErr.ToError()
 	}
 
 	table, err := gotable.Create("fs name", "result")
@@ -125,7 +136,7 @@ func (fCmd *FsCommand) Init(cmd *cobra.Command, args []string) error {
 	fsTypeStr := config.GetFlagString(cmd, config.CURVEFS_FSTYPE)
 	fsType, errFstype := cobrautil.TranslateFsType(fsTypeStr)
 	if errFstype.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(errFstype.Message)
+		return errFstype.ToError()
 	}
 
 	var fsDetail mds.FsDetail
@@ -133,21 +144,21 @@ func (fCmd *FsCommand) Init(cmd *cobra.Command, args []string) error {
 	case common.FSType_TYPE_S3:
 		errS3 := setS3Info(&fsDetail, fCmd.Cmd)
 		if errS3.TypeCode() != cmderror.CODE_SUCCESS {
-			return fmt.Errorf(errS3.Message)
+			return errS3.ToError()
 		}
 	case common.FSType_TYPE_VOLUME:
 		errVolume := setVolumeInfo(&fsDetail, fCmd.Cmd)
 		if errVolume.TypeCode() != cmderror.CODE_SUCCESS {
-			return fmt.Errorf(errVolume.Message)
+			return errVolume.ToError()
 		}
 	case common.FSType_TYPE_HYBRID:
 		errS3 := setS3Info(&fsDetail, fCmd.Cmd)
 		if errS3.TypeCode() != cmderror.CODE_SUCCESS {
-			return fmt.Errorf(errS3.Message)
+			return errS3.ToError()
 		}
 		errVolume := setVolumeInfo(&fsDetail, fCmd.Cmd)
 		if errVolume.TypeCode() != cmderror.CODE_SUCCESS {
-			return fmt.Errorf(errVolume.Message)
+			return errVolume.ToError()
 		}
 	default:
 		return fmt.Errorf("invalid fs type: %s", fsTypeStr)
@@ -178,7 +189,8 @@ func (fCmd *FsCommand) Init(cmd *cobra.Command, args []string) error {
 
 	timeout := viper.GetDuration(config.VIPER_GLOBALE_RPCTIMEOUT)
 	retrytimes := viper.GetInt32(config.VIPER_GLOBALE_RPCRETRYTIMES)
-	fCmd.Rpc.Info = basecmd.NewRpc(addrs, timeout, retrytimes, "CreateFs")
+	fCmd.Rpc.Info = basecmd.NewMdsRpc(fCmd.Cmd, addrs, timeout, retrytimes, "CreateFs")
+	fCmd.Rpc.Info.RetryMutating = config.GetFlagBool(fCmd.Cmd, config.RPCRETRYMUTATING)
 
 	return nil
 }
@@ -289,9 +301,12 @@ func setVolumeInfo(detail *mds.FsDetail, cmd *cobra.Command) *cmderror.CmdError
 }
 
 func (fCmd *FsCommand) RunCommand(cmd *cobra.Command, args []string) error {
-	result, errCmd := basecmd.GetRpcResponse(fCmd.Rpc.Info, fCmd.Rpc)
+	result, errCmd := basecmd.GetRpcResponse(fCmd.Cmd.Context(), fCmd.Rpc.Info, fCmd.Rpc)
+	if basecmd.IsDryRunError(errCmd) {
+		return nil
+	}
 	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(errCmd.Message)
+		return errCmd.ToError()
 	}
 
 	response := result.(*mds.CreateFsResponse)
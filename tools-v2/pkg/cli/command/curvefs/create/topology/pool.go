@@ -31,6 +31,7 @@ import (
 	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/topology"
 	"golang.org/x/exp/slices"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -68,7 +69,15 @@ func (dpRpc *DeletePoolRpc) Stub_Func(ctx context.Context) (interface{}, error)
 	return dpRpc.topologyClient.DeletePool(ctx, dpRpc.Request)
 }
 
-var _ basecmd.RpcFunc = (*DeletePoolRpc)(nil) // check interface
+func (dpRpc *DeletePoolRpc) IsMutating() bool {
+	return true
+}
+
+func (dpRpc *DeletePoolRpc) GetRequest() proto.Message {
+	return dpRpc.Request
+}
+
+var _ basecmd.MutatingRpcFunc = (*DeletePoolRpc)(nil) // check interface
 
 type CreatePoolRpc struct {
 	Info           *basecmd.Rpc
@@ -84,7 +93,15 @@ func (cpRpc *CreatePoolRpc) Stub_Func(ctx context.Context) (interface{}, error)
 	return cpRpc.topologyClient.CreatePool(ctx, cpRpc.Request)
 }
 
-var _ basecmd.RpcFunc = (*CreatePoolRpc)(nil) // check interface
+func (cpRpc *CreatePoolRpc) IsMutating() bool {
+	return true
+}
+
+func (cpRpc *CreatePoolRpc) GetRequest() proto.Message {
+	return cpRpc.Request
+}
+
+var _ basecmd.MutatingRpcFunc = (*CreatePoolRpc)(nil) // check interface
 
 type ListPoolRpc struct {
 	Info           *basecmd.Rpc
@@ -103,8 +120,8 @@ func (lpRpc *ListPoolRpc) Stub_Func(ctx context.Context) (interface{}, error) {
 func (tCmd *TopologyCommand) listPool() (*topology.ListPoolResponse, *cmderror.CmdError) {
 	tCmd.listPoolRpc = &ListPoolRpc{}
 	tCmd.listPoolRpc.Request = &topology.ListPoolRequest{}
-	tCmd. listPoolRpc.Info = basecmd.NewRpc(tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "ListPool")
-	result, err := basecmd.GetRpcResponse(tCmd.listPoolRpc.Info, tCmd.listPoolRpc)
+	tCmd. listPoolRpc.Info = basecmd.NewMdsRpc(tCmd.Cmd, tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "ListPool")
+	result, err := basecmd.GetRpcResponse(tCmd.Cmd.Context(), tCmd.listPoolRpc.Info, tCmd.listPoolRpc)
 	if err.TypeCode() != cmderror.CODE_SUCCESS {
 		return nil, err
 	}
@@ -176,10 +193,14 @@ func (tCmd *TopologyCommand) scanPools() *cmderror.CmdError {
 
 func (tCmd *TopologyCommand) removePools() *cmderror.CmdError {
 	tCmd.deletePoolRpc = &DeletePoolRpc{}
-	tCmd.deletePoolRpc.Info = basecmd.NewRpc(tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "DeleteServer")
+	tCmd.deletePoolRpc.Info = basecmd.NewMdsRpc(tCmd.Cmd, tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "DeletePool")
+	tCmd.deletePoolRpc.Info.RetryMutating = tCmd.retryMutating
 	for _, delReuest := range tCmd.deletePool {
 		tCmd.deletePoolRpc.Request = delReuest
-		result, err := basecmd.GetRpcResponse(tCmd.deletePoolRpc.Info, tCmd.deletePoolRpc)
+		result, err := basecmd.GetRpcResponse(tCmd.Cmd.Context(), tCmd.deletePoolRpc.Info, tCmd.deletePoolRpc)
+		if basecmd.IsDryRunError(err) {
+			continue
+		}
 		if err.TypeCode() != cmderror.CODE_SUCCESS {
 			return err
 		}
@@ -193,10 +214,14 @@ func (tCmd *TopologyCommand) removePools() *cmderror.CmdError {
 
 func (tCmd *TopologyCommand) createPools() *cmderror.CmdError {
 	tCmd.createPoolRpc = &CreatePoolRpc{}
-	tCmd.createPoolRpc.Info = basecmd.NewRpc(tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "CreatePool")
+	tCmd.createPoolRpc.Info = basecmd.NewMdsRpc(tCmd.Cmd, tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "CreatePool")
+	tCmd.createPoolRpc.Info.RetryMutating = tCmd.retryMutating
 	for _, crtReuest := range tCmd.createPool {
 		tCmd.createPoolRpc.Request = crtReuest
-		result, err := basecmd.GetRpcResponse(tCmd.createPoolRpc.Info, tCmd.createPoolRpc)
+		result, err := basecmd.GetRpcResponse(tCmd.Cmd.Context(), tCmd.createPoolRpc.Info, tCmd.createPoolRpc)
+		if basecmd.IsDryRunError(err) {
+			continue
+		}
 		if err.TypeCode() != cmderror.CODE_SUCCESS {
 			return err
 		}
@@ -30,6 +30,7 @@ import (
 	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/topology"
 	"golang.org/x/exp/slices"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
 type Server struct {
@@ -60,7 +61,15 @@ func (dsRpc *DeleteServerRpc) Stub_Func(ctx context.Context) (interface{}, error
 	return dsRpc.topologyClient.DeleteServer(ctx, dsRpc.Request)
 }
 
-var _ basecmd.RpcFunc = (*DeleteServerRpc)(nil) // check interface
+func (dsRpc *DeleteServerRpc) IsMutating() bool {
+	return true
+}
+
+func (dsRpc *DeleteServerRpc) GetRequest() proto.Message {
+	return dsRpc.Request
+}
+
+var _ basecmd.MutatingRpcFunc = (*DeleteServerRpc)(nil) // check interface
 
 type CreateServerRpc struct {
 	Info           *basecmd.Rpc
@@ -76,7 +85,15 @@ func (csRpc *CreateServerRpc) Stub_Func(ctx context.Context) (interface{}, error
 	return csRpc.topologyClient.RegistServer(ctx, csRpc.Request)
 }
 
-var _ basecmd.RpcFunc = (*CreateServerRpc)(nil) // check interface
+func (csRpc *CreateServerRpc) IsMutating() bool {
+	return true
+}
+
+func (csRpc *CreateServerRpc) GetRequest() proto.Message {
+	return csRpc.Request
+}
+
+var _ basecmd.MutatingRpcFunc = (*CreateServerRpc)(nil) // check interface
 
 type ListZoneServerRpc struct {
 	Info           *basecmd.Rpc
@@ -101,8 +118,8 @@ func (tCmd *TopologyCommand) listZoneServer(zoneId uint32) (*topology.ListZoneSe
 	tCmd.listZoneServerRpc = &ListZoneServerRpc{
 		Request: request,
 	}
-	tCmd.listZoneServerRpc.Info = basecmd.NewRpc(tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "ListPoolZone")
-	result, err := basecmd.GetRpcResponse(tCmd.listZoneServerRpc.Info, tCmd.listZoneServerRpc)
+	tCmd.listZoneServerRpc.Info = basecmd.NewMdsRpc(tCmd.Cmd, tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "ListPoolZone")
+	result, err := basecmd.GetRpcResponse(tCmd.Cmd.Context(), tCmd.listZoneServerRpc.Info, tCmd.listZoneServerRpc)
 	if err.TypeCode() != cmderror.CODE_SUCCESS {
 		return nil, err
 	}
@@ -175,10 +192,14 @@ func (tCmd *TopologyCommand) scanServers() *cmderror.CmdError {
 
 func (tCmd *TopologyCommand) removeServers() *cmderror.CmdError {
 	tCmd.deleteServerRpc = &DeleteServerRpc{}
-	tCmd.deleteServerRpc.Info = basecmd.NewRpc(tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "DeleteServer")
+	tCmd.deleteServerRpc.Info = basecmd.NewMdsRpc(tCmd.Cmd, tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "DeleteServer")
+	tCmd.deleteServerRpc.Info.RetryMutating = tCmd.retryMutating
 	for _, delReuest := range tCmd.deleteServer {
 		tCmd.deleteServerRpc.Request = delReuest
-		result, err := basecmd.GetRpcResponse(tCmd.deleteServerRpc.Info, tCmd.deleteServerRpc)
+		result, err := basecmd.GetRpcResponse(tCmd.Cmd.Context(), tCmd.deleteServerRpc.Info, tCmd.deleteServerRpc)
+		if basecmd.IsDryRunError(err) {
+			continue
+		}
 		if err.TypeCode() != cmderror.CODE_SUCCESS {
 			return err
 		}
@@ -192,10 +213,14 @@ func (tCmd *TopologyCommand) removeServers() *cmderror.CmdError {
 
 func (tCmd *TopologyCommand) createServers() *cmderror.CmdError {
 	tCmd.createServerRpc = &CreateServerRpc{}
-	tCmd.createServerRpc.Info = basecmd.NewRpc(tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "RegisterServer")
+	tCmd.createServerRpc.Info = basecmd.NewMdsRpc(tCmd.Cmd, tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "RegisterServer")
+	tCmd.createServerRpc.Info.RetryMutating = tCmd.retryMutating
 	for _, crtReuest := range tCmd.createServer {
 		tCmd.createServerRpc.Request = crtReuest
-		result, err := basecmd.GetRpcResponse(tCmd.createServerRpc.Info, tCmd.createServerRpc)
+		result, err := basecmd.GetRpcResponse(tCmd.Cmd.Context(), tCmd.createServerRpc.Info, tCmd.createServerRpc)
+		if basecmd.IsDryRunError(err) {
+			continue
+		}
 		if err.TypeCode() != cmderror.CODE_SUCCESS {
 			return err
 		}
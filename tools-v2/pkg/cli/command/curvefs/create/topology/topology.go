@@ -57,10 +57,11 @@ type Topology struct {
 
 type TopologyCommand struct {
 	basecmd.FinalCurveCmd
-	topology   Topology
-	timeout    time.Duration
-	retryTimes int32
-	addrs      []string
+	topology      Topology
+	timeout       time.Duration
+	retryTimes    int32
+	retryMutating bool
+	addrs         []string
 	// pool
 	clusterPoolsInfo []*topology.PoolInfo
 	createPoolRpc    *CreatePoolRpc
@@ -100,18 +101,27 @@ func NewTopologyCommand() *cobra.Command {
 func (tCmd *TopologyCommand) AddFlags() {
 	config.AddRpcRetryTimesFlag(tCmd.Cmd)
 	config.AddRpcTimeoutFlag(tCmd.Cmd)
+	config.AddRpcRetryMutatingFlag(tCmd.Cmd)
+	config.AddDryRunFlag(tCmd.Cmd)
 	config.AddFsMdsAddrFlag(tCmd.Cmd)
 	config.AddClusterMapRequiredFlag(tCmd.Cmd)
 }
 
 func (tCmd *TopologyCommand) Init(cmd *cobra.Command, args []string) error {
+	// Init is run again by every run of --watch
+	tCmd.topology = Topology{}
+	tCmd.clusterPoolsInfo, tCmd.createPool, tCmd.deletePool = nil, nil, nil
+	tCmd.clusterZonesInfo, tCmd.createZone, tCmd.deleteZone = nil, nil, nil
+	tCmd.clusterServersInfo, tCmd.createServer, tCmd.deleteServer = nil, nil, nil
+
 	addrs, addrErr := config.GetFsMdsAddrSlice(tCmd.Cmd)
 	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
+		return addrErr.ToError()
 	}
 	tCmd.addrs = addrs
 	tCmd.timeout = config.GetFlagDuration(tCmd.Cmd, config.RPCTIMEOUT)
 	tCmd.retryTimes = config.GetFlagInt32(tCmd.Cmd, config.RPCRETRYTIMES)
+	tCmd.retryMutating = config.GetFlagBool(tCmd.Cmd, config.RPCRETRYMUTATING)
 
 	filePath := config.GetFlagString(tCmd.Cmd, config.CURVEFS_CLUSTERMAP)
 	jsonFile, err := os.Open(filePath)
@@ -130,7 +140,7 @@ func (tCmd *TopologyCommand) Init(cmd *cobra.Command, args []string) error {
 
 	updateZoneErr := tCmd.updateZone()
 	if updateZoneErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(updateZoneErr.Message)
+		return updateZoneErr.ToError()
 	}
 
 	table, err := gotable.Create(ROW_NAME, ROW_TYPE, ROW_OPERATION, ROW_PARENT)
@@ -185,7 +195,7 @@ func (tCmd *TopologyCommand) updateTopology() *cmderror.CmdError {
 func (tCmd *TopologyCommand) RunCommand(cmd *cobra.Command, args []string) error {
 	err := tCmd.updateTopology()
 	if err.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(err.Message)
+		return err.ToError()
 	}
 	result, resultErr := cobrautil.TableToResult(tCmd.Table)
 	if resultErr != nil {
@@ -202,7 +212,7 @@ func (tCmd *TopologyCommand) Print(cmd *cobra.Command, args []string) error {
 
 func (tCmd *TopologyCommand) ResultPlainOutput() error {
 	if len(tCmd.createPool) == 0 && len(tCmd.deletePool) == 0 && len(tCmd.createZone) == 0 && len(tCmd.deleteZone) == 0 && len(tCmd.createServer) == 0 && len(tCmd.deleteServer) == 0 {
-		fmt.Println("no change")
+		fmt.Fprintln(tCmd.Cmd.OutOrStdout(), "no change")
 	}
 	return output.FinalCmdOutputPlain(&tCmd.FinalCurveCmd, tCmd)
 }
@@ -30,6 +30,7 @@ import (
 	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/topology"
 	"golang.org/x/exp/slices"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -55,7 +56,15 @@ func (dzRpc *DeleteZoneRpc) Stub_Func(ctx context.Context) (interface{}, error)
 	return dzRpc.topologyClient.DeleteZone(ctx, dzRpc.Request)
 }
 
-var _ basecmd.RpcFunc = (*DeleteZoneRpc)(nil) // check interface
+func (dzRpc *DeleteZoneRpc) IsMutating() bool {
+	return true
+}
+
+func (dzRpc *DeleteZoneRpc) GetRequest() proto.Message {
+	return dzRpc.Request
+}
+
+var _ basecmd.MutatingRpcFunc = (*DeleteZoneRpc)(nil) // check interface
 
 type CreateZoneRpc struct {
 	Info           *basecmd.Rpc
@@ -71,7 +80,15 @@ func (czRpc *CreateZoneRpc) Stub_Func(ctx context.Context) (interface{}, error)
 	return czRpc.topologyClient.CreateZone(ctx, czRpc.Request)
 }
 
-var _ basecmd.RpcFunc = (*CreateZoneRpc)(nil) // check interface
+func (czRpc *CreateZoneRpc) IsMutating() bool {
+	return true
+}
+
+func (czRpc *CreateZoneRpc) GetRequest() proto.Message {
+	return czRpc.Request
+}
+
+var _ basecmd.MutatingRpcFunc = (*CreateZoneRpc)(nil) // check interface
 
 type ListPoolZoneRpc struct {
 	Info           *basecmd.Rpc
@@ -96,8 +113,8 @@ func (tCmd *TopologyCommand) listPoolZone(poolId uint32) (*topology.ListPoolZone
 	tCmd.listPoolZoneRpc = &ListPoolZoneRpc{
 		Request: request,
 	}
-	tCmd.listPoolZoneRpc.Info = basecmd.NewRpc(tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "ListPoolZone")
-	result, err := basecmd.GetRpcResponse(tCmd.listPoolZoneRpc.Info, tCmd.listPoolZoneRpc)
+	tCmd.listPoolZoneRpc.Info = basecmd.NewMdsRpc(tCmd.Cmd, tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "ListPoolZone")
+	result, err := basecmd.GetRpcResponse(tCmd.Cmd.Context(), tCmd.listPoolZoneRpc.Info, tCmd.listPoolZoneRpc)
 	if err.TypeCode() != cmderror.CODE_SUCCESS {
 		return nil, err
 	}
@@ -166,10 +183,14 @@ func (tCmd *TopologyCommand) scanZones() *cmderror.CmdError {
 
 func (tCmd *TopologyCommand) removeZones() *cmderror.CmdError {
 	tCmd.deleteZoneRpc = &DeleteZoneRpc{}
-	tCmd.deleteZoneRpc.Info = basecmd.NewRpc(tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "DeleteServer")
+	tCmd.deleteZoneRpc.Info = basecmd.NewMdsRpc(tCmd.Cmd, tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "DeleteZone")
+	tCmd.deleteZoneRpc.Info.RetryMutating = tCmd.retryMutating
 	for _, delReuest := range tCmd.deleteZone {
 		tCmd.deleteZoneRpc.Request = delReuest
-		result, err := basecmd.GetRpcResponse(tCmd.deleteZoneRpc.Info, tCmd.deleteZoneRpc)
+		result, err := basecmd.GetRpcResponse(tCmd.Cmd.Context(), tCmd.deleteZoneRpc.Info, tCmd.deleteZoneRpc)
+		if basecmd.IsDryRunError(err) {
+			continue
+		}
 		if err.TypeCode() != cmderror.CODE_SUCCESS {
 			return err
 		}
@@ -183,10 +204,14 @@ func (tCmd *TopologyCommand) removeZones() *cmderror.CmdError {
 
 func (tCmd *TopologyCommand) createZones() *cmderror.CmdError {
 	tCmd.createZoneRpc = &CreateZoneRpc{}
-	tCmd.createZoneRpc.Info = basecmd.NewRpc(tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "CreateZone")
+	tCmd.createZoneRpc.Info = basecmd.NewMdsRpc(tCmd.Cmd, tCmd.addrs, tCmd.timeout, tCmd.retryTimes, "CreateZone")
+	tCmd.createZoneRpc.Info.RetryMutating = tCmd.retryMutating
 	for _, crtReuest := range tCmd.createZone {
 		tCmd.createZoneRpc.Request = crtReuest
-		result, err := basecmd.GetRpcResponse(tCmd.createZoneRpc.Info, tCmd.createZoneRpc)
+		result, err := basecmd.GetRpcResponse(tCmd.Cmd.Context(), tCmd.createZoneRpc.Info, tCmd.createZoneRpc)
+		if basecmd.IsDryRunError(err) {
+			continue
+		}
 		if err.TypeCode() != cmderror.CODE_SUCCESS {
 			return err
 		}
@@ -30,12 +30,14 @@ import (
 	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
 	cobrautil "github.com/opencurve/curve/tools-v2/internal/utils"
 	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/fsclient"
 	"github.com/opencurve/curve/tools-v2/pkg/config"
 	"github.com/opencurve/curve/tools-v2/pkg/output"
 	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/mds"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
 type DeleteFsRpc struct {
@@ -44,7 +46,7 @@ type DeleteFsRpc struct {
 	mdsClient mds.MdsServiceClient
 }
 
-var _ basecmd.RpcFunc = (*DeleteFsRpc)(nil) // check interface
+var _ basecmd.MutatingRpcFunc = (*DeleteFsRpc)(nil) // check interface
 
 type FsCommand struct {
 	basecmd.FinalCurveCmd
@@ -61,6 +63,14 @@ func (dfRpc *DeleteFsRpc) Stub_Func(ctx context.Context) (interface{}, error) {
 	return dfRpc.mdsClient.DeleteFs(ctx, dfRpc.Request)
 }
 
+func (dfRpc *DeleteFsRpc) IsMutating() bool {
+	return true
+}
+
+func (dfRpc *DeleteFsRpc) GetRequest() proto.Message {
+	return dfRpc.Request
+}
+
 func NewFsCommand() *cobra.Command {
 	fsCmd := &FsCommand{
 		FinalCurveCmd: basecmd.FinalCurveCmd{
@@ -75,6 +85,8 @@ func NewFsCommand() *cobra.Command {
 func (fCmd *FsCommand) AddFlags() {
 	config.AddRpcRetryTimesFlag(fCmd.Cmd)
 	config.AddRpcTimeoutFlag(fCmd.Cmd)
+	config.AddRpcRetryMutatingFlag(fCmd.Cmd)
+	config.AddDryRunFlag(fCmd.Cmd)
 	config.AddFsMdsAddrFlag(fCmd.Cmd)
 	config.AddFsNameRequiredFlag(fCmd.Cmd)
 	config.AddNoConfirmOptionFlag(fCmd.Cmd)
@@ -83,7 +95,7 @@ func (fCmd *FsCommand) AddFlags() {
 func (fCmd *FsCommand) Init(cmd *cobra.Command, args []string) error {
 	addrs, addrErr := config.GetFsMdsAddrSlice(fCmd.Cmd)
 	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
+		return addrErr.ToError()
 	}
 
 	table, err := gotable.Create("fs name", "result")
@@ -92,7 +104,7 @@ func (fCmd *FsCommand) Init(cmd *cobra.Command, args []string) error {
 	}
 	fCmd.Table = table
 
-	fsName := viper.GetString(config.VIPER_CURVEFS_FSNAME)
+	fsName := config.GetFlagString(fCmd.Cmd, config.CURVEFS_FSNAME)
 
 	request := &mds.DeleteFsRequest{
 		FsName: &fsName,
@@ -102,7 +114,8 @@ func (fCmd *FsCommand) Init(cmd *cobra.Command, args []string) error {
 	}
 	timeout := viper.GetDuration(config.VIPER_GLOBALE_RPCTIMEOUT)
 	retrytimes := viper.GetInt32(config.VIPER_GLOBALE_RPCRETRYTIMES)
-	fCmd.Rpc.Info = basecmd.NewRpc(addrs, timeout, retrytimes, "DeleteFs")
+	fCmd.Rpc.Info = basecmd.NewMdsRpc(fCmd.Cmd, addrs, timeout, retrytimes, "DeleteFs")
+	fCmd.Rpc.Info.RetryMutating = config.GetFlagBool(fCmd.Cmd, config.RPCRETRYMUTATING)
 
 	return nil
 }
@@ -113,16 +126,26 @@ func (fCmd *FsCommand) Print(cmd *cobra.Command, args []string) error {
 
 func (fCmd *FsCommand) RunCommand(cmd *cobra.Command, args []string) error {
 	fsName := fCmd.Rpc.Request.GetFsName()
-	if !viper.GetBool(config.VIPER_CURVEFS_NOCONFIRM) && !cobrautil.AskConfirmation(fmt.Sprintf("Are you sure to delete fs %s?", fsName), fsName) {
+	// nothing is deleted in dry-run mode
+	if !basecmd.IsDryRun(fCmd.Cmd.Context()) && !config.GetFlagBool(fCmd.Cmd, config.CURVEFS_NOCONFIRM) && !cobrautil.AskConfirmation(fmt.Sprintf("Are you sure to delete fs %s?", fsName), fsName) {
 		fCmd.Cmd.SilenceUsage = true
-		return fmt.Errorf("abort delete fs")
+		errAbort := cmderror.ErrAborted()
+		errAbort.Format("delete fs")
+		return errAbort.ToError()
 	}
 
-	result, err := basecmd.GetRpcResponse(fCmd.Rpc.Info, fCmd.Rpc)
-	var errs []*cmderror.CmdError
+	if err := fsclient.SetDryRunFs(fCmd.Cmd, fsName); err.TypeCode() != cmderror.CODE_SUCCESS {
+		fCmd.Cmd.SilenceUsage = true
+		return err.ToError()
+	}
+	result, err := basecmd.GetRpcResponse(fCmd.Cmd.Context(), fCmd.Rpc.Info, fCmd.Rpc)
+	if basecmd.IsDryRunError(err) {
+		return nil
+	}
 	if err.TypeCode() != cmderror.CODE_SUCCESS {
-		errs = append(errs, err)
+		return err.ToError()
 	}
+	var errs []*cmderror.CmdError
 	response := result.(*mds.DeleteFsResponse)
 
 	errDel := cmderror.ErrDeleteFs(int(response.GetStatusCode()))
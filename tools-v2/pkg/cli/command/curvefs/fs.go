@@ -25,6 +25,7 @@ package curvefs
 import (
 	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
 	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/check"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/completion"
 	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/create"
 	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/delete"
 	list "github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/list"
@@ -61,5 +62,7 @@ func NewCurveFsCommand() *cobra.Command {
 			Short: "Manage curvefs cluster",
 		},
 	}
-	return basecmd.NewMidCurveCli(&fsCmd.MidCurveCmd, fsCmd)
+	cmd := basecmd.NewMidCurveCli(&fsCmd.MidCurveCmd, fsCmd)
+	completion.RegisterFlagCompletions(cmd)
+	return cmd
 }
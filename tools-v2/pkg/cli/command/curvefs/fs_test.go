@@ -0,0 +1,371 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package curvefs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/testing/fakecluster"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/viper"
+)
+
+type document struct {
+	Error    *cmderror.CmdError `json:"error"`
+	Result   interface{}        `json:"result"`
+	ExitCode int                `json:"exitCode"`
+}
+
+// startCluster serves the fake cluster, the commands find it by the config
+func startCluster(t *testing.T, modify func(f *fakecluster.Fixture)) *fakecluster.Cluster {
+	// the mutating rpc are recorded in the audit log of $HOME
+	t.Setenv("HOME", t.TempDir())
+	fixture, err := fakecluster.LoadFixture("../../../../testing/fakecluster/testdata/cluster.yaml")
+	So(err, ShouldBeNil)
+	if modify != nil {
+		modify(fixture)
+	}
+	cluster, err := fakecluster.Start(fixture)
+	So(err, ShouldBeNil)
+	So(viper.MergeConfigMap(cluster.Config()), ShouldBeNil)
+	return cluster
+}
+
+// runFs runs the curvefs command with json format and returns the document printed
+func runFs(args ...string) (document, error) {
+	cmd := NewCurveFsCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs(append(args, "--format", "json"))
+	err := cmd.Execute()
+	var doc document
+	if out.Len() > 0 {
+		So(json.Unmarshal(out.Bytes(), &doc), ShouldBeNil)
+	}
+	return doc, err
+}
+
+// rows returns the result of the command printing a table
+func rows(doc document) []map[string]interface{} {
+	var ret []map[string]interface{}
+	for _, row := range doc.Result.([]interface{}) {
+		ret = append(ret, row.(map[string]interface{}))
+	}
+	return ret
+}
+
+func field(doc document, keys ...string) interface{} {
+	var value interface{} = doc.Result
+	for _, key := range keys {
+		value = value.(map[string]interface{})[key]
+	}
+	return value
+}
+
+func TestStatus(t *testing.T) {
+	Convey("the cluster is healthy", t, func() {
+		cluster := startCluster(t, nil)
+		defer cluster.Stop()
+
+		doc, err := runFs("status", "cluster")
+		So(err, ShouldBeNil)
+		So(doc.ExitCode, ShouldEqual, cmderror.EXIT_SUCCESS)
+		So(field(doc, "mds").([]interface{})[0], ShouldContainKey, "status")
+
+		doc, err = runFs("status", "mds")
+		So(err, ShouldBeNil)
+		So(rows(doc)[0]["status"], ShouldEqual, "leader")
+		So(rows(doc)[0]["version"], ShouldEqual, "2.5.0")
+
+		doc, err = runFs("status", "etcd")
+		So(err, ShouldBeNil)
+		So(rows(doc)[0]["status"], ShouldEqual, "leader")
+
+		doc, err = runFs("status", "copyset")
+		So(err, ShouldBeNil)
+		So(rows(doc), ShouldHaveLength, 2)
+	})
+
+	Convey("the metaserver is offline", t, func() {
+		cluster := startCluster(t, func(f *fakecluster.Fixture) {
+			f.Metaservers[2].Offline = true
+		})
+		defer cluster.Stop()
+
+		doc, err := runFs("status", "metaserver")
+		So(err, ShouldNotBeNil)
+		So(doc.ExitCode, ShouldNotEqual, cmderror.EXIT_SUCCESS)
+		_, externalAddr := cluster.MetaserverAddr(3)
+		for _, row := range rows(doc) {
+			if row["externalAddr"] == externalAddr {
+				So(row["status"], ShouldEqual, "offline")
+			} else {
+				So(row["status"], ShouldEqual, "online")
+			}
+		}
+	})
+}
+
+func TestList(t *testing.T) {
+	Convey("the items of cluster are listed", t, func() {
+		cluster := startCluster(t, nil)
+		defer cluster.Stop()
+
+		doc, err := runFs("list", "fs")
+		So(err, ShouldBeNil)
+		So(field(doc, "fsInfo"), ShouldHaveLength, 2)
+
+		doc, err = runFs("list", "mountpoint")
+		So(err, ShouldBeNil)
+		So(rows(doc)[0]["mount point"], ShouldEqual, "curvefs-client1:9000:/usr/local/curvefs/client/mnt")
+
+		doc, err = runFs("list", "partition", "--fsid", "1")
+		So(err, ShouldBeNil)
+		So(rows(doc), ShouldHaveLength, 1)
+
+		doc, err = runFs("list", "topology")
+		So(err, ShouldBeNil)
+		So(field(doc, "clusterId"), ShouldEqual, "8b6a2ba4-fake-cluster")
+
+		doc, err = runFs("list", "copyset")
+		So(err, ShouldBeNil)
+		So(field(doc, "copysetValues"), ShouldHaveLength, 2)
+	})
+}
+
+func TestQuery(t *testing.T) {
+	Convey("the items of cluster are queried", t, func() {
+		cluster := startCluster(t, nil)
+		defer cluster.Stop()
+
+		doc, err := runFs("query", "fs", "--fsname", "test2")
+		So(err, ShouldBeNil)
+		So(rows(doc)[0]["fsInfo"].(map[string]interface{})["fsId"], ShouldEqual, 2)
+
+		doc, err = runFs("query", "inode", "--fsid", "1", "--inodeid", "2")
+		So(err, ShouldBeNil)
+		So(field(doc, "inode", "length"), ShouldEqual, 4096)
+
+		doc, err = runFs("query", "metaserver", "--metaserverid", "2")
+		So(err, ShouldBeNil)
+		So(rows(doc)[0]["MetaServerInfo"].(map[string]interface{})["hostname"], ShouldEqual, "metaserver2")
+
+		doc, err = runFs("query", "partition", "--partitionid", "2")
+		So(err, ShouldBeNil)
+		So(field(doc, "copysetMap", "2", "copysetId"), ShouldEqual, 2)
+
+		_, err = runFs("query", "fs", "--fsname", "unknown")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	Convey("the fs is created", t, func() {
+		cluster := startCluster(t, nil)
+		defer cluster.Stop()
+
+		doc, err := runFs("create", "fs", "--fsname", "test3")
+		So(err, ShouldBeNil)
+		So(field(doc, "fsInfo", "fsId"), ShouldEqual, 3)
+		So(cluster.Calls("CreateFs"), ShouldEqual, 1)
+		So(cluster.Fixture().Fs, ShouldHaveLength, 3)
+
+		doc, err = runFs("create", "fs", "--fsname", "test1")
+		So(err, ShouldBeNil)
+		So(field(doc, "statusCode"), ShouldEqual, "FS_EXIST")
+		So(cluster.Fixture().Fs, ShouldHaveLength, 3)
+	})
+
+	Convey("the topology is created by the cluster map", t, func() {
+		cluster := startCluster(t, nil)
+		defer cluster.Stop()
+		clusterMap := filepath.Join(t.TempDir(), "topology.json")
+		So(os.WriteFile(clusterMap, []byte(`{
+			"servers": [
+				{"name": "server1", "internalip": "127.0.0.1", "internalport": 16701, "externalip": "127.0.0.1", "externalport": 16701, "zone": "zone1", "pool": "pool1"},
+				{"name": "server2", "internalip": "127.0.0.1", "internalport": 16702, "externalip": "127.0.0.1", "externalport": 16702, "zone": "zone2", "pool": "pool1"},
+				{"name": "server3", "internalip": "127.0.0.1", "internalport": 16703, "externalip": "127.0.0.1", "externalport": 16703, "zone": "zone3", "pool": "pool1"},
+				{"name": "server4", "internalip": "127.0.0.1", "internalport": 16704, "externalip": "127.0.0.1", "externalport": 16704, "zone": "zone4", "pool": "pool2"}
+			],
+			"pools": [
+				{"name": "pool1", "replicasnum": 3, "copysetnum": 2, "zonenum": 3},
+				{"name": "pool2", "replicasnum": 1, "copysetnum": 1, "zonenum": 1}
+			],
+			"npools": 2
+		}`), 0644), ShouldBeNil)
+
+		_, err := runFs("create", "topology", "--clustermap", clusterMap)
+		So(err, ShouldBeNil)
+		fixture := cluster.Fixture()
+		So(fixture.Pools, ShouldHaveLength, 2)
+		So(fixture.Pools[1].Name, ShouldEqual, "pool2")
+		So(fixture.Zones[len(fixture.Zones)-1].Name, ShouldEqual, "zone4")
+		So(fixture.Servers[len(fixture.Servers)-1].Name, ShouldEqual, "server4")
+	})
+}
+
+func TestCheck(t *testing.T) {
+	Convey("the copysets are healthy", t, func() {
+		cluster := startCluster(t, nil)
+		defer cluster.Stop()
+
+		doc, err := runFs("check", "copyset", "--copysetid", "1,2", "--poolid", "1,1")
+		So(err, ShouldBeNil)
+		for _, row := range rows(doc) {
+			So(row["status"], ShouldEqual, "ok")
+		}
+	})
+
+	Convey("the peer of copyset is in error", t, func() {
+		cluster := startCluster(t, func(f *fakecluster.Fixture) {
+			f.Copysets[0].States = map[uint32]string{3: "error"}
+		})
+		defer cluster.Stop()
+
+		doc, err := runFs("check", "copyset", "--copysetid", "1", "--poolid", "1")
+		So(err, ShouldNotBeNil)
+		So(rows(doc)[0]["status"], ShouldEqual, "warn")
+	})
+
+	Convey("the mds is unreachable", t, func() {
+		cluster := startCluster(t, nil)
+		defer cluster.Stop()
+
+		// the usage is printed instead of the document
+		for _, command := range []string{"check", "query"} {
+			cmd := NewCurveFsCommand()
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs([]string{command, "copyset", "--copysetid", "1", "--poolid", "1",
+				"--mdsaddr", "127.0.0.1:1", "--rpcretrytimes", "0"})
+			err := cmd.Execute()
+			So(err, ShouldNotBeNil)
+			So(cmderror.ExitCode(err), ShouldEqual, cmderror.EXIT_UNREACHABLE)
+		}
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	Convey("the fs is looked up but not changed in dry-run mode", t, func() {
+		cluster := startCluster(t, nil)
+		defer cluster.Stop()
+
+		for _, args := range [][]string{
+			{"delete", "fs", "--fsname", "test1", "--noconfirm"},
+			{"umount", "fs", "--fsname", "test1", "--mountpoint", "curvefs-client1:9000:/usr/local/curvefs/client/mnt"},
+		} {
+			doc, err := runFs(append(args, "--dry-run")...)
+			So(err, ShouldBeNil)
+			requests := rows(doc)
+			So(requests, ShouldHaveLength, 1)
+			So(requests[0]["request"].(map[string]interface{})["fsName"], ShouldEqual, "test1")
+			So(requests[0]["target"].(map[string]interface{})["fsName"], ShouldEqual, "test1")
+
+			// the dry-run fails as the command if the fs does not exist
+			args[3] = "nosuch"
+			_, err = runFs(append(args, "--dry-run")...)
+			So(err, ShouldNotBeNil)
+		}
+
+		doc, err := runFs("query", "fs", "--fsname", "test1")
+		So(err, ShouldBeNil)
+		So(doc.ExitCode, ShouldEqual, cmderror.EXIT_SUCCESS)
+		So(rows(doc)[0]["fsInfo"].(map[string]interface{})["mountNum"], ShouldEqual, 1)
+	})
+}
+
+func TestWatch(t *testing.T) {
+	Convey("every run of --watch prints its own result", t, func() {
+		cluster := startCluster(t, nil)
+		defer cluster.Stop()
+		viper.Set(config.VIPER_GLOBALE_WATCH, 20*time.Millisecond)
+		defer viper.Set(config.VIPER_GLOBALE_WATCH, time.Duration(0))
+
+		clusterMap := filepath.Join(t.TempDir(), "topology.json")
+		So(os.WriteFile(clusterMap, []byte(`{
+			"servers": [
+				{"name": "server4", "internalip": "127.0.0.1", "internalport": 16704, "externalip": "127.0.0.1", "externalport": 16704, "zone": "zone4", "pool": "pool2"}
+			],
+			"pools": [{"name": "pool2", "replicasnum": 1, "copysetnum": 1, "zonenum": 1}],
+			"npools": 1
+		}`), 0644), ShouldBeNil)
+
+		for _, args := range [][]string{
+			{"create", "topology", "--clustermap", clusterMap, "--dry-run"},
+			{"status", "mds"},
+			{"status", "metaserver"},
+			{"query", "fs", "--fsname", "test1"},
+			{"query", "metaserver", "--metaserverid", "1"},
+			{"list", "partition"},
+		} {
+			cmd := NewCurveFsCommand()
+			ctx, cancel := context.WithCancel(context.Background())
+			// stop between two runs, a deadline may cancel the running one
+			out := &stopWriter{stop: cancel, after: 3}
+			cmd.SetOut(out)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(append(args, "--format", "json"))
+			err := cmd.ExecuteContext(ctx)
+			cancel()
+			So(err, ShouldBeNil)
+
+			// no header is printed between the documents
+			var docs []document
+			decoder := json.NewDecoder(&out.Buffer)
+			for decoder.More() {
+				var doc document
+				So(decoder.Decode(&doc), ShouldBeNil)
+				docs = append(docs, doc)
+			}
+			So(len(docs), ShouldEqual, 3)
+			for _, doc := range docs[1:] {
+				So(doc, ShouldResemble, docs[0])
+			}
+		}
+	})
+}
+
+// stopWriter calls stop after the documents are written, one write per document
+type stopWriter struct {
+	bytes.Buffer
+	stop   context.CancelFunc
+	after  int
+	writes int
+}
+
+func (w *stopWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes == w.after {
+		w.stop()
+	}
+	return w.Buffer.Write(p)
+}
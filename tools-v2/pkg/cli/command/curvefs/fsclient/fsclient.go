@@ -0,0 +1,111 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+// Package fsclient creates the client.Client by the flags and config of curvefs commands
+package fsclient
+
+import (
+	"errors"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/client"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Options returns the client options of cmd, the flags which cmd does not have
+// are got from the config
+func Options(cmd *cobra.Command) (client.Options, *cmderror.CmdError) {
+	addrs, err := config.GetFsMdsAddrSlice(cmd)
+	if err.TypeCode() != cmderror.CODE_SUCCESS {
+		return client.Options{}, err
+	}
+	options := client.Options{
+		MdsAddrs:           addrs,
+		RpcTimeout:         viper.GetDuration(config.VIPER_GLOBALE_RPCTIMEOUT),
+		RpcRetryTimes:      viper.GetInt32(config.VIPER_GLOBALE_RPCRETRYTIMES),
+		HttpTimeout:        viper.GetDuration(config.VIPER_GLOBALE_HTTPTIMEOUT),
+		MdsLeaderCacheFile: basecmd.MdsLeaderCacheFile(),
+	}
+	if config.GetFlagString(cmd, config.CURVEFS_MDSDUMMYADDR) != "" {
+		dummyAddrs, err := config.GetFsMdsDummyAddrSlice(cmd)
+		if err.TypeCode() == cmderror.CODE_SUCCESS {
+			options.MdsDummyAddrs = dummyAddrs
+		}
+	}
+	if config.GetFlagString(cmd, config.CURVEFS_ETCDADDR) != "" {
+		etcdAddrs, err := config.GetFsEtcdAddrSlice(cmd)
+		if err.TypeCode() == cmderror.CODE_SUCCESS {
+			options.EtcdAddrs = etcdAddrs
+		}
+	}
+	return options, cmderror.ErrSuccess()
+}
+
+// New returns the client of the cluster which cmd manages
+func New(cmd *cobra.Command) (*client.Client, *cmderror.CmdError) {
+	options, err := Options(cmd)
+	if err.TypeCode() != cmderror.CODE_SUCCESS {
+		return nil, err
+	}
+	fsClient, newErr := client.New(options)
+	if newErr != nil {
+		retErr := cmderror.ErrGetAddr()
+		retErr.Format(config.CURVEFS_MDSADDR, newErr.Error())
+		return nil, retErr
+	}
+	return fsClient, cmderror.ErrSuccess()
+}
+
+// ToCmdError converts the error returned by the client to the error of commands,
+// the message keeps the context which the error is wrapped with
+func ToCmdError(err error) *cmderror.CmdError {
+	var clientErr *client.Error
+	if err == nil || !errors.As(err, &clientErr) {
+		return cmderror.ToCmdError(err)
+	}
+	retErr := &cmderror.CmdError{
+		Code:    clientErr.Code,
+		Message: err.Error(),
+	}
+	return retErr.WithExitCode(clientErr.ExitCode)
+}
+
+// SetDryRunFs gets the fs by the read-only rpc as the target of the requests
+// in dry-run mode, so the dry-run fails as the command if the fs does not exist
+func SetDryRunFs(cmd *cobra.Command, fsName string) *cmderror.CmdError {
+	dryRun := basecmd.DryRunFromContext(cmd.Context())
+	if dryRun == nil {
+		return cmderror.ErrSuccess()
+	}
+	fsClient, err := New(cmd)
+	if err.TypeCode() != cmderror.CODE_SUCCESS {
+		return err
+	}
+	fsInfo, errGet := fsClient.GetFsInfo(cmd.Context(), fsName)
+	if errGet != nil {
+		return ToCmdError(errGet)
+	}
+	dryRun.SetTarget(fsInfo)
+	return cmderror.ErrSuccess()
+}
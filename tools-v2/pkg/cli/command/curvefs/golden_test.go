@@ -0,0 +1,196 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package curvefs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/testing/fakecluster"
+	"github.com/opencurve/curve/tools-v2/testing/golden"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/cobra"
+)
+
+// the formats whose output is checked in, the new format should be added here
+var goldenFormats = []string{
+	config.FORMAT_PLAIN,
+	config.FORMAT_JSON,
+	config.FORMAT_YAML,
+	config.FORMAT_CSV,
+	config.FORMAT_TSV,
+}
+
+// goldenCase runs the command on a new fake cluster,
+// args returns the arguments which may depend on the cluster
+type goldenCase struct {
+	name string
+	args func(t *testing.T, cluster *fakecluster.Cluster) []string
+}
+
+func fixedArgs(args ...string) func(*testing.T, *fakecluster.Cluster) []string {
+	return func(*testing.T, *fakecluster.Cluster) []string {
+		return args
+	}
+}
+
+// every command of curvefs should have a case at least
+var goldenCases = []goldenCase{
+	{"check-copyset", fixedArgs("check", "copyset", "--copysetid", "1,2", "--poolid", "1,1")},
+	{"create-fs", fixedArgs("create", "fs", "--fsname", "test3")},
+	{"create-topology", func(t *testing.T, cluster *fakecluster.Cluster) []string {
+		clusterMap := filepath.Join(t.TempDir(), "topology.json")
+		So(os.WriteFile(clusterMap, []byte(`{
+			"servers": [
+				{"name": "server1", "internalip": "127.0.0.1", "internalport": 16701, "externalip": "127.0.0.1", "externalport": 16701, "zone": "zone1", "pool": "pool1"},
+				{"name": "server2", "internalip": "127.0.0.1", "internalport": 16702, "externalip": "127.0.0.1", "externalport": 16702, "zone": "zone2", "pool": "pool1"},
+				{"name": "server3", "internalip": "127.0.0.1", "internalport": 16703, "externalip": "127.0.0.1", "externalport": 16703, "zone": "zone3", "pool": "pool1"},
+				{"name": "server4", "internalip": "127.0.0.1", "internalport": 16704, "externalip": "127.0.0.1", "externalport": 16704, "zone": "zone4", "pool": "pool2"}
+			],
+			"pools": [
+				{"name": "pool1", "replicasnum": 3, "copysetnum": 2, "zonenum": 3},
+				{"name": "pool2", "replicasnum": 1, "copysetnum": 1, "zonenum": 1}
+			],
+			"npools": 2
+		}`), 0644), ShouldBeNil)
+		return []string{"create", "topology", "--clustermap", clusterMap}
+	}},
+	{"delete-fs", fixedArgs("delete", "fs", "--fsname", "test2", "--noconfirm")},
+	{"list-copyset", fixedArgs("list", "copyset")},
+	{"list-fs", fixedArgs("list", "fs")},
+	{"list-mountpoint", fixedArgs("list", "mountpoint")},
+	{"list-partition", fixedArgs("list", "partition")},
+	// the json and yaml output is the rows of the table if any table option is set
+	{"list-partition-table-option", fixedArgs("list", "partition", "--columns", "partition id,fs id,status", "--sort-by", "-partition id")},
+	{"list-topology", fixedArgs("list", "topology")},
+	{"query-copyset", fixedArgs("query", "copyset", "--copysetid", "1,2", "--poolid", "1,1")},
+	{"query-fs", fixedArgs("query", "fs", "--fsname", "test1,test2")},
+	{"query-inode", fixedArgs("query", "inode", "--fsid", "1", "--inodeid", "2")},
+	{"query-metaserver", fixedArgs("query", "metaserver", "--metaserverid", "1,2")},
+	{"query-partition", fixedArgs("query", "partition", "--partitionid", "1,2")},
+	{"status-cluster", fixedArgs("status", "cluster")},
+	{"status-copyset", fixedArgs("status", "copyset")},
+	{"status-etcd", fixedArgs("status", "etcd")},
+	{"status-mds", fixedArgs("status", "mds")},
+	{"status-metaserver", fixedArgs("status", "metaserver")},
+	{"umount-fs", fixedArgs("umount", "fs", "--fsname", "test1", "--mountpoint", "curvefs-client1:9000:/usr/local/curvefs/client/mnt")},
+	{"usage-inode", fixedArgs("usage", "inode")},
+	{"usage-metadata", fixedArgs("usage", "metadata")},
+}
+
+// the cases print several tables with different columns and have no table
+// for csv and tsv format, which only fail with "the command has no table to output"
+var multiTableCases = map[string]bool{
+	"status-cluster": true,
+}
+
+func isTableFormat(format string) bool {
+	return format == config.FORMAT_CSV || format == config.FORMAT_TSV
+}
+
+// runGolden runs the command and returns all it prints,
+// the addresses of cluster are replaced by the names of servers
+func runGolden(cluster *fakecluster.Cluster, args []string, format string) string {
+	cmd := NewCurveFsCommand()
+	// the usage changes with the flags, it is not the output of command
+	cmd.SilenceUsage = true
+	// they are the global flags of curve
+	config.AddTablePFlags(cmd)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(append(args, "--format", format))
+	cmd.Execute()
+	return anonymize(cluster, out.String())
+}
+
+func anonymize(cluster *fakecluster.Cluster, text string) string {
+	addrs := map[string]string{
+		"mds":      cluster.MdsAddr,
+		"mdsDummy": cluster.MdsDummyAddr,
+		"etcd":     cluster.EtcdAddr,
+	}
+	for _, ms := range cluster.Fixture().Metaservers {
+		internal, external := cluster.MetaserverAddr(ms.Id)
+		addrs[fmt.Sprintf("metaserver%d", ms.Id)] = internal
+		addrs[fmt.Sprintf("metaserver%dExternal", ms.Id)] = external
+	}
+	for name, addr := range addrs {
+		if addr == "" {
+			continue
+		}
+		host, port, _ := strings.Cut(addr, ":")
+		text = strings.ReplaceAll(text, addr, fmt.Sprintf("%s:<%s>", host, name))
+		// the port may be printed alone, e.g. internalPort of metaserver.
+		// the columns of tables in plain format are not aligned after that
+		portRegex := regexp.MustCompile(`(Port"?: )` + port + `\b`)
+		text = portRegex.ReplaceAllString(text, fmt.Sprintf("${1}<%s>", name))
+	}
+	return text
+}
+
+func leafCommands(cmd *cobra.Command) []string {
+	if !cmd.HasSubCommands() {
+		return []string{cmd.CommandPath()}
+	}
+	var paths []string
+	for _, sub := range cmd.Commands() {
+		paths = append(paths, leafCommands(sub)...)
+	}
+	return paths
+}
+
+func TestGolden(t *testing.T) {
+	Convey("every command has a golden case", t, func() {
+		covered := make(map[string]bool)
+		for _, c := range goldenCases {
+			covered[c.name] = true
+		}
+		for _, path := range leafCommands(NewCurveFsCommand()) {
+			name := strings.ReplaceAll(strings.TrimPrefix(path, "fs "), " ", "-")
+			So(covered, ShouldContainKey, name)
+		}
+	})
+
+	for _, c := range goldenCases {
+		for _, format := range goldenFormats {
+			if multiTableCases[c.name] && isTableFormat(format) {
+				continue
+			}
+			Convey(fmt.Sprintf("the output of %s in %s format", c.name, format), t, func() {
+				cluster := startCluster(t, nil)
+				defer cluster.Stop()
+
+				got := runGolden(cluster, c.args(t, cluster), format)
+				want, err := golden.Read(filepath.Join("testdata", "golden", c.name+"."+format), []byte(got))
+				So(err, ShouldBeNil)
+				So(got, ShouldEqual, string(want))
+			})
+		}
+	}
+}
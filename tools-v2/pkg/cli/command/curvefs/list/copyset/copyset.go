@@ -82,17 +82,6 @@ func NewCopysetCommand() *cobra.Command {
 	return copysetCmd.Cmd
 }
 
-func NewListCopysetCommand() *CopysetCommand {
-	copysetCmd := &CopysetCommand{
-		FinalCurveCmd: basecmd.FinalCurveCmd{
-			Use:   "copyset",
-			Short: "list all copyset info of the curvefs",
-		},
-	}
-	basecmd.NewFinalCurveCli(&copysetCmd.FinalCurveCmd, copysetCmd)
-	return copysetCmd
-}
-
 func (cCmd *CopysetCommand) AddFlags() {
 	config.AddRpcRetryTimesFlag(cCmd.Cmd)
 	config.AddRpcTimeoutFlag(cCmd.Cmd)
@@ -102,13 +91,13 @@ func (cCmd *CopysetCommand) AddFlags() {
 func (cCmd *CopysetCommand) Init(cmd *cobra.Command, args []string) error {
 	addrs, addrErr := config.GetFsMdsAddrSlice(cCmd.Cmd)
 	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
+		return addrErr.ToError()
 	}
 	cCmd.Rpc = &ListCopysetRpc{}
 	cCmd.Rpc.Request = &topology.ListCopysetInfoRequest{}
 	timeout := viper.GetDuration(config.VIPER_GLOBALE_RPCTIMEOUT)
 	retrytimes := viper.GetInt32(config.VIPER_GLOBALE_RPCRETRYTIMES)
-	cCmd.Rpc.Info = basecmd.NewRpc(addrs, timeout, retrytimes, "ListCopysetInfo")
+	cCmd.Rpc.Info = basecmd.NewMdsRpc(cCmd.Cmd, addrs, timeout, retrytimes, "ListCopysetInfo")
 
 	table, err := gotable.Create(ROW_KEY, ROW_COPYSET_ID, ROW_POOL_ID, ROW_EPOCH, ROW_LEADER_PEER, ROW_PEER_NUMBER)
 	if err != nil {
@@ -123,9 +112,9 @@ func (cCmd *CopysetCommand) Print(cmd *cobra.Command, args []string) error {
 }
 
 func (cCmd *CopysetCommand) RunCommand(cmd *cobra.Command, args []string) error {
-	response, errCmd := basecmd.GetRpcResponse(cCmd.Rpc.Info, cCmd.Rpc)
+	response, errCmd := basecmd.GetRpcResponse(cCmd.Cmd.Context(), cCmd.Rpc.Info, cCmd.Rpc)
 	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(errCmd.Message)
+		return errCmd.ToError()
 	}
 	cCmd.response = response.(*topology.ListCopysetInfoResponse)
 	res, err := output.MarshalProtoJson(cCmd.response)
@@ -155,7 +144,7 @@ func (cCmd *CopysetCommand) updateTable() {
 			row[ROW_LEADER_PEER] = ""
 			row[ROW_PEER_NUMBER] = fmt.Sprintf("%d", len(info.GetPeers()))
 		} else {
-			row[ROW_LEADER_PEER] = info.GetLeaderPeer().String()
+			row[ROW_LEADER_PEER] = cobrautil.PeerString(info.GetLeaderPeer())
 			peerNum := 0
 			for _, peer := range info.GetPeers() {
 				if peer != nil {
@@ -172,19 +161,3 @@ func (cCmd *CopysetCommand) updateTable() {
 func (cCmd *CopysetCommand) ResultPlainOutput() error {
 	return output.FinalCmdOutputPlain(&cCmd.FinalCurveCmd, cCmd)
 }
-
-func GetCopysetsInfos(caller *cobra.Command) (*topology.ListCopysetInfoResponse, *cmderror.CmdError) {
-	listCopyset := NewListCopysetCommand()
-	listCopyset.Cmd.SetArgs([]string{
-		fmt.Sprintf("--%s", config.FORMAT), config.FORMAT_NOOUT,
-	})
-	cobrautil.AlignFlags(caller, listCopyset.Cmd, []string{config.RPCRETRYTIMES, config.RPCTIMEOUT, config.CURVEFS_MDSADDR})
-	listCopyset.Cmd.SilenceUsage = true
-	err := listCopyset.Cmd.Execute()
-	if err != nil {
-		retErr := cmderror.ErrListCopyset()
-		retErr.Format(err)
-		return nil, retErr
-	}
-	return listCopyset.response, cmderror.ErrSuccess()
-}
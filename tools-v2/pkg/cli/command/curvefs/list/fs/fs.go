@@ -23,7 +23,6 @@
 package fs
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 
@@ -31,38 +30,22 @@ import (
 	"github.com/liushuochen/gotable/table"
 	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
 	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/fsclient"
+	"github.com/opencurve/curve/tools-v2/pkg/client"
 	"github.com/opencurve/curve/tools-v2/pkg/config"
 	"github.com/opencurve/curve/tools-v2/pkg/output"
 	mds "github.com/opencurve/curve/tools-v2/proto/curvefs/proto/mds"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-	"google.golang.org/grpc"
 )
 
-type ListFsRpc struct {
-	Info      *basecmd.Rpc
-	Request   *mds.ListClusterFsInfoRequest
-	mdsClient mds.MdsServiceClient
-}
-
-var _ basecmd.RpcFunc = (*ListFsRpc)(nil) // check interface
-
 type FsCommand struct {
 	basecmd.FinalCurveCmd
-	Rpc      *ListFsRpc
+	client   *client.Client
 	response *mds.ListClusterFsInfoResponse
 }
 
 var _ basecmd.FinalCurveCmdFunc = (*FsCommand)(nil) // check interface
 
-func (fRpc *ListFsRpc) NewRpcClient(cc grpc.ClientConnInterface) {
-	fRpc.mdsClient = mds.NewMdsServiceClient(cc)
-}
-
-func (fRpc *ListFsRpc) Stub_Func(ctx context.Context) (interface{}, error) {
-	return fRpc.mdsClient.ListClusterFsInfo(ctx, fRpc.Request)
-}
-
 func NewFsCommand() *cobra.Command {
 	fsCmd := &FsCommand{
 		FinalCurveCmd: basecmd.FinalCurveCmd{
@@ -74,15 +57,6 @@ func NewFsCommand() *cobra.Command {
 	return fsCmd.Cmd
 }
 
-func NewListFsCommand() *FsCommand {
-	listFsCmd := &FsCommand{
-		FinalCurveCmd: basecmd.FinalCurveCmd{},
-	}
-
-	basecmd.NewFinalCurveCli(&listFsCmd.FinalCurveCmd, listFsCmd)
-	return listFsCmd
-}
-
 func (fCmd *FsCommand) AddFlags() {
 	config.AddRpcRetryTimesFlag(fCmd.Cmd)
 	config.AddRpcTimeoutFlag(fCmd.Cmd)
@@ -90,15 +64,11 @@ func (fCmd *FsCommand) AddFlags() {
 }
 
 func (fCmd *FsCommand) Init(cmd *cobra.Command, args []string) error {
-	addrs, addrErr := config.GetFsMdsAddrSlice(fCmd.Cmd)
-	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
+	var errCmd *cmderror.CmdError
+	fCmd.client, errCmd = fsclient.New(fCmd.Cmd)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		return errCmd.ToError()
 	}
-	fCmd.Rpc = &ListFsRpc{}
-	fCmd.Rpc.Request = &mds.ListClusterFsInfoRequest{}
-	timeout := viper.GetDuration(config.VIPER_GLOBALE_RPCTIMEOUT)
-	retrytimes := viper.GetInt32(config.VIPER_GLOBALE_RPCRETRYTIMES)
-	fCmd.Rpc.Info = basecmd.NewRpc(addrs, timeout, retrytimes, "ListClusterFsInfo")
 
 	table, err := gotable.Create("id", "name", "status", "capacity", "blockSize", "fsType", "sumInDir", "owner", "mountNum")
 	if err != nil {
@@ -113,11 +83,11 @@ func (fCmd *FsCommand) Print(cmd *cobra.Command, args []string) error {
 }
 
 func (fCmd *FsCommand) RunCommand(cmd *cobra.Command, args []string) error {
-	response, errCmd := basecmd.GetRpcResponse(fCmd.Rpc.Info, fCmd.Rpc)
-	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(errCmd.Message)
+	fsInfo, err := fCmd.client.ListFs(fCmd.Cmd.Context())
+	if err != nil {
+		return fsclient.ToCmdError(err).ToError()
 	}
-	fCmd.response = response.(*mds.ListClusterFsInfoResponse)
+	fCmd.response = &mds.ListClusterFsInfoResponse{FsInfo: fsInfo}
 	res, err := output.MarshalProtoJson(fCmd.response)
 	if err != nil {
 		return err
@@ -153,16 +123,17 @@ func (fCmd *FsCommand) ResultPlainOutput() error {
 }
 
 func GetClusterFsInfo(caller *cobra.Command) (*mds.ListClusterFsInfoResponse, *cmderror.CmdError) {
-	listFs := NewListFsCommand()
-	listFs.Cmd.SetArgs([]string{"--format", "noout"})
-	listFs.Cmd.SilenceUsage = true
-	err := listFs.Cmd.Execute()
+	fsClient, errCmd := fsclient.New(caller)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		return nil, errCmd
+	}
+	fsInfo, err := fsClient.ListFs(caller.Context())
 	if err != nil {
 		retErr := cmderror.ErrGetClusterFsInfo()
 		retErr.Format(err.Error())
 		return nil, retErr
 	}
-	return listFs.response, cmderror.ErrSuccess()
+	return &mds.ListClusterFsInfoResponse{FsInfo: fsInfo}, cmderror.ErrSuccess()
 }
 
 func GetFsIds(caller *cobra.Command) ([]string, *cmderror.CmdError) {
@@ -65,7 +65,7 @@ func (mpCmd *MountpointCommand) Init(cmd *cobra.Command, args []string) error {
 	var fsInfoErr *cmderror.CmdError
 	mpCmd.fsInfo, fsInfoErr = fs.GetClusterFsInfo(mpCmd.Cmd)
 	if fsInfoErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(fsInfoErr.Message)
+		return fsInfoErr.ToError()
 	}
 	mpCmd.Error = fsInfoErr
 
@@ -25,16 +25,15 @@ package partition
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/liushuochen/gotable"
 	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
-	cobrautil "github.com/opencurve/curve/tools-v2/internal/utils"
 	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
 	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/list/fs"
 	"github.com/opencurve/curve/tools-v2/pkg/config"
 	"github.com/opencurve/curve/tools-v2/pkg/output"
-	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/common"
 	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/topology"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -51,9 +50,8 @@ var _ basecmd.RpcFunc = (*ListPartitionRpc)(nil) // check interface
 
 type PartitionCommand struct {
 	basecmd.FinalCurveCmd
-	Rpc                []*ListPartitionRpc
-	fsId2Rows          map[uint32][]map[string]string
-	fsId2PartitionList map[uint32][]*common.PartitionInfo
+	Rpc       []*ListPartitionRpc
+	fsId2Rows map[uint32][]map[string]string
 }
 
 var _ basecmd.FinalCurveCmdFunc = (*PartitionCommand)(nil) // check interface
@@ -87,7 +85,7 @@ func (pCmd *PartitionCommand) AddFlags() {
 func (pCmd *PartitionCommand) Init(cmd *cobra.Command, args []string) error {
 	addrs, addrErr := config.GetFsMdsAddrSlice(pCmd.Cmd)
 	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
+		return addrErr.ToError()
 	}
 
 	table, err := gotable.Create("partition id", "fs id", "pool id", "copyset id", "start", "end", "status")
@@ -100,11 +98,11 @@ func (pCmd *PartitionCommand) Init(cmd *cobra.Command, args []string) error {
 		var getFsIdErr *cmderror.CmdError
 		fsIds, getFsIdErr = fs.GetFsIds(pCmd.Cmd)
 		if getFsIdErr.TypeCode() != cmderror.CODE_SUCCESS {
-			return fmt.Errorf(getFsIdErr.Message)
+			return getFsIdErr.ToError()
 		}
 	}
 	pCmd.fsId2Rows = make(map[uint32][]map[string]string)
-	pCmd.fsId2PartitionList = make(map[uint32][]*common.PartitionInfo)
+	pCmd.Rpc = make([]*ListPartitionRpc, 0)
 	for _, fsId := range fsIds {
 		id, err := strconv.ParseUint(fsId, 10, 32)
 		if err != nil {
@@ -118,7 +116,7 @@ func (pCmd *PartitionCommand) Init(cmd *cobra.Command, args []string) error {
 		}
 		timeout := viper.GetDuration(config.VIPER_GLOBALE_RPCTIMEOUT)
 		retrytimes := viper.GetInt32(config.VIPER_GLOBALE_RPCRETRYTIMES)
-		rpc.Info = basecmd.NewRpc(addrs, timeout, retrytimes, "ListPartition")
+		rpc.Info = basecmd.NewMdsRpc(pCmd.Cmd, addrs, timeout, retrytimes, "ListPartition")
 		pCmd.Rpc = append(pCmd.Rpc, rpc)
 		pCmd.fsId2Rows[id32] = make([]map[string]string, 1)
 		pCmd.fsId2Rows[id32][0] = make(map[string]string)
@@ -129,7 +127,6 @@ func (pCmd *PartitionCommand) Init(cmd *cobra.Command, args []string) error {
 		pCmd.fsId2Rows[id32][0]["start"] = "DNE"
 		pCmd.fsId2Rows[id32][0]["end"] = "DNE"
 		pCmd.fsId2Rows[id32][0]["status"] = "DNE"
-		pCmd.fsId2PartitionList[id32] = make([]*common.PartitionInfo, 0)
 	}
 
 	return nil
@@ -146,7 +143,7 @@ func (pCmd *PartitionCommand) RunCommand(cmd *cobra.Command, args []string) erro
 		infos = append(infos, rpc.Info)
 		funcs = append(funcs, rpc)
 	}
-	results, err := basecmd.GetRpcListResponse(infos, funcs)
+	results, err := basecmd.GetRpcListResponse(pCmd.Cmd.Context(), infos, funcs)
 	var errs []*cmderror.CmdError
 	var resList []interface{}
 	errs = append(errs, err)
@@ -164,7 +161,6 @@ func (pCmd *PartitionCommand) RunCommand(cmd *cobra.Command, args []string) erro
 		for _, partition := range partitionList {
 			fsId := partition.GetFsId()
 			rows := pCmd.fsId2Rows[fsId]
-			pCmd.fsId2PartitionList[fsId] = append(pCmd.fsId2PartitionList[fsId], partition)
 			var row map[string]string
 			if len(rows) == 1 && rows[0]["pool id"] == "DNE" {
 				row = rows[0]
@@ -193,34 +189,16 @@ func (pCmd *PartitionCommand) ResultPlainOutput() error {
 }
 
 func (pCmd *PartitionCommand) updateTable() {
-	for _, rows := range pCmd.fsId2Rows {
-		for _, row := range rows {
+	fsIds := make([]uint32, 0, len(pCmd.fsId2Rows))
+	for fsId := range pCmd.fsId2Rows {
+		fsIds = append(fsIds, fsId)
+	}
+	sort.Slice(fsIds, func(i, j int) bool {
+		return fsIds[i] < fsIds[j]
+	})
+	for _, fsId := range fsIds {
+		for _, row := range pCmd.fsId2Rows[fsId] {
 			pCmd.Table.AddRow(row)
 		}
 	}
 }
-
-func NewListPartitionCommand() *PartitionCommand {
-	pCmd := &PartitionCommand{
-		FinalCurveCmd: basecmd.FinalCurveCmd{
-			Use:   "partition",
-			Short: "list partition in curvefs by fsid",
-		},
-	}
-	basecmd.NewFinalCurveCli(&pCmd.FinalCurveCmd, pCmd)
-	return pCmd
-}
-
-func GetFsPartition(caller *cobra.Command) *map[uint32][]*common.PartitionInfo {
-	listPartionCmd := NewListPartitionCommand()
-	listPartionCmd.Cmd.SetArgs([]string{
-		fmt.Sprintf("--%s", config.FORMAT), config.FORMAT_NOOUT,
-	})
-	cobrautil.AlignFlags(caller, listPartionCmd.Cmd, []string{
-		config.RPCRETRYTIMES, config.RPCTIMEOUT, config.CURVEFS_MDSADDR,
-		config.CURVEFS_FSID,
-	})
-	listPartionCmd.Cmd.SilenceUsage = true
-	listPartionCmd.Cmd.Execute()
-	return &listPartionCmd.fsId2PartitionList
-}
@@ -23,7 +23,6 @@
 package topology
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -32,40 +31,21 @@ import (
 	"github.com/liushuochen/gotable/table"
 	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
 	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/fsclient"
+	"github.com/opencurve/curve/tools-v2/pkg/client"
 	"github.com/opencurve/curve/tools-v2/pkg/config"
 	"github.com/opencurve/curve/tools-v2/pkg/output"
 	topology "github.com/opencurve/curve/tools-v2/proto/curvefs/proto/topology"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-	"google.golang.org/grpc"
 )
 
-type ListTopologyRpc struct {
-	Info           *basecmd.Rpc
-	Request        *topology.ListTopologyRequest
-	topologyClient topology.TopologyServiceClient
-	Response       *topology.ListTopologyResponse
-}
-
-var _ basecmd.RpcFunc = (*ListTopologyRpc)(nil) // check interface
-
 type TopologyCommand struct {
 	basecmd.FinalCurveCmd
-	Rpc          ListTopologyRpc
-	externalAddr []string
-	internalAddr []string
+	client *client.Client
 }
 
 var _ basecmd.FinalCurveCmdFunc = (*TopologyCommand)(nil) // check interface
 
-func (lRpc *ListTopologyRpc) NewRpcClient(cc grpc.ClientConnInterface) {
-	lRpc.topologyClient = topology.NewTopologyServiceClient(cc)
-}
-
-func (lRpc *ListTopologyRpc) Stub_Func(ctx context.Context) (interface{}, error) {
-	return lRpc.topologyClient.ListTopology(ctx, lRpc.Request)
-}
-
 func NewTopologyCommand() *cobra.Command {
 	topologyCmd := &TopologyCommand{
 		FinalCurveCmd: basecmd.FinalCurveCmd{
@@ -77,36 +57,27 @@ func NewTopologyCommand() *cobra.Command {
 	return topologyCmd.Cmd
 }
 
-func GetMetaserverAddrs() ([]string, []string, *cmderror.CmdError) {
-	listTopo := NewListTopologyCommand()
-	listTopo.Cmd.SetArgs([]string{"--format", "noout"})
-	err := listTopo.Cmd.Execute()
-	if err != nil {
-		retErr := cmderror.ErrGetMetaserverAddr()
-		retErr.Format(err.Error())
-		return nil, nil, retErr
+func GetMetaserverAddrs(caller *cobra.Command) ([]string, []string, *cmderror.CmdError) {
+	response, err := GetTopology(caller)
+	if err.TypeCode() != cmderror.CODE_SUCCESS {
+		return nil, nil, err
 	}
-	return listTopo.externalAddr, listTopo.internalAddr, cmderror.ErrSuccess()
+	externalAddrs, internalAddrs := metaserverAddrs(response.GetMetaservers().GetMetaServerInfos())
+	return externalAddrs, internalAddrs, cmderror.ErrSuccess()
 }
 
-func GetTopology() (*topology.ListTopologyResponse, *cmderror.CmdError) {
-	listTopo := NewListTopologyCommand()
-	listTopo.Cmd.SetArgs([]string{"--format", "noout"})
-	err := listTopo.Cmd.Execute()
+func GetTopology(caller *cobra.Command) (*topology.ListTopologyResponse, *cmderror.CmdError) {
+	fsClient, errCmd := fsclient.New(caller)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		return nil, errCmd
+	}
+	response, err := fsClient.ListTopology(caller.Context())
 	if err != nil {
 		retErr := cmderror.ErrGetMetaserverAddr()
 		retErr.Format(err.Error())
 		return nil, retErr
 	}
-	return listTopo.Rpc.Response, cmderror.ErrSuccess()
-}
-
-func NewListTopologyCommand() *TopologyCommand {
-	topologyCmd := &TopologyCommand{
-		FinalCurveCmd: basecmd.FinalCurveCmd{},
-	}
-	basecmd.NewFinalCurveCli(&topologyCmd.FinalCurveCmd, topologyCmd)
-	return topologyCmd
+	return response, cmderror.ErrSuccess()
 }
 
 func (tCmd *TopologyCommand) AddFlags() {
@@ -116,14 +87,11 @@ func (tCmd *TopologyCommand) AddFlags() {
 }
 
 func (tCmd *TopologyCommand) Init(cmd *cobra.Command, args []string) error {
-	addrs, addrErr := config.GetFsMdsAddrSlice(tCmd.Cmd)
-	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
+	var errCmd *cmderror.CmdError
+	tCmd.client, errCmd = fsclient.New(tCmd.Cmd)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		return errCmd.ToError()
 	}
-	tCmd.Rpc.Request = &topology.ListTopologyRequest{}
-	timeout := viper.GetDuration(config.VIPER_GLOBALE_RPCTIMEOUT)
-	retrytimes := viper.GetInt32(config.VIPER_GLOBALE_RPCRETRYTIMES)
-	tCmd.Rpc.Info = basecmd.NewRpc(addrs, timeout, retrytimes, "ListTopology")
 
 	table, err := gotable.Create("id", "type", "name", "child type", "child list")
 	if err != nil {
@@ -138,13 +106,11 @@ func (tCmd *TopologyCommand) Print(cmd *cobra.Command, args []string) error {
 }
 
 func (tCmd *TopologyCommand) RunCommand(cmd *cobra.Command, args []string) error {
-	response, errCmd := basecmd.GetRpcResponse(tCmd.Rpc.Info, &tCmd.Rpc)
-	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(errCmd.Message)
+	topologyResponse, err := tCmd.client.ListTopology(tCmd.Cmd.Context())
+	if err != nil {
+		return fsclient.ToCmdError(err).ToError()
 	}
-	tCmd.Error = errCmd
-	topologyResponse := response.(*topology.ListTopologyResponse)
-	tCmd.Rpc.Response = topologyResponse
+	tCmd.Error = cmderror.ErrSuccess()
 	res, err := output.MarshalProtoJson(topologyResponse)
 	if err != nil {
 		return err
@@ -154,10 +120,8 @@ func (tCmd *TopologyCommand) RunCommand(cmd *cobra.Command, args []string) error
 	updateTable(tCmd.Table, topologyResponse)
 	errs := updateJsonPoolInfoRedundanceAndPlaceMentPolicy(&mapRes, topologyResponse)
 	if len(errs) > 0 {
-		return fmt.Errorf(cmderror.MostImportantCmdError(errs).Message)
+		return cmderror.MostImportantCmdError(errs).ToError()
 	}
-
-	tCmd.updateMetaserverAddr(topologyResponse.GetMetaservers().MetaServerInfos)
 	return nil
 }
 
@@ -244,14 +208,16 @@ func updateTable(table *table.Table, topology *topology.ListTopologyResponse) {
 	table.AddRows(rows)
 }
 
-func (tCmd *TopologyCommand) updateMetaserverAddr(metaservers []*topology.MetaServerInfo) {
+func metaserverAddrs(metaservers []*topology.MetaServerInfo) ([]string, []string) {
+	var externalAddrs, internalAddrs []string
 	for _, metaserver := range metaservers {
 		internalAddr := fmt.Sprintf("%s:%d", metaserver.GetInternalIp(), metaserver.GetInternalPort())
-		tCmd.internalAddr = append(tCmd.internalAddr, internalAddr)
+		internalAddrs = append(internalAddrs, internalAddr)
 
 		externalAddr := fmt.Sprintf("%s:%d", metaserver.GetExternalIp(), metaserver.GetExternalPort())
-		tCmd.externalAddr = append(tCmd.externalAddr, externalAddr)
+		externalAddrs = append(externalAddrs, externalAddr)
 	}
+	return externalAddrs, internalAddrs
 }
 
 func updateJsonPoolInfoRedundanceAndPlaceMentPolicy(topologyMap *map[string]interface{}, topology *topology.ListTopologyResponse) []*cmderror.CmdError {
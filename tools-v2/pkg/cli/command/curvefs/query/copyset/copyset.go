@@ -23,7 +23,6 @@
 package copyset
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 
@@ -31,42 +30,23 @@ import (
 	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
 	cobrautil "github.com/opencurve/curve/tools-v2/internal/utils"
 	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/fsclient"
+	"github.com/opencurve/curve/tools-v2/pkg/client"
 	"github.com/opencurve/curve/tools-v2/pkg/config"
 	"github.com/opencurve/curve/tools-v2/pkg/output"
 	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/copyset"
-	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/heartbeat"
-	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/topology"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-	"golang.org/x/exp/slices"
-	"google.golang.org/grpc"
 )
 
-type QueryCopysetRpc struct {
-	Info           *basecmd.Rpc
-	Request        *topology.GetCopysetsInfoRequest
-	topologyClient topology.TopologyServiceClient
-}
-
-var _ basecmd.RpcFunc = (*QueryCopysetRpc)(nil) // check interface
-
 type CopysetCommand struct {
 	basecmd.FinalCurveCmd
-	Rpc         *QueryCopysetRpc
-	Rows        []map[string]string
-	key2Copyset map[uint64]*cobrautil.CopysetInfoStatus
+	client      *client.Client
+	options     client.CopysetOptions
+	key2Copyset map[uint64]*client.CopysetStatus
 }
 
 var _ basecmd.FinalCurveCmdFunc = (*CopysetCommand)(nil) // check interface
 
-func (qcRpc *QueryCopysetRpc) NewRpcClient(cc grpc.ClientConnInterface) {
-	qcRpc.topologyClient = topology.NewTopologyServiceClient(cc)
-}
-
-func (qcRpc *QueryCopysetRpc) Stub_Func(ctx context.Context) (interface{}, error) {
-	return qcRpc.topologyClient.GetCopysetsInfo(ctx, qcRpc.Request)
-}
-
 func NewCopysetCommand() *cobra.Command {
 	copysetCmd := &CopysetCommand{
 		FinalCurveCmd: basecmd.FinalCurveCmd{
@@ -78,14 +58,6 @@ func NewCopysetCommand() *cobra.Command {
 	return copysetCmd.Cmd
 }
 
-func NewQueryCopysetCommand() *CopysetCommand {
-	queryCopysetCmd := &CopysetCommand{
-		FinalCurveCmd: basecmd.FinalCurveCmd{},
-	}
-	basecmd.NewFinalCurveCli(&queryCopysetCmd.FinalCurveCmd, queryCopysetCmd)
-	return queryCopysetCmd
-}
-
 func (cCmd *CopysetCommand) AddFlags() {
 	config.AddRpcRetryTimesFlag(cCmd.Cmd)
 	config.AddRpcTimeoutFlag(cCmd.Cmd)
@@ -95,64 +67,49 @@ func (cCmd *CopysetCommand) AddFlags() {
 	config.AddDetailOptionFlag(cCmd.Cmd)
 }
 
-func (cCmd *CopysetCommand) Init(cmd *cobra.Command, args []string) error {
-	addrs, addrErr := config.GetFsMdsAddrSlice(cCmd.Cmd)
-	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
+// the copysets of --poolid and --copysetid
+func copysetKeys(cmd *cobra.Command) ([]client.CopysetKey, error) {
+	poolidsStr := config.GetFlagStringSlice(cmd, config.CURVEFS_POOLID)
+	copysetidsStr := config.GetFlagStringSlice(cmd, config.CURVEFS_COPYSETID)
+	if len(poolidsStr) != len(copysetidsStr) {
+		return nil, fmt.Errorf("%s and %s is must be in one-to-one correspondence", config.CURVEFS_POOLID, config.CURVEFS_COPYSETID)
 	}
-
-	var poolids []uint32
-	poolidsStr := config.GetFlagStringSlice(cCmd.Cmd, config.CURVEFS_POOLID)
-	for _, poolidStr := range poolidsStr {
-		poolid, _ := strconv.ParseUint(poolidStr, 10, 32)
-		poolids = append(poolids, uint32(poolid))
-	}
-
-	var copysetids []uint32
-	copysetidsStr := config.GetFlagStringSlice(cCmd.Cmd, config.CURVEFS_COPYSETID)
-	for _, copysetidStr := range copysetidsStr {
-		copysetid, _ := strconv.ParseUint(copysetidStr, 10, 32)
-		copysetids = append(copysetids, uint32(copysetid))
+	var keys []client.CopysetKey
+	for i := range poolidsStr {
+		poolid, _ := strconv.ParseUint(poolidsStr[i], 10, 32)
+		copysetid, _ := strconv.ParseUint(copysetidsStr[i], 10, 32)
+		keys = append(keys, client.CopysetKey{
+			PoolId:    uint32(poolid),
+			CopysetId: uint32(copysetid),
+		})
 	}
+	return keys, nil
+}
 
-	if len(poolids) != len(copysetids) {
-		return fmt.Errorf("%s and %s is must be in one-to-one correspondence", config.CURVEFS_POOLID, config.CURVEFS_COPYSETID)
+func (cCmd *CopysetCommand) Init(cmd *cobra.Command, args []string) error {
+	var errCmd *cmderror.CmdError
+	cCmd.client, errCmd = fsclient.New(cCmd.Cmd)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		return errCmd.ToError()
 	}
-	table, err := gotable.Create("copyset key", "leader peer", "epoch")
+	keys, err := copysetKeys(cCmd.Cmd)
 	if err != nil {
 		return err
 	}
+	cCmd.options = client.CopysetOptions{
+		Copysets:   keys,
+		PeerStatus: config.GetFlagBool(cCmd.Cmd, config.CURVEFS_DETAIL),
+	}
 
-	cCmd.Table = table
-
-	cCmd.Rows = make([]map[string]string, 0)
-	timeout := viper.GetDuration(config.VIPER_GLOBALE_RPCTIMEOUT)
-	retrytimes := viper.GetInt32(config.VIPER_GLOBALE_RPCRETRYTIMES)
-	getRequest := &topology.GetCopysetsInfoRequest{}
-	cCmd.key2Copyset = make(map[uint64]*cobrautil.CopysetInfoStatus)
-	for i := range poolids {
-		poolid := poolids[i]
-		copysetid := copysetids[i]
-		copysetKey := topology.CopysetKey{
-			PoolId:    &poolid,
-			CopysetId: &copysetid,
-		}
-		key := cobrautil.GetCopysetKey(uint64(poolid), uint64(copysetid))
-		getRequest.CopysetKeys = append(getRequest.CopysetKeys, &copysetKey)
-
-		row := make(map[string]string)
-		row["copyset key"] = fmt.Sprintf("%d", key)
-		row["leader peer"] = "DNE"
-		row["epoch"] = "DNE"
-
-		cCmd.Rows = append(cCmd.Rows, row)
-		cCmd.key2Copyset[key] = nil
+	header := []string{"copyset key", "leader peer", "epoch"}
+	if cCmd.options.PeerStatus {
+		header = append(header, "peer addr", "status", "state", "term", "readonly")
 	}
-	cCmd.Rpc = &QueryCopysetRpc{
-		Request: getRequest,
+	table, err := gotable.Create(header...)
+	if err != nil {
+		return err
 	}
-	cCmd.Rpc.Info = basecmd.NewRpc(addrs, timeout, retrytimes, "GetCopysetsInfo")
-
+	cCmd.Table = table
 	return nil
 }
 
@@ -161,203 +118,112 @@ func (cCmd *CopysetCommand) Print(cmd *cobra.Command, args []string) error {
 }
 
 func (cCmd *CopysetCommand) RunCommand(cmd *cobra.Command, args []string) error {
-	result, err := basecmd.GetRpcResponse(cCmd.Rpc.Info, cCmd.Rpc)
-	var errs []*cmderror.CmdError
-	if err.TypeCode() != cmderror.CODE_SUCCESS {
-		errs = append(errs, err)
-	} else {
-		response := result.(*topology.GetCopysetsInfoResponse)
-		copysetValues := response.GetCopysetValues()
-		for _, copysetValue := range copysetValues {
-			var copysetKey uint64
-			var copysetInfo *heartbeat.CopySetInfo
-			if copysetValue.GetStatusCode() != topology.TopoStatusCode_TOPO_COPYSET_NOT_FOUND {
-				copysetInfo = copysetValue.GetCopysetInfo()
-				poolid := copysetInfo.GetPoolId()
-				copysetid := copysetInfo.GetCopysetId()
-				copysetKey = cobrautil.GetCopysetKey(uint64(poolid), uint64(copysetid))
-				if cCmd.key2Copyset[copysetKey] == nil {
-					cCmd.key2Copyset[copysetKey] = &cobrautil.CopysetInfoStatus{
-						Info: copysetInfo,
-					}
-				}
-			}
-			if copysetValue.GetStatusCode() == topology.TopoStatusCode_TOPO_OK {
-				row := slices.IndexFunc(cCmd.Rows, func(row map[string]string) bool { return row["copyset key"] == fmt.Sprintf("%d", copysetKey) })
-				cCmd.Rows[row]["leader peer"] = copysetInfo.GetLeaderPeer().String()
-				cCmd.Rows[row]["epoch"] = fmt.Sprintf("%d", copysetInfo.GetEpoch())
-			} else {
-				err := cmderror.ErrGetCopysetsInfo(int(copysetValue.GetStatusCode()))
-				errs = append(errs, err)
-			}
-		}
-
-		detail := config.GetFlagBool(cCmd.Cmd, config.CURVEFS_DETAIL)
-		if detail {
-			statusErr := cCmd.UpdateCopysetsStatus(copysetValues)
-			errs = append(errs, statusErr...)
-		}
+	key2Copyset, err := cCmd.client.GetCopysetStatus(cCmd.Cmd.Context(), cCmd.options)
+	if key2Copyset == nil {
+		return fsclient.ToCmdError(err).ToError()
+	}
+	cCmd.key2Copyset = key2Copyset
+	for _, key := range cCmd.options.Copysets {
+		cCmd.Table.AddRows(copysetRows(key.Key(), key2Copyset[key.Key()], cCmd.options.PeerStatus))
 	}
-
-	cCmd.Table.AddRows(cCmd.Rows)
 	cCmd.Result = cCmd.key2Copyset
-	cCmd.Error = cmderror.MostImportantCmdError(errs)
+	cCmd.Error = fsclient.ToCmdError(err)
 	return nil
 }
 
-func (cCmd *CopysetCommand) UpdateCopysetsStatus(values []*topology.CopysetValue) []*cmderror.CmdError {
-	cCmd.Table.AddColumn("peer addr")
-	cCmd.Table.AddColumn("status")
-	cCmd.Table.AddColumn("state")
-	cCmd.Table.AddColumn("term")
-	cCmd.Table.AddColumn("readonly")
-	for _, row := range cCmd.Rows {
-		row["peer addr"] = "DNE"
-		row["status"] = "DNE"
-		row["state"] = "DNE"
-		row["term"] = "DNE"
-		row["readonly"] = "DNE"
+// one row for the copyset, or one row for every peer of it if detail
+func copysetRows(key uint64, status *client.CopysetStatus, detail bool) []map[string]string {
+	row := make(map[string]string)
+	row["copyset key"] = fmt.Sprintf("%d", key)
+	row["leader peer"] = "DNE"
+	row["epoch"] = "DNE"
+	if status != nil && status.Info != nil {
+		row["leader peer"] = cobrautil.PeerString(status.Info.GetLeaderPeer())
+		row["epoch"] = fmt.Sprintf("%d", status.Info.GetEpoch())
+	}
+	if !detail {
+		return []map[string]string{row}
 	}
 
-	addr2Request := make(map[string]*copyset.CopysetsStatusRequest)
-	var ret []*cmderror.CmdError
-	for _, value := range values {
-		if value.GetStatusCode() == topology.TopoStatusCode_TOPO_OK {
-			copysetInfo := value.GetCopysetInfo()
-			peers := copysetInfo.GetPeers()
-			for _, peer := range peers {
-				addr, err := cobrautil.SplitPeerToAddr(peer.GetAddress())
-				if err.TypeCode() != cmderror.CODE_SUCCESS {
-					ret = append(ret, err)
-				} else if addr2Request[addr] == nil {
-					addr2Request[addr] = &copyset.CopysetsStatusRequest{}
-				}
-				poolid := copysetInfo.GetPoolId()
-				copysetid := copysetInfo.GetCopysetId()
-				copsetStatRequest := &copyset.CopysetStatusRequest{
-					PoolId:    &poolid,
-					CopysetId: &copysetid,
-				}
-				addr2Request[addr].Copysets = append(addr2Request[addr].Copysets, copsetStatRequest)
-
-				copysetKey := cobrautil.GetCopysetKey(uint64(poolid), uint64(copysetid))
-				rowIndex := slices.IndexFunc(cCmd.Rows, func(row map[string]string) bool { return row["copyset key"] == fmt.Sprintf("%d", copysetKey) })
-				if rowIndex == -1 {
-					errIndex := cmderror.ErrCopysetKey()
-					errIndex.Format(copysetKey, addr)
-					ret = append(ret, errIndex)
-					continue
-				}
-				row := cCmd.Rows[rowIndex]
-				if row["peer addr"] != "DNE" {
-					rowNew := make(map[string]string)
-					rowNew["copyset key"] = row["copyset key"]
-					rowNew["leader peer"] = row["leader peer"]
-					rowNew["epoch"] = row["epoch"]
-					rowNew["peer addr"] = addr
-					rowNew["status"] = "DNE"
-					rowNew["state"] = "DNE"
-					rowNew["term"] = "DNE"
-					rowNew["readonly"] = "DNE"
-					cCmd.Rows = append(cCmd.Rows, rowNew)
-				} else {
-					row["peer addr"] = addr
-				}
+	var addrs []string
+	if status != nil {
+		for _, peer := range status.Info.GetPeers() {
+			addr, err := cobrautil.SplitPeerToAddr(peer.GetAddress())
+			if err.TypeCode() == cmderror.CODE_SUCCESS {
+				addrs = append(addrs, addr)
 			}
 		}
 	}
-
-	// update row & copysetInfoStatus
-	timeout := viper.GetDuration(config.VIPER_GLOBALE_RPCTIMEOUT)
-	retrytimes := viper.GetInt32(config.VIPER_GLOBALE_RPCRETRYTIMES)
-	results := GetCopysetsStatus(&addr2Request, timeout, retrytimes)
-	for _, result := range results {
-		ret = append(ret, result.Error)
-		copysets := result.Request.GetCopysets()
-		copysetsStatus := result.Status.GetStatus()
-		for i := range copysets {
-			ret = append(ret, result.Error)
-			copysetInfo := copysets[i]
-			poolId := copysetInfo.GetPoolId()
-			copysetId := copysetInfo.GetCopysetId()
-			copysetKey := cobrautil.GetCopysetKey(uint64(poolId), uint64(copysetId))
-
-			copysetInfoStatus := cCmd.key2Copyset[copysetKey]
-			var status *copyset.CopysetStatusResponse
-			if copysetsStatus != nil {
-				status = copysetsStatus[i]
-			}
-			if copysetInfoStatus.Peer2Status == nil {
-				copysetInfoStatus.Peer2Status = make(map[string]*copyset.CopysetStatusResponse)
-			}
-			copysetInfoStatus.Peer2Status[result.Addr] = status
-
-			rowIndex := slices.IndexFunc(cCmd.Rows, func(row map[string]string) bool {
-				peerAddr := row["peer addr"]
-				addr := result.Addr
-				return (row["copyset key"] == fmt.Sprintf("%d", copysetKey)) && (peerAddr == addr)
-			})
-
-			if rowIndex == -1 {
-				errIndex := cmderror.ErrCopysetKey()
-				errIndex.Format(copysetKey, result.Addr)
-				ret = append(ret, errIndex)
-				continue
-			}
-
-			row := cCmd.Rows[rowIndex]
-			row["status"] = status.GetStatus().String()
-			if status.GetStatus() != copyset.COPYSET_OP_STATUS_COPYSET_OP_STATUS_SUCCESS {
-				row["state"] = "DNE"
-				row["term"] = "DNE"
-				row["readonly"] = "DNE"
-				continue
+	if len(addrs) == 0 {
+		addrs = append(addrs, "DNE")
+	}
+	var rows []map[string]string
+	for _, addr := range addrs {
+		peerRow := make(map[string]string)
+		for k, v := range row {
+			peerRow[k] = v
+		}
+		peerRow["peer addr"] = addr
+		peerRow["status"] = "DNE"
+		peerRow["state"] = "DNE"
+		peerRow["term"] = "DNE"
+		peerRow["readonly"] = "DNE"
+		if peerStatus := peer2Status(status, addr); peerStatus != nil {
+			peerRow["status"] = peerStatus.GetStatus().String()
+			if peerStatus.GetStatus() == copyset.COPYSET_OP_STATUS_COPYSET_OP_STATUS_SUCCESS {
+				copysetStatus := peerStatus.GetCopysetStatus()
+				peerRow["state"] = fmt.Sprintf("%d", copysetStatus.GetState())
+				peerRow["term"] = fmt.Sprintf("%d", copysetStatus.GetTerm())
+				peerRow["readonly"] = fmt.Sprintf("%t", copysetStatus.GetReadonly())
 			}
-			copysetStatus := status.GetCopysetStatus()
-			row["state"] = fmt.Sprintf("%d", copysetStatus.GetState())
-			row["term"] = fmt.Sprintf("%d", copysetStatus.GetTerm())
-			row["readonly"] = fmt.Sprintf("%t", copysetStatus.GetReadonly())
 		}
+		rows = append(rows, peerRow)
 	}
-	return ret
+	return rows
+}
+
+func peer2Status(status *client.CopysetStatus, addr string) *copyset.CopysetStatusResponse {
+	if status == nil {
+		return nil
+	}
+	return status.Peer2Status[addr]
 }
 
 func (cCmd *CopysetCommand) ResultPlainOutput() error {
 	return output.FinalCmdOutputPlain(&cCmd.FinalCurveCmd, cCmd)
 }
 
-// copsetIds,poolId just like: 1,2,3
-func QueryCopysetInfoStatus(caller *cobra.Command) (*map[uint64]*cobrautil.CopysetInfoStatus, *cmderror.CmdError) {
-	queryCopyset := NewQueryCopysetCommand()
-	queryCopyset.Cmd.SetArgs([]string{
-		fmt.Sprintf("--%s", config.CURVEFS_DETAIL),
-		fmt.Sprintf("--%s", config.FORMAT), config.FORMAT_NOOUT,
-	})
-	cobrautil.AlignFlags(caller, queryCopyset.Cmd, []string{config.RPCRETRYTIMES, config.RPCTIMEOUT, config.CURVEFS_MDSADDR, config.CURVEFS_COPYSETID, config.CURVEFS_POOLID})
-	queryCopyset.Cmd.SilenceUsage = true
-	err := queryCopyset.Cmd.Execute()
+// queryCopyset gets the copysets of the --poolid and --copysetid of caller,
+// the copysets got are returned with the error if some of them fail.
+func queryCopyset(caller *cobra.Command, peerStatus bool) (*map[uint64]*client.CopysetStatus, *cmderror.CmdError) {
+	fsClient, errCmd := fsclient.New(caller)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		return nil, errCmd
+	}
+	keys, err := copysetKeys(caller)
 	if err != nil {
 		retErr := cmderror.ErrQueryCopyset()
 		retErr.Format(err.Error())
 		return nil, retErr
 	}
-
-	return &queryCopyset.key2Copyset, cmderror.ErrSuccess()
-}
-
-func QueryCopysetInfo(caller *cobra.Command) (*map[uint64]*cobrautil.CopysetInfoStatus, *cmderror.CmdError) {
-	queryCopyset := NewQueryCopysetCommand()
-	queryCopyset.Cmd.SetArgs([]string{
-		fmt.Sprintf("--%s", config.FORMAT), config.FORMAT_NOOUT,
+	key2Copyset, err := fsClient.GetCopysetStatus(caller.Context(), client.CopysetOptions{
+		Copysets:   keys,
+		PeerStatus: peerStatus,
 	})
-	cobrautil.AlignFlags(caller, queryCopyset.Cmd, []string{config.RPCRETRYTIMES, config.RPCTIMEOUT, config.CURVEFS_MDSADDR, config.CURVEFS_COPYSETID, config.CURVEFS_POOLID})
-	queryCopyset.Cmd.SilenceUsage = true
-	err := queryCopyset.Cmd.Execute()
-	if err != nil {
+	if key2Copyset == nil {
 		retErr := cmderror.ErrQueryCopyset()
 		retErr.Format(err.Error())
-		return nil, retErr
+		// e.g. the mds is unreachable
+		return nil, retErr.WithExitCode(fsclient.ToCmdError(err).ExitCode())
 	}
+	return &key2Copyset, fsclient.ToCmdError(err)
+}
+
+// copsetIds,poolId just like: 1,2,3
+func QueryCopysetInfoStatus(caller *cobra.Command) (*map[uint64]*client.CopysetStatus, *cmderror.CmdError) {
+	return queryCopyset(caller, true)
+}
 
-	return &queryCopyset.key2Copyset, cmderror.ErrSuccess()
+func QueryCopysetInfo(caller *cobra.Command) (*map[uint64]*client.CopysetStatus, *cmderror.CmdError) {
+	return queryCopyset(caller, false)
 }
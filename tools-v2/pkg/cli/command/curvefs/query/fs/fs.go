@@ -85,7 +85,7 @@ func (fCmd *FsCommand) AddFlags() {
 func (fCmd *FsCommand) Init(cmd *cobra.Command, args []string) error {
 	addrs, addrErr := config.GetFsMdsAddrSlice(fCmd.Cmd)
 	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
+		return addrErr.ToError()
 	}
 
 	var fsIds []string
@@ -109,6 +109,7 @@ func (fCmd *FsCommand) Init(cmd *cobra.Command, args []string) error {
 	fCmd.Table = table
 
 	fCmd.Rows = make([]map[string]string, 0)
+	fCmd.Rpc = make([]*QueryFsRpc, 0)
 	timeout := viper.GetDuration(config.VIPER_GLOBALE_RPCTIMEOUT)
 	retrytimes := viper.GetInt32(config.VIPER_GLOBALE_RPCRETRYTIMES)
 	for i := range fsNames {
@@ -118,7 +119,7 @@ func (fCmd *FsCommand) Init(cmd *cobra.Command, args []string) error {
 		rpc := &QueryFsRpc{
 			Request: request,
 		}
-		rpc.Info = basecmd.NewRpc(addrs, timeout, retrytimes, "GetFsInfo")
+		rpc.Info = basecmd.NewMdsRpc(fCmd.Cmd, addrs, timeout, retrytimes, "GetFsInfo")
 		fCmd.Rpc = append(fCmd.Rpc, rpc)
 		row := make(map[string]string)
 		row["name"] = fsNames[i]
@@ -145,7 +146,7 @@ func (fCmd *FsCommand) Init(cmd *cobra.Command, args []string) error {
 		rpc := &QueryFsRpc{
 			Request: request,
 		}
-		rpc.Info = basecmd.NewRpc(addrs, timeout, retrytimes, "GetFsInfo")
+		rpc.Info = basecmd.NewMdsRpc(fCmd.Cmd, addrs, timeout, retrytimes, "GetFsInfo")
 		fCmd.Rpc = append(fCmd.Rpc, rpc)
 		row := make(map[string]string)
 		row["id"] = fsIds[i]
@@ -175,7 +176,7 @@ func (fCmd *FsCommand) RunCommand(cmd *cobra.Command, args []string) error {
 		funcs = append(funcs, rpc)
 	}
 
-	results, err := basecmd.GetRpcListResponse(infos, funcs)
+	results, err := basecmd.GetRpcListResponse(fCmd.Cmd.Context(), infos, funcs)
 	var errs []*cmderror.CmdError
 	if err.TypeCode() != cmderror.CODE_SUCCESS {
 		errs = append(errs, err)
@@ -23,23 +23,17 @@
 package inode
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/liushuochen/gotable"
 	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
-	cobrautil "github.com/opencurve/curve/tools-v2/internal/utils"
 	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
-	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/list/partition"
-	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/query/copyset"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/fsclient"
+	"github.com/opencurve/curve/tools-v2/pkg/client"
 	"github.com/opencurve/curve/tools-v2/pkg/config"
 	"github.com/opencurve/curve/tools-v2/pkg/output"
-	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/common"
 	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/metaserver"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-	"golang.org/x/exp/slices"
-	"google.golang.org/grpc"
 )
 
 const (
@@ -55,25 +49,10 @@ const (
 	ROW_S3CHUNKINFO_SIZE    = "s3 size"
 )
 
-type QueryInodeRpc struct {
-	Info             *basecmd.Rpc
-	Request          *metaserver.GetInodeRequest
-	metaserverClient metaserver.MetaServerServiceClient
-}
-
-var _ basecmd.RpcFunc = (*QueryInodeRpc)(nil) // check interface
-
-func (qiRpc *QueryInodeRpc) NewRpcClient(cc grpc.ClientConnInterface) {
-	qiRpc.metaserverClient = metaserver.NewMetaServerServiceClient(cc)
-}
-
-func (qiRpc *QueryInodeRpc) Stub_Func(ctx context.Context) (interface{}, error) {
-	return qiRpc.metaserverClient.GetInode(ctx, qiRpc.Request)
-}
-
 type InodeCommand struct {
 	basecmd.FinalCurveCmd
-	QIRpc *QueryInodeRpc
+	client  *client.Client
+	options client.InodeOptions
 }
 
 var _ basecmd.FinalCurveCmdFunc = (*InodeCommand)(nil) // check interface
@@ -104,63 +83,15 @@ func (iCmd *InodeCommand) Init(cmd *cobra.Command, args []string) error {
 	}
 	iCmd.Table = table
 
-	fsId := config.GetFlagUint32(iCmd.Cmd, config.CURVEFS_FSID)
-	inodeId := config.GetFlagUint64(iCmd.Cmd, config.CURVEFS_INODEID)
-
-	fsId2PartitionList := partition.GetFsPartition(iCmd.Cmd)
-	partitionInfoList := (*fsId2PartitionList)[fsId]
-	if partitionInfoList == nil {
-		return fmt.Errorf("inode[%d] is not found in fs[%d]", inodeId, fsId)
-	}
-	index := slices.IndexFunc(partitionInfoList,
-		func(p *common.PartitionInfo) bool {
-			return p.GetFsId() == fsId && p.GetStart() <= inodeId && p.GetEnd() >= inodeId
-		})
-	if index < 0 {
-		return fmt.Errorf("inode[%d] is not on any partition of fs[%d]", inodeId, fsId)
+	var errCmd *cmderror.CmdError
+	iCmd.client, errCmd = fsclient.New(iCmd.Cmd)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		return errCmd.ToError()
 	}
-	partitionInfo := partitionInfoList[index]
-	poolId := partitionInfo.GetPoolId()
-	copyetId := partitionInfo.GetCopysetId()
-	partitionId := partitionInfo.GetPartitionId()
-	supportStream := false
-	inodeRequest := &metaserver.GetInodeRequest{
-		PoolId:           &poolId,
-		CopysetId:        &copyetId,
-		PartitionId:      &partitionId,
-		FsId:             &fsId,
-		InodeId:          &inodeId,
-		SupportStreaming: &supportStream,
+	iCmd.options = client.InodeOptions{
+		FsId:    config.GetFlagUint32(iCmd.Cmd, config.CURVEFS_FSID),
+		InodeId: config.GetFlagUint64(iCmd.Cmd, config.CURVEFS_INODEID),
 	}
-	iCmd.QIRpc = &QueryInodeRpc{
-		Request: inodeRequest,
-	}
-	// get addrs
-	config.AddCopysetidSliceRequiredFlag(iCmd.Cmd)
-	config.AddPoolidSliceRequiredFlag(iCmd.Cmd)
-	iCmd.Cmd.ParseFlags([]string{
-		fmt.Sprintf("--%s", config.CURVEFS_COPYSETID), fmt.Sprintf("%d", copyetId),
-		fmt.Sprintf("--%s", config.CURVEFS_POOLID), fmt.Sprintf("%d", poolId),
-	})
-	key2Copyset, errQuery := copyset.QueryCopysetInfo(iCmd.Cmd)
-	if errQuery.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf("query copyset info failed: %s", errQuery.Message)
-	}
-	if len(*key2Copyset) == 0 {
-		return fmt.Errorf("no copysetinfo found")
-	}
-	key := cobrautil.GetCopysetKey(uint64(poolId), uint64(copyetId))
-	leader := (*key2Copyset)[key].Info.GetLeaderPeer()
-	addr, peerErr := cobrautil.PeertoAddr(leader)
-	if peerErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf("pares leader peer[%s] failed: %s", leader, peerErr.Message)
-	}
-	addrs := []string{addr}
-
-	timeout := viper.GetDuration(config.VIPER_GLOBALE_RPCTIMEOUT)
-	retrytimes := viper.GetInt32(config.VIPER_GLOBALE_RPCRETRYTIMES)
-	iCmd.QIRpc.Info = basecmd.NewRpc(addrs, timeout, retrytimes, "GetInode")
-
 	return nil
 }
 
@@ -169,15 +100,10 @@ func (iCmd *InodeCommand) Print(cmd *cobra.Command, args []string) error {
 }
 
 func (iCmd *InodeCommand) RunCommand(cmd *cobra.Command, args []string) error {
-	inodeResult, err := basecmd.GetRpcResponse(iCmd.QIRpc.Info, iCmd.QIRpc)
-	if err.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf("get inode failed: %s", err.Message)
-	}
-	getInodeResponse := inodeResult.(*metaserver.GetInodeResponse)
-	if getInodeResponse.GetStatusCode() != metaserver.MetaStatusCode_OK {
-		return fmt.Errorf("get inode failed: %s", getInodeResponse.GetStatusCode().String())
+	inode, err := iCmd.client.GetInode(iCmd.Cmd.Context(), iCmd.options)
+	if err != nil {
+		return fsclient.ToCmdError(err).ToError()
 	}
-	inode := getInodeResponse.GetInode()
 	if len(inode.S3ChunkInfoMap) == 0 {
 		row := make(map[string]string)
 		row[ROW_FS_ID] = fmt.Sprintf("%d", inode.GetFsId())
@@ -212,7 +138,11 @@ func (iCmd *InodeCommand) RunCommand(cmd *cobra.Command, args []string) error {
 		}
 		iCmd.Table.AddRows(rows)
 	}
-	iCmd.Result = getInodeResponse
+	ok := metaserver.MetaStatusCode_OK
+	iCmd.Result = &metaserver.GetInodeResponse{
+		StatusCode: &ok,
+		Inode:      inode,
+	}
 	iCmd.Error = cmderror.ErrSuccess()
 	return nil
 }
@@ -86,7 +86,7 @@ func (mCmd *MetaserverCommand) AddFlags() {
 func (mCmd *MetaserverCommand) Init(cmd *cobra.Command, args []string) error {
 	addrs, addrErr := config.GetFsMdsAddrSlice(mCmd.Cmd)
 	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
+		return addrErr.ToError()
 	}
 
 	var metaserverAddrs []string
@@ -110,6 +110,7 @@ func (mCmd *MetaserverCommand) Init(cmd *cobra.Command, args []string) error {
 	mCmd.Table = table
 
 	mCmd.Rows = make([]map[string]string, 0)
+	mCmd.Rpc = make([]*QueryMetaserverRpc, 0)
 	timeout := viper.GetDuration(config.VIPER_GLOBALE_RPCTIMEOUT)
 	retrytimes := viper.GetInt32(config.VIPER_GLOBALE_RPCRETRYTIMES)
 	for i := range metaserverAddrs {
@@ -129,7 +130,7 @@ func (mCmd *MetaserverCommand) Init(cmd *cobra.Command, args []string) error {
 		rpc := &QueryMetaserverRpc{
 			Request: request,
 		}
-		rpc.Info = basecmd.NewRpc(addrs, timeout, retrytimes, "GetMetaServerInfo")
+		rpc.Info = basecmd.NewMdsRpc(mCmd.Cmd, addrs, timeout, retrytimes, "GetMetaServerInfo")
 		mCmd.Rpc = append(mCmd.Rpc, rpc)
 		row := make(map[string]string)
 		row["id"] = ""
@@ -149,7 +150,7 @@ func (mCmd *MetaserverCommand) Init(cmd *cobra.Command, args []string) error {
 		rpc := &QueryMetaserverRpc{
 			Request: request,
 		}
-		rpc.Info = basecmd.NewRpc(addrs, timeout, retrytimes, "GetMetaServerInfo")
+		rpc.Info = basecmd.NewMdsRpc(mCmd.Cmd, addrs, timeout, retrytimes, "GetMetaServerInfo")
 		mCmd.Rpc = append(mCmd.Rpc, rpc)
 		row := make(map[string]string)
 		row["id"] = metaserverIds[i]
@@ -172,7 +173,7 @@ func (mCmd *MetaserverCommand) RunCommand(cmd *cobra.Command, args []string) err
 		funcs = append(funcs, rpc)
 	}
 
-	results, err := basecmd.GetRpcListResponse(infos, funcs)
+	results, err := basecmd.GetRpcListResponse(mCmd.Cmd.Context(), infos, funcs)
 	var errs []*cmderror.CmdError
 	if err.TypeCode() != cmderror.CODE_SUCCESS {
 		errs = append(errs, err)
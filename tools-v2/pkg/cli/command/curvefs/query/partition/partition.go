@@ -25,6 +25,7 @@ package partition
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/liushuochen/gotable"
@@ -82,7 +83,7 @@ func (pCmd *PartitionCommand) AddFlags() {
 func (pCmd *PartitionCommand) Init(cmd *cobra.Command, args []string) error {
 	addrs, addrErr := config.GetFsMdsAddrSlice(pCmd.Cmd)
 	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
+		return addrErr.ToError()
 	}
 
 	table, err := gotable.Create("id", "pool id", "copyset id", "peer id", "peer address")
@@ -110,7 +111,7 @@ func (pCmd *PartitionCommand) Init(cmd *cobra.Command, args []string) error {
 	}
 	timeout := viper.GetDuration(config.VIPER_GLOBALE_RPCTIMEOUT)
 	retrytimes := viper.GetInt32(config.VIPER_GLOBALE_RPCRETRYTIMES)
-	pCmd.Rpc.Info = basecmd.NewRpc(addrs, timeout, retrytimes, "GetCopysetOfPartition")
+	pCmd.Rpc.Info = basecmd.NewMdsRpc(pCmd.Cmd, addrs, timeout, retrytimes, "GetCopysetOfPartition")
 
 	return nil
 }
@@ -120,7 +121,7 @@ func (pCmd *PartitionCommand) Print(cmd *cobra.Command, args []string) error {
 }
 
 func (pCmd *PartitionCommand) RunCommand(cmd *cobra.Command, args []string) error {
-	result, err := basecmd.GetRpcResponse(pCmd.Rpc.Info, pCmd.Rpc)
+	result, err := basecmd.GetRpcResponse(pCmd.Cmd.Context(), pCmd.Rpc.Info, pCmd.Rpc)
 	var errs []*cmderror.CmdError
 	if err.TypeCode() != cmderror.CODE_SUCCESS {
 		errs = append(errs, err)
@@ -138,7 +139,15 @@ func (pCmd *PartitionCommand) RunCommand(cmd *cobra.Command, args []string) erro
 
 	var rows []map[string]string
 	copysetMap := response.GetCopysetMap()
-	for k, v := range copysetMap {
+	partitionIds := make([]uint32, 0, len(copysetMap))
+	for k := range copysetMap {
+		partitionIds = append(partitionIds, k)
+	}
+	sort.Slice(partitionIds, func(i, j int) bool {
+		return partitionIds[i] < partitionIds[j]
+	})
+	for _, k := range partitionIds {
+		v := copysetMap[k]
 		for _, peer := range v.GetPeers() {
 			row := make(map[string]string)
 			row["id"] = strconv.Itoa(int(k))
@@ -103,8 +103,11 @@ func (cCmd *ClusterCommand) RunCommand(cmd *cobra.Command, args []string) error
 
 func (cCmd *ClusterCommand) ResultPlainOutput() error {
 	for _, server := range cCmd.serverList {
-		fmt.Printf("%s:\n", server)
-		fmt.Println(cCmd.type2Table[server])
+		fmt.Fprintf(cCmd.Cmd.OutOrStdout(), "%s:\n", server)
+		// the table is nil if the status can not be got, the error is printed later
+		if table := cCmd.type2Table[server]; table != nil {
+			fmt.Fprint(cCmd.Cmd.OutOrStdout(), output.TableString(table))
+		}
 	}
 	return nil
 }
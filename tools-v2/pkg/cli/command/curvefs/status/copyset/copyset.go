@@ -23,22 +23,23 @@
 package copyset
 
 import (
-	"fmt"
-	"strings"
+	"context"
 
 	"github.com/liushuochen/gotable/table"
 	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
-	cobrautil "github.com/opencurve/curve/tools-v2/internal/utils"
 	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
 	checkCopyset "github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/check/copyset"
-	listCopyset "github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/list/copyset"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/fsclient"
+	"github.com/opencurve/curve/tools-v2/pkg/client"
 	"github.com/opencurve/curve/tools-v2/pkg/config"
 	"github.com/opencurve/curve/tools-v2/pkg/output"
+	"github.com/opencurve/curve/tools-v2/pkg/tracing"
 	"github.com/spf13/cobra"
 )
 
 type CopysetCommand struct {
 	basecmd.FinalCurveCmd
+	client *client.Client
 }
 
 var _ basecmd.FinalCurveCmdFunc = (*CopysetCommand)(nil) // check interface
@@ -61,23 +62,11 @@ func (cCmd *CopysetCommand) AddFlags() {
 }
 
 func (cCmd *CopysetCommand) Init(cmd *cobra.Command, args []string) error {
-	response, err := listCopyset.GetCopysetsInfos(cCmd.Cmd)
-	if err.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(err.Message)
+	var errCmd *cmderror.CmdError
+	cCmd.client, errCmd = fsclient.New(cCmd.Cmd)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		return errCmd.ToError()
 	}
-	var copysetIdVec []string
-	var poolIdVec []string
-	for _, v := range response.GetCopysetValues() {
-		info := v.GetCopysetInfo()
-		copysetIdVec = append(copysetIdVec, fmt.Sprintf("%d", info.GetCopysetId()))
-		poolIdVec = append(poolIdVec, fmt.Sprintf("%d", info.GetPoolId()))
-	}
-	copysetIds := strings.Join(copysetIdVec, ",")
-	poolIds := strings.Join(poolIdVec, ",")
-	result, table, errCheck := checkCopyset.GetCopysetsStatus(cCmd.Cmd, copysetIds, poolIds)
-	cCmd.Result = result
-	cCmd.Table = table
-	cCmd.Error = errCheck
 	return nil
 }
 
@@ -86,6 +75,13 @@ func (cCmd *CopysetCommand) Print(cmd *cobra.Command, args []string) error {
 }
 
 func (cCmd *CopysetCommand) RunCommand(cmd *cobra.Command, args []string) error {
+	result, table, errCmd := getStatus(cCmd.Cmd.Context(), cCmd.client)
+	if table == nil {
+		return errCmd.ToError()
+	}
+	cCmd.Result = result
+	cCmd.Table = table
+	cCmd.Error = errCmd
 	return nil
 }
 
@@ -93,26 +89,38 @@ func (cCmd *CopysetCommand) ResultPlainOutput() error {
 	return output.FinalCmdOutputPlain(&cCmd.FinalCurveCmd, cCmd)
 }
 
-func NewStatusCopysetCommand() *CopysetCommand {
-	copysetCmd := &CopysetCommand{
-		FinalCurveCmd: basecmd.FinalCurveCmd{
-			Use:   "etcd",
-			Short: "get the etcd status of curvefs",
-		},
+// getStatus checks all the copysets in mds, the table is nil if they can not be got
+func getStatus(ctx context.Context, fsClient *client.Client) (interface{}, *table.Table, *cmderror.CmdError) {
+	infos, err := fsClient.ListCopyset(ctx)
+	if err != nil {
+		retErr := cmderror.ErrListCopyset()
+		retErr.Format(err.Error())
+		return nil, nil, retErr.WithExitCode(fsclient.ToCmdError(err).ExitCode())
+	}
+	var keys []client.CopysetKey
+	for _, info := range infos {
+		keys = append(keys, client.CopysetKey{
+			PoolId:    info.GetPoolId(),
+			CopysetId: info.GetCopysetId(),
+		})
 	}
-	basecmd.NewFinalCurveCli(&copysetCmd.FinalCurveCmd, copysetCmd)
-	return copysetCmd
+	return checkCopyset.GetCopysetsStatus(ctx, fsClient, keys)
 }
 
+// GetCopysetStatus returns the health of all the copysets, the mds addresses are got
+// from the flags of caller or the config
 func GetCopysetStatus(caller *cobra.Command) (*interface{}, *table.Table, *cmderror.CmdError) {
-	copysetCmd := NewStatusCopysetCommand()
-	copysetCmd.Cmd.SetArgs([]string{
-		fmt.Sprintf("--%s", config.FORMAT), config.FORMAT_NOOUT,
-	})
-	cobrautil.AlignFlags(caller, copysetCmd.Cmd, []string{
-		config.RPCRETRYTIMES, config.RPCTIMEOUT, config.CURVEFS_MDSADDR,
-	})
-	copysetCmd.Cmd.SilenceUsage = true
-	copysetCmd.Cmd.Execute()
-	return &copysetCmd.Result, copysetCmd.Table, copysetCmd.Error
+	ctx, span := tracing.Start(caller.Context(), "copyset", tracing.SPAN_KIND_INTERNAL)
+	defer span.End()
+	var result interface{}
+	fsClient, errCmd := fsclient.New(caller)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		span.SetError(errCmd.Message)
+		return &result, nil, errCmd
+	}
+	result, table, errCmd := getStatus(ctx, fsClient)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		span.SetError(errCmd.Message)
+	}
+	return &result, table, errCmd
 }
@@ -23,40 +23,26 @@
 package etcd
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 
 	"github.com/liushuochen/gotable"
 	"github.com/liushuochen/gotable/table"
 	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
 	cobrautil "github.com/opencurve/curve/tools-v2/internal/utils"
 	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/fsclient"
+	"github.com/opencurve/curve/tools-v2/pkg/client"
 	config "github.com/opencurve/curve/tools-v2/pkg/config"
 	"github.com/opencurve/curve/tools-v2/pkg/output"
+	"github.com/opencurve/curve/tools-v2/pkg/tracing"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 type EtcdCommand struct {
 	basecmd.FinalCurveCmd
-	metrics []basecmd.Metric
-	rows    []map[string]string
+	client *client.Client
 }
 
-const (
-	STATUS_SUBURI      = "/v2/stats/self"
-	STATUS_METRIC_KEY  = "state"
-	VERSION_SUBURI     = "/version"
-	VARSION_METRIC_KEY = "etcdserver"
-)
-
-var (
-	EtcdStatusMap = map[string]string{
-		"StateLeader":   "leader",
-		"StateFollower": "follower",
-	}
-)
-
 var _ basecmd.FinalCurveCmdFunc = (*EtcdCommand)(nil) // check interface
 
 func NewEtcdCommand() *cobra.Command {
@@ -76,34 +62,11 @@ func (eCmd *EtcdCommand) AddFlags() {
 }
 
 func (eCmd *EtcdCommand) Init(cmd *cobra.Command, args []string) error {
-	table, err := gotable.Create("addr", "version", "status")
-	if err != nil {
-		cobra.CheckErr(err)
-	}
-	eCmd.Table = table
-
-	// set main addr
-	etcdAddrs, addrErr := config.GetFsEtcdAddrSlice(eCmd.Cmd)
-	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
+	var errCmd *cmderror.CmdError
+	eCmd.client, errCmd = newClient(eCmd.Cmd)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		return errCmd.ToError()
 	}
-	for _, addr := range etcdAddrs {
-		// set metric
-		timeout := viper.GetDuration(config.VIPER_GLOBALE_HTTPTIMEOUT)
-		addrs := []string{addr}
-		statusMetric := basecmd.NewMetric(addrs, STATUS_SUBURI, timeout)
-		eCmd.metrics = append(eCmd.metrics, *statusMetric)
-		versionMetric := basecmd.NewMetric(addrs, VERSION_SUBURI, timeout)
-		eCmd.metrics = append(eCmd.metrics, *versionMetric)
-
-		// set rows
-		row := make(map[string]string)
-		row["addr"] = addr
-		row["status"] = "offline"
-		row["version"] = "unknown"
-		eCmd.rows = append(eCmd.rows, row)
-	}
-
 	return nil
 }
 
@@ -112,70 +75,13 @@ func (eCmd *EtcdCommand) Print(cmd *cobra.Command, args []string) error {
 }
 
 func (eCmd *EtcdCommand) RunCommand(cmd *cobra.Command, args []string) error {
-	results := make(chan basecmd.MetricResult, config.MaxChannelSize())
-	size := 0
-	var errs []*cmderror.CmdError
-	for _, metric := range eCmd.metrics {
-		size++
-		go func(m basecmd.Metric) {
-			result, err := basecmd.QueryMetric(m)
-			var key string
-			var metricKey string
-			if m.SubUri == STATUS_SUBURI {
-				key = "status"
-				metricKey = STATUS_METRIC_KEY
-			} else {
-				key = "version"
-				metricKey = VARSION_METRIC_KEY
-			}
-			var value string
-			if err.TypeCode() == cmderror.CODE_SUCCESS {
-				value, err = basecmd.GetKeyValueFromJsonMetric(result, metricKey)
-				errs = append(errs, err)
-			}
-			results <- basecmd.MetricResult{
-				Addr:  m.Addrs[0],
-				Key:   key,
-				Value: value,
-				Err:   err,
-			}
-		}(metric)
-	}
-
-	count := 0
-	for res := range results {
-		if res.Err.TypeCode() != cmderror.CODE_SUCCESS {
-			err := cmderror.ErrEtcdOffline()
-			err.Format(res.Addr)
-			errs = append(errs, err)
-		}
-		for _, row := range eCmd.rows {
-			if res.Err.TypeCode() == cmderror.CODE_SUCCESS && row["addr"] == res.Addr {
-				if res.Key == "status" {
-					row[res.Key] = EtcdStatusMap[res.Value]
-				} else {
-					row[res.Key] = res.Value
-				}
-			}
-		}
-		count++
-		if count >= size {
-			break
-		}
-	}
-	eCmd.Error = cmderror.MostImportantCmdError(errs)
-
-	eCmd.Table.AddRows(eCmd.rows)
-	jsonResult, err := eCmd.Table.JSON(0)
-	if err != nil {
-		cobra.CheckErr(err)
-	}
-	var m interface{}
-	err = json.Unmarshal([]byte(jsonResult), &m)
-	if err != nil {
-		cobra.CheckErr(err)
+	result, table, errCmd := getStatus(eCmd.Cmd.Context(), eCmd.client)
+	if table == nil {
+		return errCmd.ToError()
 	}
-	eCmd.Result = m
+	eCmd.Result = result
+	eCmd.Table = table
+	eCmd.Error = errCmd
 	return nil
 }
 
@@ -183,26 +89,52 @@ func (eCmd *EtcdCommand) ResultPlainOutput() error {
 	return output.FinalCmdOutputPlain(&eCmd.FinalCurveCmd, eCmd)
 }
 
-func NewStatusEtcdCommand() *EtcdCommand {
-	etcdCmd := &EtcdCommand{
-		FinalCurveCmd: basecmd.FinalCurveCmd{
-			Use:   "etcd",
-			Short: "get the etcd status of curvefs",
-		},
+// the etcd addresses are checked, the client only gets them if they are valid
+func newClient(cmd *cobra.Command) (*client.Client, *cmderror.CmdError) {
+	_, errCmd := config.GetFsEtcdAddrSlice(cmd)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		return nil, errCmd
 	}
-	basecmd.NewFinalCurveCli(&etcdCmd.FinalCurveCmd, etcdCmd)
-	return etcdCmd
+	return fsclient.New(cmd)
 }
 
+// the table is nil if the status of none of etcd is got
+func getStatus(ctx context.Context, fsClient *client.Client) (interface{}, *table.Table, *cmderror.CmdError) {
+	statuses, err := fsClient.GetEtcdStatus(ctx)
+	if statuses == nil {
+		return nil, nil, fsclient.ToCmdError(err)
+	}
+	table, errTable := gotable.Create("addr", "version", "status")
+	if errTable != nil {
+		return nil, nil, cmderror.ToCmdError(errTable)
+	}
+	for _, status := range statuses {
+		table.AddRow(map[string]string{
+			"addr":    status.Addr,
+			"version": status.Version,
+			"status":  status.Status,
+		})
+	}
+	result, errResult := cobrautil.TableToResult(table)
+	if errResult != nil {
+		return nil, nil, cmderror.ToCmdError(errResult)
+	}
+	return result, table, fsclient.ToCmdError(err)
+}
+
+// GetEtcdStatus returns the status of etcd, the addresses are got from the flags of caller or the config
 func GetEtcdStatus(caller *cobra.Command) (*interface{}, *table.Table, *cmderror.CmdError) {
-	etcdCmd := NewStatusEtcdCommand()
-	etcdCmd.Cmd.SetArgs([]string{
-		fmt.Sprintf("--%s", config.FORMAT), config.FORMAT_NOOUT,
-	})
-	cobrautil.AlignFlags(caller, etcdCmd.Cmd, []string{
-		config.RPCRETRYTIMES, config.RPCTIMEOUT, config.CURVEFS_MDSADDR,
-	})
-	etcdCmd.Cmd.SilenceUsage = true
-	etcdCmd.Cmd.Execute()
-	return &etcdCmd.Result, etcdCmd.Table, etcdCmd.Error
+	ctx, span := tracing.Start(caller.Context(), "etcd", tracing.SPAN_KIND_INTERNAL)
+	defer span.End()
+	var result interface{}
+	fsClient, errCmd := newClient(caller)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		span.SetError(errCmd.Message)
+		return &result, nil, errCmd
+	}
+	result, table, errCmd := getStatus(ctx, fsClient)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		span.SetError(errCmd.Message)
+	}
+	return &result, table, errCmd
 }
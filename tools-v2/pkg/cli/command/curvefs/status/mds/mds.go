@@ -23,31 +23,26 @@
 package mds
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 
 	"github.com/liushuochen/gotable"
 	"github.com/liushuochen/gotable/table"
 	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
 	cobrautil "github.com/opencurve/curve/tools-v2/internal/utils"
 	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/fsclient"
+	"github.com/opencurve/curve/tools-v2/pkg/client"
 	config "github.com/opencurve/curve/tools-v2/pkg/config"
 	"github.com/opencurve/curve/tools-v2/pkg/output"
+	"github.com/opencurve/curve/tools-v2/pkg/tracing"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 type MdsCommand struct {
 	basecmd.FinalCurveCmd
-	metrics []basecmd.Metric
-	rows    []map[string]string
+	client *client.Client
 }
 
-const (
-	STATUS_SUBURI  = "/vars/curvefs_mds_status"
-	VERSION_SUBURI = "/vars/curve_version"
-)
-
 var _ basecmd.FinalCurveCmdFunc = (*MdsCommand)(nil) // check interface
 
 func NewMdsCommand() *cobra.Command {
@@ -68,43 +63,11 @@ func (mCmd *MdsCommand) AddFlags() {
 }
 
 func (mCmd *MdsCommand) Init(cmd *cobra.Command, args []string) error {
-	table, err := gotable.Create("addr", "dummyAddr", "version", "status")
-	if err != nil {
-		cobra.CheckErr(err)
-	}
-	mCmd.Table = table
-
-	// set main addr
-	mainAddrs, addrErr := config.GetFsMdsAddrSlice(mCmd.Cmd)
-	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
-	}
-
-	// set dummy addr
-	dummyAddrs, addrErr := config.GetFsMdsDummyAddrSlice(mCmd.Cmd)
-	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
-	}
-	for _, addr := range dummyAddrs {
-		// Use the dummy port to access the metric service
-		timeout := viper.GetDuration(config.VIPER_GLOBALE_HTTPTIMEOUT)
-
-		addrs := []string{addr}
-		statusMetric := basecmd.NewMetric(addrs, STATUS_SUBURI, timeout)
-		mCmd.metrics = append(mCmd.metrics, *statusMetric)
-		versionMetric := basecmd.NewMetric(addrs, VERSION_SUBURI, timeout)
-		mCmd.metrics = append(mCmd.metrics, *versionMetric)
-	}
-
-	for i := range mainAddrs {
-		row := make(map[string]string)
-		row["addr"] = mainAddrs[i]
-		row["dummyAddr"] = dummyAddrs[i]
-		row["status"] = "offline"
-		row["version"] = "unknown"
-		mCmd.rows = append(mCmd.rows, row)
+	var errCmd *cmderror.CmdError
+	mCmd.client, errCmd = newClient(mCmd.Cmd)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		return errCmd.ToError()
 	}
-
 	return nil
 }
 
@@ -113,60 +76,13 @@ func (mCmd *MdsCommand) Print(cmd *cobra.Command, args []string) error {
 }
 
 func (mCmd *MdsCommand) RunCommand(cmd *cobra.Command, args []string) error {
-	results := make(chan basecmd.MetricResult, config.MaxChannelSize())
-	size := 0
-	for _, metric := range mCmd.metrics {
-		size++
-		go func(m basecmd.Metric) {
-			result, err := basecmd.QueryMetric(m)
-			var key string
-			if m.SubUri == STATUS_SUBURI {
-				key = "status"
-			} else {
-				key = "version"
-			}
-			var value string
-			if err.TypeCode() == cmderror.CODE_SUCCESS {
-				value, err = basecmd.GetMetricValue(result)
-			}
-			results <- basecmd.MetricResult{
-				Addr:  m.Addrs[0],
-				Key:   key,
-				Value: value,
-				Err:   err,
-			}
-		}(metric)
-	}
-
-	count := 0
-	var errs []*cmderror.CmdError
-	for res := range results {
-		for _, row := range mCmd.rows {
-			if res.Err.TypeCode() == cmderror.CODE_SUCCESS && row["dummyAddr"] == res.Addr {
-				row[res.Key] = res.Value
-			} else if res.Err.TypeCode() != cmderror.CODE_SUCCESS {
-				offErr := cmderror.ErrMdsOffline()
-				offErr.Format(res.Addr)
-				errs = append(errs, offErr)
-			}
-		}
-		count++
-		if count >= size {
-			break
-		}
-	}
-	mCmd.Error= cmderror.MostImportantCmdError(errs)
-	mCmd.Table.AddRows(mCmd.rows)
-	jsonResult, err := mCmd.Table.JSON(0)
-	if err != nil {
-		cobra.CheckErr(err)
-	}
-	var m interface{}
-	err = json.Unmarshal([]byte(jsonResult), &m)
-	if err != nil {
-		cobra.CheckErr(err)
+	result, table, errCmd := getStatus(mCmd.Cmd.Context(), mCmd.client)
+	if table == nil {
+		return errCmd.ToError()
 	}
-	mCmd.Result = m
+	mCmd.Result = result
+	mCmd.Table = table
+	mCmd.Error = errCmd
 	return nil
 }
 
@@ -174,24 +90,53 @@ func (mCmd *MdsCommand) ResultPlainOutput() error {
 	return output.FinalCmdOutputPlain(&mCmd.FinalCurveCmd, mCmd)
 }
 
-func NewStatusMdsCommand() *MdsCommand {
-	mdsCmd := &MdsCommand{
-		FinalCurveCmd: basecmd.FinalCurveCmd{
-			Use:   "mds",
-			Short: "get the inode usage of curvefs",
-		},
+// the status of mds is got from the dummy addresses, so they are checked too
+func newClient(cmd *cobra.Command) (*client.Client, *cmderror.CmdError) {
+	_, errCmd := config.GetFsMdsDummyAddrSlice(cmd)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		return nil, errCmd
 	}
-	basecmd.NewFinalCurveCli(&mdsCmd.FinalCurveCmd, mdsCmd)
-	return mdsCmd
+	return fsclient.New(cmd)
 }
 
+// the table is nil if the status of none of mds is got
+func getStatus(ctx context.Context, fsClient *client.Client) (interface{}, *table.Table, *cmderror.CmdError) {
+	statuses, err := fsClient.GetMdsStatus(ctx)
+	if statuses == nil {
+		return nil, nil, fsclient.ToCmdError(err)
+	}
+	table, errTable := gotable.Create("addr", "dummyAddr", "version", "status")
+	if errTable != nil {
+		return nil, nil, cmderror.ToCmdError(errTable)
+	}
+	for _, status := range statuses {
+		table.AddRow(map[string]string{
+			"addr":      status.Addr,
+			"dummyAddr": status.MetricAddr,
+			"version":   status.Version,
+			"status":    status.Status,
+		})
+	}
+	result, errResult := cobrautil.TableToResult(table)
+	if errResult != nil {
+		return nil, nil, cmderror.ToCmdError(errResult)
+	}
+	return result, table, fsclient.ToCmdError(err)
+}
+
+// GetMdsStatus returns the status of mds, the addresses are got from the flags of caller or the config
 func GetMdsStatus(caller *cobra.Command) (*interface{}, *table.Table, *cmderror.CmdError) {
-	mdsCmd := NewStatusMdsCommand()
-	mdsCmd.Cmd.SetArgs([]string{
-		fmt.Sprintf("--%s", config.FORMAT), config.FORMAT_NOOUT,
-	})
-	cobrautil.AlignFlags(caller, mdsCmd.Cmd, []string{config.RPCRETRYTIMES, config.RPCTIMEOUT, config.CURVEFS_MDSADDR})
-	mdsCmd.Cmd.SilenceUsage = true
-	mdsCmd.Cmd.Execute()
-	return &mdsCmd.Result, mdsCmd.Table, mdsCmd.Error
+	ctx, span := tracing.Start(caller.Context(), "mds", tracing.SPAN_KIND_INTERNAL)
+	defer span.End()
+	var result interface{}
+	fsClient, errCmd := newClient(caller)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		span.SetError(errCmd.Message)
+		return &result, nil, errCmd
+	}
+	result, table, errCmd := getStatus(ctx, fsClient)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		span.SetError(errCmd.Message)
+	}
+	return &result, table, errCmd
 }
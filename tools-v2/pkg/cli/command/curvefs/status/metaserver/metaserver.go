@@ -23,32 +23,26 @@
 package metaserver
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 
 	"github.com/liushuochen/gotable"
 	"github.com/liushuochen/gotable/table"
 	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
 	cobrautil "github.com/opencurve/curve/tools-v2/internal/utils"
 	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
-	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/list/topology"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/fsclient"
+	"github.com/opencurve/curve/tools-v2/pkg/client"
 	config "github.com/opencurve/curve/tools-v2/pkg/config"
 	"github.com/opencurve/curve/tools-v2/pkg/output"
+	"github.com/opencurve/curve/tools-v2/pkg/tracing"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 type MetaserverCommand struct {
 	basecmd.FinalCurveCmd
-	metrics []basecmd.Metric
-	rows    []map[string]string
+	client *client.Client
 }
 
-const (
-	STATUS_SUBURI  = "/vars/pid"
-	VERSION_SUBURI = "/vars/curve_version"
-)
-
 var _ basecmd.FinalCurveCmdFunc = (*MetaserverCommand)(nil) // check interface
 
 func NewMetaserverCommand() *cobra.Command {
@@ -69,37 +63,10 @@ func (mCmd *MetaserverCommand) AddFlags() {
 }
 
 func (mCmd *MetaserverCommand) Init(cmd *cobra.Command, args []string) error {
-	externalAddrs, internalAddrs, errMetaserver := topology.GetMetaserverAddrs()
-	if errMetaserver.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(errMetaserver.Message)
-	}
-
-	table, err := gotable.Create("externalAddr", "internalAddr", "version", "status")
-	if err != nil {
-		cobra.CheckErr(err)
-	}
-	mCmd.Table = table
-
-	for i, addr := range externalAddrs {
-		if !cobrautil.IsValidAddr(addr) {
-			return fmt.Errorf("invalid metaserver external addr: %s", addr)
-		}
-
-		// set metrics
-		timeout := viper.GetDuration(config.VIPER_GLOBALE_HTTPTIMEOUT)
-		addrs := []string{addr}
-		statusMetric := basecmd.NewMetric(addrs, STATUS_SUBURI, timeout)
-		mCmd.metrics = append(mCmd.metrics, *statusMetric)
-		versionMetric := basecmd.NewMetric(addrs, VERSION_SUBURI, timeout)
-		mCmd.metrics = append(mCmd.metrics, *versionMetric)
-
-		// set rows
-		row := make(map[string]string)
-		row["externalAddr"] = addr
-		row["internalAddr"] = internalAddrs[i]
-		row["status"] = "offline"
-		row["version"] = "unknown"
-		mCmd.rows = append(mCmd.rows, row)
+	var errCmd *cmderror.CmdError
+	mCmd.client, errCmd = fsclient.New(mCmd.Cmd)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		return errCmd.ToError()
 	}
 	return nil
 }
@@ -109,66 +76,13 @@ func (mCmd *MetaserverCommand) Print(cmd *cobra.Command, args []string) error {
 }
 
 func (mCmd *MetaserverCommand) RunCommand(cmd *cobra.Command, args []string) error {
-	results := make(chan basecmd.MetricResult, config.MaxChannelSize())
-	size := 0
-	for _, metric := range mCmd.metrics {
-		size++
-		go func(m basecmd.Metric) {
-			result, err := basecmd.QueryMetric(m)
-			var key string
-			if m.SubUri == STATUS_SUBURI {
-				key = "status"
-			} else {
-				key = "version"
-			}
-			var value string
-			if err.TypeCode() == cmderror.CODE_SUCCESS {
-				value, err = basecmd.GetMetricValue(result)
-			}
-			results <- basecmd.MetricResult{
-				Addr:  m.Addrs[0],
-				Key:   key,
-				Value: value,
-				Err:   err,
-			}
-		}(metric)
-	}
-
-	count := 0
-	var errs []*cmderror.CmdError
-	for res := range results {
-		for _, row := range mCmd.rows {
-			if res.Err.TypeCode() == cmderror.CODE_SUCCESS && row["externalAddr"] == res.Addr {
-				if res.Key == "status" {
-					row[res.Key] = "online"
-				} else {
-					row[res.Key] = res.Value
-				}
-			} else if res.Err.TypeCode() != cmderror.CODE_SUCCESS {
-				offline := cmderror.ErrMetaserverOffline()
-				offline.Format(res.Addr)
-				errs = append(errs, offline)
-			}
-		}
-		count++
-		if count >= size {
-			break
-		}
-	}
-
-	mCmd.Error = cmderror.MostImportantCmdError(errs)
-
-	mCmd.Table.AddRows(mCmd.rows)
-	jsonResult, err := mCmd.Table.JSON(0)
-	if err != nil {
-		cobra.CheckErr(err)
+	result, table, errCmd := getStatus(mCmd.Cmd.Context(), mCmd.client)
+	if table == nil {
+		return errCmd.ToError()
 	}
-	var m interface{}
-	err = json.Unmarshal([]byte(jsonResult), &m)
-	if err != nil {
-		cobra.CheckErr(err)
-	}
-	mCmd.Result = m
+	mCmd.Result = result
+	mCmd.Table = table
+	mCmd.Error = errCmd
 	return nil
 }
 
@@ -176,27 +90,47 @@ func (mCmd *MetaserverCommand) ResultPlainOutput() error {
 	return output.FinalCmdOutputPlain(&mCmd.FinalCurveCmd, mCmd)
 }
 
-func NewStatusMetaserverCommand() *MetaserverCommand {
-	mdsCmd := &MetaserverCommand{
-		FinalCurveCmd: basecmd.FinalCurveCmd{
-			Use:   "metaserver",
-			Short: "get metaserver status of curvefs",
-		},
+// the table is nil if the metaservers can not be got from mds
+func getStatus(ctx context.Context, fsClient *client.Client) (interface{}, *table.Table, *cmderror.CmdError) {
+	statuses, err := fsClient.GetMetaserverStatus(ctx)
+	if statuses == nil && err != nil {
+		retErr := cmderror.ErrGetMetaserverAddr()
+		retErr.Format(err.Error())
+		return nil, nil, retErr.WithExitCode(fsclient.ToCmdError(err).ExitCode())
 	}
-	basecmd.NewFinalCurveCli(&mdsCmd.FinalCurveCmd, mdsCmd)
-	return mdsCmd
+	table, errTable := gotable.Create("externalAddr", "internalAddr", "version", "status")
+	if errTable != nil {
+		return nil, nil, cmderror.ToCmdError(errTable)
+	}
+	for _, status := range statuses {
+		table.AddRow(map[string]string{
+			"externalAddr": status.MetricAddr,
+			"internalAddr": status.Addr,
+			"version":      status.Version,
+			"status":       status.Status,
+		})
+	}
+	result, errResult := cobrautil.TableToResult(table)
+	if errResult != nil {
+		return nil, nil, cmderror.ToCmdError(errResult)
+	}
+	return result, table, fsclient.ToCmdError(err)
 }
 
+// GetMetaserverStatus returns the status of metaservers, the mds addresses are got
+// from the flags of caller or the config
 func GetMetaserverStatus(caller *cobra.Command) (*interface{}, *table.Table, *cmderror.CmdError) {
-	metaserverCmd := NewStatusMetaserverCommand()
-	metaserverCmd.Cmd.SetArgs([]string{
-		fmt.Sprintf("--%s", config.FORMAT), config.FORMAT_NOOUT,
-	})
-	cobrautil.AlignFlags(caller, metaserverCmd.Cmd, []string{
-		config.RPCRETRYTIMES, config.RPCTIMEOUT, config.CURVEFS_MDSADDR,
-	})
-	metaserverCmd.Cmd.SilenceUsage = true
-	metaserverCmd.Cmd.Execute()
-	return &metaserverCmd.Result, metaserverCmd.Table, metaserverCmd.Error
+	ctx, span := tracing.Start(caller.Context(), "metaserver", tracing.SPAN_KIND_INTERNAL)
+	defer span.End()
+	var result interface{}
+	fsClient, errCmd := fsclient.New(caller)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		span.SetError(errCmd.Message)
+		return &result, nil, errCmd
+	}
+	result, table, errCmd := getStatus(ctx, fsClient)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		span.SetError(errCmd.Message)
+	}
+	return &result, table, errCmd
 }
-
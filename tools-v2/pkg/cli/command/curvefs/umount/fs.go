@@ -32,12 +32,14 @@ import (
 	"github.com/liushuochen/gotable"
 	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
 	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs/fsclient"
 	"github.com/opencurve/curve/tools-v2/pkg/config"
 	"github.com/opencurve/curve/tools-v2/pkg/output"
 	mds "github.com/opencurve/curve/tools-v2/proto/curvefs/proto/mds"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
 type UmountFsRpc struct {
@@ -46,7 +48,7 @@ type UmountFsRpc struct {
 	mdsClient mds.MdsServiceClient
 }
 
-var _ basecmd.RpcFunc = (*UmountFsRpc)(nil) // check interface
+var _ basecmd.MutatingRpcFunc = (*UmountFsRpc)(nil) // check interface
 
 type FsCommand struct {
 	basecmd.FinalCurveCmd
@@ -65,6 +67,14 @@ func (ufRp *UmountFsRpc) Stub_Func(ctx context.Context) (interface{}, error) {
 	return ufRp.mdsClient.UmountFs(ctx, ufRp.Request)
 }
 
+func (ufRp *UmountFsRpc) IsMutating() bool {
+	return true
+}
+
+func (ufRp *UmountFsRpc) GetRequest() proto.Message {
+	return ufRp.Request
+}
+
 func NewFsCommand() *cobra.Command {
 	fsCmd := &FsCommand{
 		FinalCurveCmd: basecmd.FinalCurveCmd{
@@ -79,6 +89,8 @@ func NewFsCommand() *cobra.Command {
 func (fCmd *FsCommand) AddFlags() {
 	config.AddRpcRetryTimesFlag(fCmd.Cmd)
 	config.AddRpcTimeoutFlag(fCmd.Cmd)
+	config.AddRpcRetryMutatingFlag(fCmd.Cmd)
+	config.AddDryRunFlag(fCmd.Cmd)
 	config.AddFsMdsAddrFlag(fCmd.Cmd)
 	config.AddFsNameRequiredFlag(fCmd.Cmd)
 	config.AddMountpointFlag(fCmd.Cmd)
@@ -87,14 +99,14 @@ func (fCmd *FsCommand) AddFlags() {
 func (fCmd *FsCommand) Init(cmd *cobra.Command, args []string) error {
 	addrs, addrErr := config.GetFsMdsAddrSlice(fCmd.Cmd)
 	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
+		return addrErr.ToError()
 	}
 
 	fCmd.Rpc.Request = &mds.UmountFsRequest{}
 
-	fCmd.fsName = viper.GetString(config.VIPER_CURVEFS_FSNAME)
+	fCmd.fsName = config.GetFlagString(fCmd.Cmd, config.CURVEFS_FSNAME)
 	fCmd.Rpc.Request.FsName = &fCmd.fsName
-	fCmd.mountpoint = viper.GetString(config.VIPER_CURVEFS_MOUNTPOINT)
+	fCmd.mountpoint = config.GetFlagString(fCmd.Cmd, config.CURVEFS_MOUNTPOINT)
 	mountpointSlice := strings.Split(fCmd.mountpoint, ":")
 	if len(mountpointSlice) != 3 {
 		return fmt.Errorf("invalid mountpoint: %s", fCmd.mountpoint)
@@ -111,7 +123,8 @@ func (fCmd *FsCommand) Init(cmd *cobra.Command, args []string) error {
 	}
 	timeout := viper.GetDuration(config.VIPER_GLOBALE_RPCTIMEOUT)
 	retrytimes := viper.GetInt32(config.VIPER_GLOBALE_RPCRETRYTIMES)
-	fCmd.Rpc.Info = basecmd.NewRpc(addrs, timeout, retrytimes, "UmountFs")
+	fCmd.Rpc.Info = basecmd.NewMdsRpc(fCmd.Cmd, addrs, timeout, retrytimes, "UmountFs")
+	fCmd.Rpc.Info.RetryMutating = config.GetFlagBool(fCmd.Cmd, config.RPCRETRYMUTATING)
 
 	table, err := gotable.Create("fs name", "mountpoint", "result")
 	if err != nil {
@@ -127,12 +140,19 @@ func (fCmd *FsCommand) Print(cmd *cobra.Command, args []string) error {
 }
 
 func (fCmd *FsCommand) RunCommand(cmd *cobra.Command, args []string) error {
-	response, errCmd := basecmd.GetRpcResponse(fCmd.Rpc.Info, &fCmd.Rpc)
+	if err := fsclient.SetDryRunFs(fCmd.Cmd, fCmd.fsName); err.TypeCode() != cmderror.CODE_SUCCESS {
+		fCmd.Cmd.SilenceUsage = true
+		return err.ToError()
+	}
+	response, errCmd := basecmd.GetRpcResponse(fCmd.Cmd.Context(), fCmd.Rpc.Info, &fCmd.Rpc)
+	if basecmd.IsDryRunError(errCmd) {
+		return nil
+	}
 	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(errCmd.Message)
+		return errCmd.ToError()
 	}
 	uf := response.(*mds.UmountFsResponse)
-	fCmd.updateTable(uf)
+	fCmd.Error = fCmd.updateTable(uf)
 
 	jsonResult, err := fCmd.Table.JSON(0)
 	if err != nil {
@@ -154,6 +174,7 @@ func (fCmd *FsCommand) updateTable(info *mds.UmountFsResponse) *cmderror.CmdErro
 	rows[0]["fs name"] = fCmd.fsName
 	rows[0]["mountpoint"] = fCmd.mountpoint
 	err := cmderror.ErrUmountFs(int(info.GetStatusCode()))
+	rows[0]["result"] = err.Message
 
 	fCmd.Table.AddRows(rows)
 	return err
@@ -76,12 +76,12 @@ func (iCmd *InodeNumCommand) AddFlags() {
 func (iCmd *InodeNumCommand) Init(cmd *cobra.Command, args []string) error {
 	addrs, addrErr := config.GetFsMdsAddrSlice(iCmd.Cmd)
 	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
+		return addrErr.ToError()
 	}
 
 	iCmd.FsId2Filetype2Metric = make(map[string]map[string]basecmd.Metric)
 
-	fsIds := viper.GetStringSlice(config.VIPER_CURVEFS_FSID)
+	fsIds := config.GetFlagStringSlice(iCmd.Cmd, config.CURVEFS_FSID)
 	if len(fsIds) == 0 {
 		fsIds = []string{"*"}
 	}
@@ -116,7 +116,7 @@ func (iCmd *InodeNumCommand) RunCommand(cmd *cobra.Command, args []string) error
 		for filetype, metric := range filetype2Metric {
 			size++
 			go func(m basecmd.Metric, filetype string, id string) {
-				result, err := basecmd.QueryMetric(m)
+				result, err := basecmd.QueryMetric(cmd.Context(), m)
 				results <- Result{
 					Result: result,
 					Error:  err,
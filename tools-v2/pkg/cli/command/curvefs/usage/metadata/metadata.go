@@ -24,7 +24,6 @@ package metadata
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/dustin/go-humanize"
 	"github.com/liushuochen/gotable"
@@ -82,14 +81,14 @@ func (mCmd *MetadataCommand) AddFlags() {
 func (mCmd *MetadataCommand) Init(cmd *cobra.Command, args []string) error {
 	addrs, addrErr := config.GetFsMdsAddrSlice(mCmd.Cmd)
 	if addrErr.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(addrErr.Message)
+		return addrErr.ToError()
 	}
 
 	mCmd.Rpc = &MetadataRpc{}
 	mCmd.Rpc.Request = &topology.StatMetadataUsageRequest{}
 	timeout := viper.GetDuration(config.VIPER_GLOBALE_RPCTIMEOUT)
 	retrytimes := viper.GetInt32(config.VIPER_GLOBALE_RPCRETRYTIMES)
-	mCmd.Rpc.Info = basecmd.NewRpc(addrs, timeout, retrytimes, "StatMetadataUsage")
+	mCmd.Rpc.Info = basecmd.NewMdsRpc(mCmd.Cmd, addrs, timeout, retrytimes, "StatMetadataUsage")
 
 	table, err := gotable.Create("metaserverAddr", "total", "used", "left")
 	if err != nil {
@@ -104,9 +103,9 @@ func (mCmd *MetadataCommand) Print(cmd *cobra.Command, args []string) error {
 }
 
 func (mCmd *MetadataCommand) RunCommand(cmd *cobra.Command, args []string) error {
-	response, errCmd := basecmd.GetRpcResponse(mCmd.Rpc.Info, mCmd.Rpc)
+	response, errCmd := basecmd.GetRpcResponse(mCmd.Cmd.Context(), mCmd.Rpc.Info, mCmd.Rpc)
 	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
-		return fmt.Errorf(errCmd.Message)
+		return errCmd.ToError()
 	}
 	mCmd.Error = errCmd
 	mCmd.response = response.(*topology.StatMetadataUsageResponse)
@@ -0,0 +1,186 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package basecmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/liushuochen/gotable"
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	config "github.com/opencurve/curve/tools-v2/pkg/config"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	ROW_DRYRUN_RPC     = "rpc"
+	ROW_DRYRUN_ADDRS   = "addrs"
+	ROW_DRYRUN_REQUEST = "request"
+	ROW_DRYRUN_TARGET  = "target"
+)
+
+// DryRunRequest is the mutating rpc which would be sent without --dry-run
+type DryRunRequest struct {
+	Rpc     string      `json:"rpc"`
+	Addrs   []string    `json:"addrs"`
+	Request interface{} `json:"request"`
+	// what the request changes, got by the read-only rpc, e.g. the fs to delete
+	Target interface{} `json:"target,omitempty"`
+}
+
+// DryRun records the mutating rpc refused by GetRpcResponse in dry-run mode
+type DryRun struct {
+	mutex    sync.Mutex
+	requests []DryRunRequest
+	target   interface{}
+}
+
+type dryRunKey struct{}
+
+// NewDryRunContext returns the context in which the mutating rpc are not sent
+func NewDryRunContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, &DryRun{})
+}
+
+// DryRunFromContext returns the DryRun in ctx, nil if it is not in dry-run mode
+func DryRunFromContext(ctx context.Context) *DryRun {
+	if ctx == nil {
+		return nil
+	}
+	d, _ := ctx.Value(dryRunKey{}).(*DryRun)
+	return d
+}
+
+func IsDryRun(ctx context.Context) bool {
+	return DryRunFromContext(ctx) != nil
+}
+
+// IsDryRunError reports whether the rpc is refused because of dry-run mode
+func IsDryRunError(err *cmderror.CmdError) bool {
+	return err != nil && err.Code == cmderror.ErrDryRun().Code
+}
+
+func (d *DryRun) add(rpc *Rpc, rpcFunc RpcFunc) {
+	request := DryRunRequest{
+		Rpc:     rpc.RpcFuncName,
+		Addrs:   rpc.Addrs,
+		Request: rpcRequest(rpcFunc),
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	request.Target = d.target
+	d.requests = append(d.requests, request)
+}
+
+// SetTarget sets the target of the requests refused later
+func (d *DryRun) SetTarget(target proto.Message) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.target = protoToJson(target)
+}
+
+// Requests returns the rpc in the order they are refused
+func (d *DryRun) Requests() []DryRunRequest {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	ret := make([]DryRunRequest, len(d.requests))
+	copy(ret, d.requests)
+	return ret
+}
+
+// the request of the mutating rpcFunc, nil for the others
+func rpcRequest(rpcFunc RpcFunc) interface{} {
+	mutating, ok := rpcFunc.(MutatingRpcFunc)
+	if !ok {
+		return nil
+	}
+	return protoToJson(mutating.GetRequest())
+}
+
+// the message is converted by protojson, so the names are the same as proto
+func protoToJson(message proto.Message) interface{} {
+	jsonByte, err := protojson.Marshal(message)
+	if err != nil {
+		return message
+	}
+	var ret interface{}
+	if err := json.Unmarshal(jsonByte, &ret); err != nil {
+		return message
+	}
+	return ret
+}
+
+// refuseDryRun records the mutating rpc and refuses to send it in dry-run mode
+func refuseDryRun(ctx context.Context, rpc *Rpc, rpcFunc RpcFunc) *cmderror.CmdError {
+	dryRun := DryRunFromContext(ctx)
+	if dryRun == nil || !IsMutatingRpcFunc(rpcFunc) {
+		return nil
+	}
+	dryRun.add(rpc, rpcFunc)
+	retErr := cmderror.ErrDryRun()
+	retErr.Format(rpc.RpcFuncName)
+	return retErr
+}
+
+// the command which has --dry-run and it is set
+func (fc *FinalCurveCmd) dryRunEnabled() bool {
+	return fc.Cmd.Flags().Lookup(config.DRYRUN) != nil && config.GetFlagBool(fc.Cmd, config.DRYRUN)
+}
+
+// setDryRunResult replaces the result of the command by the requests it would send
+func (fc *FinalCurveCmd) setDryRunResult() error {
+	dryRun := DryRunFromContext(fc.Cmd.Context())
+	if dryRun == nil {
+		return nil
+	}
+	requests := dryRun.Requests()
+	table, err := gotable.Create(ROW_DRYRUN_RPC, ROW_DRYRUN_ADDRS, ROW_DRYRUN_REQUEST, ROW_DRYRUN_TARGET)
+	if err != nil {
+		return err
+	}
+	for _, request := range requests {
+		requestByte, err := json.Marshal(request.Request)
+		if err != nil {
+			return fmt.Errorf("marshal the request of %s failed: %w", request.Rpc, err)
+		}
+		targetByte := []byte{}
+		if request.Target != nil {
+			if targetByte, err = json.Marshal(request.Target); err != nil {
+				return fmt.Errorf("marshal the target of %s failed: %w", request.Rpc, err)
+			}
+		}
+		table.AddRow(map[string]string{
+			ROW_DRYRUN_RPC:     request.Rpc,
+			ROW_DRYRUN_ADDRS:   strings.Join(request.Addrs, ","),
+			ROW_DRYRUN_REQUEST: string(requestByte),
+			ROW_DRYRUN_TARGET:  string(targetByte),
+		})
+	}
+	fc.Table = table
+	fc.Result = requests
+	fc.Error = cmderror.ErrSuccess()
+	return nil
+}
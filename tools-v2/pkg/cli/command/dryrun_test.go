@@ -0,0 +1,136 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package basecmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	config "github.com/opencurve/curve/tools-v2/pkg/config"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// the health check is sent as the rpc which changes the cluster
+type mutatingHealthRpc struct {
+	healthRpc
+	Request *healthpb.HealthCheckRequest
+}
+
+func (mRpc *mutatingHealthRpc) IsMutating() bool {
+	return true
+}
+
+func (mRpc *mutatingHealthRpc) GetRequest() proto.Message {
+	return mRpc.Request
+}
+
+var _ MutatingRpcFunc = (*mutatingHealthRpc)(nil)
+
+type dryRunCommand struct {
+	FinalCurveCmd
+	addr string
+	err  *cmderror.CmdError
+}
+
+var _ FinalCurveCmdFunc = (*dryRunCommand)(nil)
+
+func (dCmd *dryRunCommand) AddFlags() {
+	config.AddDryRunFlag(dCmd.Cmd)
+}
+
+func (dCmd *dryRunCommand) Init(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (dCmd *dryRunCommand) RunCommand(cmd *cobra.Command, args []string) error {
+	rpc := &mutatingHealthRpc{Request: &healthpb.HealthCheckRequest{Service: "curvefs"}}
+	_, dCmd.err = GetRpcResponse(cmd.Context(), NewRpc([]string{dCmd.addr}, time.Second, 0, "Check"), rpc)
+	dCmd.Result = "sent"
+	return nil
+}
+
+func (dCmd *dryRunCommand) Print(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (dCmd *dryRunCommand) ResultPlainOutput() error {
+	return nil
+}
+
+func TestDryRun(t *testing.T) {
+	// the mutating rpc sent are recorded
+	viper.Set(config.VIPER_GLOBALE_AUDITLOG, filepath.Join(t.TempDir(), "audit.log"))
+	defer viper.Set(config.VIPER_GLOBALE_AUDITLOG, "")
+
+	Convey("the mutating rpc is not sent in dry-run mode", t, func() {
+		server, addr := startHealthServer()
+		defer server.Stop()
+
+		ctx := NewDryRunContext(context.Background())
+		So(IsDryRun(ctx), ShouldBeTrue)
+		rpc := &mutatingHealthRpc{Request: &healthpb.HealthCheckRequest{Service: "curvefs"}}
+		_, err := GetRpcResponse(ctx, NewRpc([]string{addr}, time.Second, 0, "Check"), rpc)
+		So(IsDryRunError(err), ShouldBeTrue)
+		requests := DryRunFromContext(ctx).Requests()
+		So(requests, ShouldHaveLength, 1)
+		So(requests[0].Rpc, ShouldEqual, "Check")
+		So(requests[0].Addrs, ShouldResemble, []string{addr})
+		So(requests[0].Request, ShouldResemble, map[string]interface{}{"service": "curvefs"})
+
+		// the rpc which does not change the cluster is sent
+		_, err = GetRpcResponse(ctx, NewRpc([]string{addr}, time.Second, 0, "Check"), &healthRpc{})
+		So(err.TypeCode(), ShouldEqual, cmderror.CODE_SUCCESS)
+		So(DryRunFromContext(ctx).Requests(), ShouldHaveLength, 1)
+
+		_, err = GetRpcResponse(context.Background(), NewRpc([]string{addr}, time.Second, 0, "Check"), rpc)
+		So(err.TypeCode(), ShouldEqual, cmderror.CODE_SUCCESS)
+	})
+
+	Convey("the result of command is the requests in dry-run mode", t, func() {
+		server, addr := startHealthServer()
+		defer server.Stop()
+
+		dCmd := &dryRunCommand{addr: addr}
+		NewFinalCurveCli(&dCmd.FinalCurveCmd, dCmd)
+		dCmd.Cmd.SetArgs([]string{"--" + config.DRYRUN})
+		So(dCmd.Cmd.Execute(), ShouldBeNil)
+		So(IsDryRunError(dCmd.err), ShouldBeTrue)
+		So(dCmd.Result, ShouldHaveSameTypeAs, []DryRunRequest{})
+		So(dCmd.Result, ShouldHaveLength, 1)
+		So(dCmd.Table.GetValues(), ShouldHaveLength, 1)
+		So(dCmd.Table.GetValues()[0][ROW_DRYRUN_REQUEST], ShouldEqual, `{"service":"curvefs"}`)
+
+		dCmd = &dryRunCommand{addr: addr}
+		NewFinalCurveCli(&dCmd.FinalCurveCmd, dCmd)
+		dCmd.Cmd.SetArgs([]string{})
+		So(dCmd.Cmd.Execute(), ShouldBeNil)
+		So(dCmd.err.TypeCode(), ShouldEqual, cmderror.CODE_SUCCESS)
+		So(dCmd.Result, ShouldEqual, "sent")
+	})
+}
@@ -0,0 +1,164 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package basecmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	config "github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/pkg/recording"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	recordingMutex sync.Mutex
+	// the traffic is saved by recorder with --record,
+	// or served by player with --replay
+	recorder *recording.Recorder
+	player   *recording.Player
+)
+
+// OpenRecording records the rpc and http traffic in recordDir or replays
+// it from replayDir, the dir opened already is kept, e.g. by the lines of shell
+func OpenRecording(recordDir string, replayDir string) error {
+	recordingMutex.Lock()
+	defer recordingMutex.Unlock()
+	if recordDir != "" && replayDir != "" {
+		recorder, player = nil, nil
+		return fmt.Errorf("--%s and --%s can not be used together", config.RECORD, config.REPLAY)
+	}
+	var err error
+	if recordDir == "" {
+		recorder = nil
+	} else if recorder == nil || recorder.Dir() != recordDir {
+		recorder, err = recording.NewRecorder(recordDir)
+	}
+	if err != nil {
+		return err
+	}
+	if replayDir == "" {
+		player = nil
+	} else if player == nil || player.Dir() != replayDir {
+		player, err = recording.NewPlayer(replayDir)
+	}
+	return err
+}
+
+func currentRecording() (*recording.Recorder, *recording.Player) {
+	recordingMutex.Lock()
+	defer recordingMutex.Unlock()
+	return recorder, player
+}
+
+// recordingInterceptor saves the rpc with --record, and returns the
+// response recorded instead of sending the rpc with --replay
+func recordingInterceptor(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	recorder, player := currentRecording()
+	request, okRequest := req.(proto.Message)
+	response, okResponse := reply.(proto.Message)
+	if !okRequest || !okResponse {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	if player != nil {
+		return player.PlayRpc(cc.Target(), method, request, response)
+	}
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	// the rpc canceled is not needed any more, e.g. another addr responds first
+	if recorder != nil && status.Code(err) != codes.Canceled {
+		if errRecord := recorder.RecordRpc(cc.Target(), method, request, response, err); errRecord != nil {
+			warnRecording(ctx, cc.Target(), method, errRecord)
+		}
+	}
+	return err
+}
+
+type recordingTransport struct {
+	next http.RoundTripper
+}
+
+// newRecordingTransport saves the http get with --record,
+// and serves it by the recording with --replay
+func newRecordingTransport(next http.RoundTripper) http.RoundTripper {
+	return &recordingTransport{next: next}
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder, player := currentRecording()
+	host, uri := req.URL.Host, req.URL.RequestURI()
+	if player != nil {
+		statusCode, body, err := player.PlayHttp(host, uri)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+			StatusCode: statusCode,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	}
+	resp, err := t.next.RoundTrip(req)
+	// the request canceled is not needed any more, e.g. another addr responds first
+	if recorder == nil || req.Context().Err() != nil {
+		return resp, err
+	}
+	var body string
+	if err == nil {
+		body, err = readBody(resp)
+	}
+	var errRecord error
+	if err != nil {
+		errRecord = recorder.RecordHttp(host, uri, 0, "", err)
+	} else {
+		errRecord = recorder.RecordHttp(host, uri, resp.StatusCode, body, nil)
+	}
+	if errRecord != nil {
+		warnRecording(req.Context(), host, uri, errRecord)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// the failure is warned but does not fail the command, like the audit log
+func warnRecording(ctx context.Context, addr string, method string, err error) {
+	errRecord := cmderror.ErrRecording()
+	errRecord.Format(method, addr, err.Error())
+	cmderror.FromContext(ctx).Add(cmderror.ErrorEntry{Err: errRecord, Addr: addr, Rpc: method})
+	fmt.Fprintln(os.Stderr, "Warning:", errRecord.Message)
+}
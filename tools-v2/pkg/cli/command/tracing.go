@@ -0,0 +1,78 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package basecmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/tracing"
+)
+
+var (
+	tracerMutex sync.Mutex
+	// the spans are written to the file of --trace-file
+	tracer *tracing.Tracer
+)
+
+// OpenTrace traces the commands to the file, or stops tracing if path is empty,
+// the tracer of the same file is kept, e.g. by the lines of shell
+func OpenTrace(path string) {
+	tracerMutex.Lock()
+	defer tracerMutex.Unlock()
+	if path == "" {
+		tracer = nil
+	} else if tracer == nil || tracer.Path() != path {
+		tracer = tracing.NewTracer(path)
+	}
+}
+
+func currentTracer() *tracing.Tracer {
+	tracerMutex.Lock()
+	defer tracerMutex.Unlock()
+	return tracer
+}
+
+// startSpan starts a span as the child of the one in ctx, the span is nil without --trace-file,
+// the rpc and http of the command are traced by transport as the children of it
+func startSpan(ctx context.Context, name string, kind tracing.SpanKind) (context.Context, *tracing.Span) {
+	return currentTracer().Start(ctx, name, kind)
+}
+
+// endSpan ends the span with the error if it fails, the failure of
+// writing the trace is warned but does not fail the command
+func endSpan(span *tracing.Span, err *cmderror.CmdError) {
+	if span == nil {
+		return
+	}
+	if err != nil && err.TypeCode() != cmderror.CODE_SUCCESS {
+		span.SetError(err.Message)
+	}
+	if errWrite := span.End(); errWrite != nil {
+		errTrace := cmderror.ErrTrace()
+		errTrace.Format(currentTracer().Path(), errWrite.Error())
+		fmt.Fprintln(os.Stderr, "Warning:", errTrace.Message)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package basecmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	config "github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/pkg/transport"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+)
+
+// The rpc and metric of commands are sent by the package transport,
+// the commands add the dry-run, audit log, -v and --record/--replay to it.
+type (
+	Rpc             = transport.Rpc
+	RpcFunc         = transport.RpcFunc
+	RpcResult       = transport.RpcResult
+	MutatingRpcFunc = transport.MutatingRpcFunc
+	LeaderResolver  = transport.LeaderResolver
+	Metric          = transport.Metric
+	MetricResult    = transport.MetricResult
+)
+
+const (
+	MDS_STATUS_SUBURI = transport.MDS_STATUS_SUBURI
+	MDS_STATUS_LEADER = transport.MDS_STATUS_LEADER
+)
+
+func init() {
+	transport.AddRpcHook(commandRpcHook)
+	transport.AddDialOptions(
+		grpc.WithChainUnaryInterceptor(verboseInterceptor, recordingInterceptor),
+		grpc.WithContextDialer(dialContext),
+	)
+	// the http replayed is logged too
+	transport.AddHttpTransport(newVerboseTransport)
+	transport.AddHttpTransport(newRecordingTransport)
+}
+
+// commandRpcHook does not send the MutatingRpcFunc in dry-run mode,
+// and records it in the audit log when it is sent, not replayed.
+func commandRpcHook(ctx context.Context, rpc *Rpc, rpcFunc RpcFunc, call transport.RpcCall) (interface{}, *cmderror.CmdError) {
+	if err := refuseDryRun(ctx, rpc, rpcFunc); err != nil {
+		return nil, err
+	}
+	if _, player := currentRecording(); !IsMutatingRpcFunc(rpcFunc) || player != nil {
+		return call(ctx)
+	}
+	start := time.Now()
+	res, err := call(ctx)
+	auditRpc(ctx, rpc, rpcFunc, res, err, time.Since(start))
+	return res, err
+}
+
+// dialContext logs the dials with -v, no connection is made with --replay
+func dialContext(ctx context.Context, addr string) (net.Conn, error) {
+	if _, player := currentRecording(); player != nil {
+		return nil, fmt.Errorf("connect to %s in replay mode", addr)
+	}
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		logVerbose(VERBOSE_RPC, fmt.Sprintf("dial %s: %v", addr, err))
+		return nil, err
+	}
+	logVerbose(VERBOSE_RPC, fmt.Sprintf("dial %s", addr))
+	return conn, nil
+}
+
+func NewRpc(addrs []string, timeout time.Duration, retryTimes int32, funcName string) *Rpc {
+	return transport.NewRpc(addrs, timeout, retryTimes, funcName)
+}
+
+// NewMdsRpc is the same as NewRpc, but the rpc is only sent to the mds leader
+// if the dummy addrs of mds are set.
+func NewMdsRpc(cmd *cobra.Command, addrs []string, timeout time.Duration, retryTimes int32, funcName string) *Rpc {
+	rpc := NewRpc(addrs, timeout, retryTimes, funcName)
+	if len(addrs) <= 1 || config.GetFlagString(cmd, config.CURVEFS_MDSDUMMYADDR) == "" {
+		return rpc
+	}
+	dummyAddrs, err := config.GetFsMdsDummyAddrSlice(cmd)
+	if err.TypeCode() != cmderror.CODE_SUCCESS || len(dummyAddrs) != len(addrs) {
+		return rpc
+	}
+	httpTimeout := viper.GetDuration(config.VIPER_GLOBALE_HTTPTIMEOUT)
+	if httpTimeout <= 0 {
+		httpTimeout = config.DEFAULT_HTTPTIMEOUT
+	}
+	rpc.Leader = transport.NewMdsLeader(addrs, dummyAddrs, httpTimeout, MdsLeaderCacheFile())
+	return rpc
+}
+
+// MdsLeaderCacheFile is the file which keeps the mds leaders for the later commands,
+// it is "" if the cache is disabled by curvefs.cacheMdsLeader
+func MdsLeaderCacheFile() string {
+	if !viper.GetBool(config.VIPER_CURVEFS_CACHEMDSLEADER) {
+		return ""
+	}
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, transport.MDS_LEADER_CACHE_FILE)
+}
+
+// GetRpcResponse sends the rpc to all rpc.Addrs (or the leader only) and
+// returns the first success response, see transport.GetRpcResponse
+func GetRpcResponse(ctx context.Context, rpc *Rpc, rpcFunc RpcFunc) (interface{}, *cmderror.CmdError) {
+	return transport.GetRpcResponse(ctx, rpc, rpcFunc)
+}
+
+func GetRpcListResponse(ctx context.Context, rpcList []*Rpc, rpcFunc []RpcFunc) ([]interface{}, *cmderror.CmdError) {
+	return transport.GetRpcListResponse(ctx, rpcList, rpcFunc)
+}
+
+func IsMutatingRpcFunc(rpcFunc RpcFunc) bool {
+	return transport.IsMutatingRpcFunc(rpcFunc)
+}
+
+// ContextCmdError returns the reason why ctx is done
+func ContextCmdError(ctx context.Context) *cmderror.CmdError {
+	return transport.ContextCmdError(ctx)
+}
+
+func NewMetric(addrs []string, subUri string, timeout time.Duration) *Metric {
+	return transport.NewMetric(addrs, subUri, timeout)
+}
+
+func QueryMetric(ctx context.Context, m Metric) (string, *cmderror.CmdError) {
+	return transport.QueryMetric(ctx, m)
+}
+
+func GetMetricValue(metricRet string) (string, *cmderror.CmdError) {
+	return transport.GetMetricValue(metricRet)
+}
+
+func GetKeyValueFromJsonMetric(metricRet string, key string) (string, *cmderror.CmdError) {
+	return transport.GetKeyValueFromJsonMetric(metricRet, key)
+}
+
+// CloseConnPool closes the connections shared by the rpc of the process
+func CloseConnPool() {
+	transport.CloseConnPool()
+}
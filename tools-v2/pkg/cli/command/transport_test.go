@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package basecmd
+
+import (
+	"context"
+	"net"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type healthRpc struct {
+	client healthpb.HealthClient
+}
+
+func (hRpc *healthRpc) NewRpcClient(cc grpc.ClientConnInterface) {
+	hRpc.client = healthpb.NewHealthClient(cc)
+}
+
+func (hRpc *healthRpc) Stub_Func(ctx context.Context) (interface{}, error) {
+	return hRpc.client.Check(ctx, &healthpb.HealthCheckRequest{})
+}
+
+func startHealthServer() (*grpc.Server, string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	So(err, ShouldBeNil)
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(lis)
+	return server, lis.Addr().String()
+}
@@ -0,0 +1,181 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package basecmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opencurve/curve/tools-v2/pkg/audit"
+	config "github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// the levels of -v, every level logs more than the former one
+const (
+	// the dials, and the rpc with the target, status and latency
+	VERBOSE_RPC = 1
+	// the request and response of rpc in json, the secrets are redacted
+	VERBOSE_RPC_BODY = 2
+	// the http get of metrics with the url and body
+	VERBOSE_HTTP = 3
+
+	VERBOSE_TIME_LAYOUT = "15:04:05.000000"
+)
+
+var (
+	// the verbose logs are written to stderr
+	VerboseOutput io.Writer = os.Stderr
+	verboseMutex  sync.Mutex
+)
+
+func verboseLevel() int {
+	return viper.GetInt(config.VIPER_GLOBALE_VERBOSE)
+}
+
+// logVerbose writes the lines as a log if -v is not less than level,
+// the lines of the logs written concurrently are not interleaved
+func logVerbose(level int, lines ...string) {
+	if verboseLevel() < level {
+		return
+	}
+	var builder strings.Builder
+	builder.WriteString(time.Now().Format(VERBOSE_TIME_LAYOUT))
+	builder.WriteString(" ")
+	builder.WriteString(strings.Join(lines, "\n    "))
+	builder.WriteString("\n")
+	verboseMutex.Lock()
+	defer verboseMutex.Unlock()
+	io.WriteString(VerboseOutput, builder.String())
+}
+
+// verboseInterceptor logs the rpc sent or replayed with -v
+func verboseInterceptor(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	level := verboseLevel()
+	if level < VERBOSE_RPC {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	duration := time.Since(start)
+
+	var result string
+	if err != nil {
+		s := status.Convert(err)
+		result = fmt.Sprintf("%s: %s", s.Code(), s.Message())
+	} else if statusCode := responseStatus(reply); statusCode != "" {
+		result = fmt.Sprintf("OK, statusCode: %s", statusCode)
+	} else {
+		result = "OK"
+	}
+	lines := []string{fmt.Sprintf("rpc %s to %s: %s, in %s", method, cc.Target(), result, duration)}
+	if level >= VERBOSE_RPC_BODY {
+		lines = append(lines, "request: "+redactedProtoJson(req))
+		if err == nil {
+			lines = append(lines, "response: "+redactedProtoJson(reply))
+		}
+	}
+	logVerbose(VERBOSE_RPC, lines...)
+	return err
+}
+
+// the message in json, the secrets like s3 sk and the password of volume are redacted
+func redactedProtoJson(value interface{}) string {
+	message, ok := value.(proto.Message)
+	if !ok {
+		return fmt.Sprintf("%v", value)
+	}
+	jsonByte, err := protojson.MarshalOptions{AllowPartial: true}.Marshal(message)
+	if err != nil {
+		return err.Error()
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(jsonByte, &decoded); err != nil {
+		return err.Error()
+	}
+	jsonByte, err = json.Marshal(audit.Redact(decoded))
+	if err != nil {
+		return err.Error()
+	}
+	return string(jsonByte)
+}
+
+type verboseTransport struct {
+	next http.RoundTripper
+}
+
+// newVerboseTransport logs the http get of metric with -v at the highest level
+func newVerboseTransport(next http.RoundTripper) http.RoundTripper {
+	return &verboseTransport{next: next}
+}
+
+func (t *verboseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if verboseLevel() < VERBOSE_HTTP {
+		return t.next.RoundTrip(req)
+	}
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	url := req.URL.String()
+	switch {
+	case req.Context().Err() != nil:
+		logVerbose(VERBOSE_HTTP, fmt.Sprintf("http %s %s: canceled, in %s", req.Method, url, time.Since(start)))
+	case err != nil:
+		logVerbose(VERBOSE_HTTP, fmt.Sprintf("http %s %s: %v, in %s", req.Method, url, err, time.Since(start)))
+	case resp.StatusCode != http.StatusOK:
+		logVerbose(VERBOSE_HTTP, fmt.Sprintf("http %s %s: %s, in %s", req.Method, url, resp.Status, time.Since(start)))
+	default:
+		body, errRead := readBody(resp)
+		if errRead != nil {
+			logVerbose(VERBOSE_HTTP, fmt.Sprintf("http %s %s: %v, in %s", req.Method, url, errRead, time.Since(start)))
+			return nil, errRead
+		}
+		logVerbose(VERBOSE_HTTP, fmt.Sprintf("http %s %s: OK, in %s", req.Method, url, time.Since(start)),
+			"body: "+strings.TrimSpace(body))
+	}
+	return resp, err
+}
+
+// readBody reads the whole body of resp, and replaces it by the one read
+// so that the caller can still read it
+func readBody(resp *http.Response) (string, error) {
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return string(body), nil
+}
@@ -0,0 +1,111 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package basecmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	config "github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/common"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/mds"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/proto"
+)
+
+func withVerbose(level int, f func(out *bytes.Buffer)) {
+	out := &bytes.Buffer{}
+	stderr := VerboseOutput
+	VerboseOutput = out
+	viper.Set(config.VIPER_GLOBALE_VERBOSE, level)
+	defer func() {
+		VerboseOutput = stderr
+		viper.Set(config.VIPER_GLOBALE_VERBOSE, 0)
+	}()
+	f(out)
+}
+
+func TestVerbose(t *testing.T) {
+	Convey("the rpc is logged by the level", t, func() {
+		server, addr := startHealthServer()
+		defer server.Stop()
+		rpc := NewRpc([]string{addr}, time.Second, 0, "Check")
+
+		withVerbose(VERBOSE_RPC, func(out *bytes.Buffer) {
+			_, err := GetRpcResponse(context.Background(), rpc, &healthRpc{})
+			So(err.Message, ShouldEqual, "success")
+			So(out.String(), ShouldContainSubstring, "rpc /grpc.health.v1.Health/Check to "+addr+": OK, in ")
+			So(out.String(), ShouldNotContainSubstring, "request: ")
+		})
+
+		withVerbose(VERBOSE_RPC_BODY, func(out *bytes.Buffer) {
+			GetRpcResponse(context.Background(), rpc, &healthRpc{})
+			So(out.String(), ShouldContainSubstring, "request: {}")
+			So(out.String(), ShouldContainSubstring, `response: {"status":"SERVING"}`)
+		})
+
+		withVerbose(0, func(out *bytes.Buffer) {
+			GetRpcResponse(context.Background(), rpc, &healthRpc{})
+			So(out.Len(), ShouldEqual, 0)
+		})
+	})
+
+	Convey("the http of metric is logged at the highest level", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("curve_version : 2.5.0\n"))
+		}))
+		defer server.Close()
+		addr := strings.TrimPrefix(server.URL, "http://")
+		metric := NewMetric([]string{addr}, "/vars/curve_version", time.Second)
+
+		withVerbose(VERBOSE_RPC_BODY, func(out *bytes.Buffer) {
+			QueryMetric(context.Background(), *metric)
+			So(out.Len(), ShouldEqual, 0)
+		})
+		withVerbose(VERBOSE_HTTP, func(out *bytes.Buffer) {
+			QueryMetric(context.Background(), *metric)
+			So(out.String(), ShouldContainSubstring, "http GET "+server.URL+"/vars/curve_version: OK, in ")
+			So(out.String(), ShouldContainSubstring, "body: curve_version : 2.5.0")
+		})
+	})
+
+	Convey("the secrets are redacted", t, func() {
+		request := &mds.CreateFsRequest{
+			FsName: proto.String("test1"),
+			FsDetail: &mds.FsDetail{
+				S3Info: &common.S3Info{Ak: proto.String("ak1"), Sk: proto.String("sk1")},
+				Volume: &common.Volume{User: proto.String("curve"), Password: proto.String("password1")},
+			},
+		}
+		value := redactedProtoJson(request)
+		So(value, ShouldContainSubstring, `"fsName":"test1"`)
+		So(value, ShouldContainSubstring, `"user":"curve"`)
+		So(value, ShouldNotContainSubstring, "sk1")
+		So(value, ShouldNotContainSubstring, "password1")
+	})
+}
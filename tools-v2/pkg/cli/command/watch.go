@@ -0,0 +1,109 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package basecmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/moby/term"
+	config "github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/spf13/viper"
+)
+
+const (
+	ANSI_CLEAR_SCREEN = "\x1b[H\x1b[2J"
+)
+
+// WatchStoppedError is returned when --watch is stopped by Ctrl-C or --cmdtimeout,
+// the process exits with Err, which is the error of the last run
+type WatchStoppedError struct {
+	Err error
+}
+
+func (e *WatchStoppedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *WatchStoppedError) Unwrap() error {
+	return e.Err
+}
+
+// only the command run by user watches, the nested ones and noout run once
+func (fc *FinalCurveCmd) watchInterval() time.Duration {
+	if !fc.Cmd.HasParent() || fc.Cmd.Flag(config.FORMAT).Value.String() == config.FORMAT_NOOUT {
+		return 0
+	}
+	return viper.GetDuration(config.VIPER_GLOBALE_WATCH)
+}
+
+// watch runs the command every interval until ctx is done,
+// Init has been run for the first time
+func (fc *FinalCurveCmd) watch(ctx context.Context, funcs FinalCurveCmdFunc, args []string, interval time.Duration) error {
+	var err error
+	for first := true; ; first = false {
+		if !first {
+			fc.newRun(ctx)
+			err = funcs.Init(fc.Cmd, args)
+		}
+		if err == nil {
+			err = fc.runCommand(funcs, args)
+		}
+		fc.printWatchHeader(interval)
+		if err == nil {
+			err = funcs.Print(fc.Cmd, args)
+		} else {
+			fc.ShowErrors()
+		}
+		if err != nil {
+			fmt.Fprintln(fc.Cmd.ErrOrStderr(), "Error:", err)
+		}
+		fc.LastTable = fc.Table
+		fc.endRun(err)
+
+		select {
+		case <-ctx.Done():
+			if err == nil {
+				return nil
+			}
+			fc.Cmd.SilenceErrors = true
+			fc.Cmd.SilenceUsage = true
+			return &WatchStoppedError{Err: err}
+		case <-time.After(interval):
+		}
+	}
+}
+
+// like watch(1): Every 2s: curve fs status copyset    2022-08-01 15:04:05,
+// only for the plain format, the others are parsed by programs
+func (fc *FinalCurveCmd) printWatchHeader(interval time.Duration) {
+	if fc.Cmd.Flag(config.FORMAT).Value.String() != config.FORMAT_PLAIN {
+		return
+	}
+	out := fc.Cmd.OutOrStdout()
+	if file, ok := out.(*os.File); ok && term.IsTerminal(file.Fd()) {
+		fmt.Fprint(out, ANSI_CLEAR_SCREEN)
+	}
+	fmt.Fprintf(out, "Every %s: %s    %s\n\n", interval, fc.Cmd.CommandPath(), time.Now().Format("2006-01-02 15:04:05"))
+}
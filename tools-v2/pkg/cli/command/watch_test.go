@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package basecmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	config "github.com/opencurve/curve/tools-v2/pkg/config"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type countCommand struct {
+	FinalCurveCmd
+	inits  int
+	runs   int
+	prints int
+}
+
+var _ FinalCurveCmdFunc = (*countCommand)(nil)
+
+func (cCmd *countCommand) AddFlags() {}
+
+func (cCmd *countCommand) Init(cmd *cobra.Command, args []string) error {
+	cCmd.inits++
+	return nil
+}
+
+func (cCmd *countCommand) RunCommand(cmd *cobra.Command, args []string) error {
+	cCmd.runs++
+	// the second run fails, and the others succeed
+	if cCmd.runs == 2 {
+		return cmderror.ErrRpcDial().ToError()
+	}
+	return nil
+}
+
+func (cCmd *countCommand) Print(cmd *cobra.Command, args []string) error {
+	cCmd.prints++
+	return nil
+}
+
+func (cCmd *countCommand) ResultPlainOutput() error {
+	return nil
+}
+
+func newCountCommand() (*countCommand, *cobra.Command) {
+	cCmd := &countCommand{FinalCurveCmd: FinalCurveCmd{Use: "count"}}
+	NewFinalCurveCli(&cCmd.FinalCurveCmd, cCmd)
+	root := &cobra.Command{Use: "curve"}
+	root.AddCommand(cCmd.Cmd)
+	root.SetArgs([]string{"count"})
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	return cCmd, root
+}
+
+func TestWatch(t *testing.T) {
+	Convey("TestWatch", t, func() {
+		defer viper.Set(config.VIPER_GLOBALE_WATCH, time.Duration(0))
+
+		Convey("run once without --watch", func() {
+			cCmd, root := newCountCommand()
+			So(root.Execute(), ShouldBeNil)
+			So([]int{cCmd.inits, cCmd.runs, cCmd.prints}, ShouldResemble, []int{1, 1, 1})
+		})
+
+		Convey("run until the context is done", func() {
+			viper.Set(config.VIPER_GLOBALE_WATCH, 20*time.Millisecond)
+			cCmd, root := newCountCommand()
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			So(root.ExecuteContext(ctx), ShouldBeNil)
+			So(cCmd.runs, ShouldBeGreaterThanOrEqualTo, 3)
+			So(cCmd.inits, ShouldEqual, cCmd.runs)
+			// the failed run is not printed
+			So(cCmd.prints, ShouldEqual, cCmd.runs-1)
+		})
+
+		Convey("exit with the last result", func() {
+			viper.Set(config.VIPER_GLOBALE_WATCH, time.Hour)
+			cCmd, root := newCountCommand()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			cCmd.runs = 1
+			err := root.ExecuteContext(ctx)
+			var stopped *WatchStoppedError
+			So(errors.As(err, &stopped), ShouldBeTrue)
+			So(cmderror.ExitCode(stopped.Err), ShouldEqual, cmderror.EXIT_UNREACHABLE)
+		})
+	})
+}
@@ -23,19 +23,33 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
 
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
 	cobraUtil "github.com/opencurve/curve/tools-v2/internal/utils"
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/cli/command/audit"
+	configcmd "github.com/opencurve/curve/tools-v2/pkg/cli/command/config"
 	"github.com/opencurve/curve/tools-v2/pkg/cli/command/curvefs"
 	"github.com/opencurve/curve/tools-v2/pkg/cli/command/version"
 	config "github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/pkg/transport"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 func addSubCommands(cmd *cobra.Command) {
 	cmd.AddCommand(curvefs.NewCurveFsCommand())
+	cmd.AddCommand(audit.NewAuditCommand())
+	cmd.AddCommand(configcmd.NewConfigCommand())
+	cmd.AddCommand(newShellCommand())
+	cmd.AddCommand(newRunCommand(newCurveCommand))
 }
 
 func setupRootCommand(cmd *cobra.Command) {
@@ -45,6 +59,54 @@ func setupRootCommand(cmd *cobra.Command) {
 	cobraUtil.SetUsageTemplate(cmd)
 }
 
+func preRun(cmd *cobra.Command, args []string) error {
+	// the config commands are still usable to fix the current context
+	if err := config.ContextError(); err != nil && !isConfigCommand(cmd) {
+		cmd.SilenceUsage = true
+		return err
+	}
+	if err := basecmd.OpenRecording(viper.GetString(config.VIPER_GLOBALE_RECORD), viper.GetString(config.VIPER_GLOBALE_REPLAY)); err != nil {
+		cmd.SilenceUsage = true
+		return err
+	}
+	basecmd.OpenTrace(viper.GetString(config.VIPER_GLOBALE_TRACEFILE))
+	transport.SetConnIdleTimeout(viper.GetDuration(config.VIPER_GLOBALE_CONNIDLETIMEOUT))
+	setCmdTimeout(cmd, args)
+	return nil
+}
+
+func isConfigCommand(cmd *cobra.Command) bool {
+	for ; cmd.HasParent(); cmd = cmd.Parent() {
+		if cmd.Name() == "config" && !cmd.Parent().HasParent() {
+			return true
+		}
+	}
+	return false
+}
+
+// release the deadline set by --cmdtimeout
+var cancelCmdTimeout context.CancelFunc = func() {}
+
+func setCmdTimeout(cmd *cobra.Command, args []string) {
+	timeout := viper.GetDuration(config.VIPER_GLOBALE_CMDTIMEOUT)
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		cmd.SetContext(ctx)
+		cancelCmdTimeout = cancel
+	}
+}
+
+// executeNested runs the command tree of a line in shell or a step in run,
+// the --cmdtimeout of it is released when it is done
+func executeNested(ctx context.Context, root *cobra.Command) (*cobra.Command, error) {
+	cancel := cancelCmdTimeout
+	cancelCmdTimeout = func() {}
+	cmd, err := root.ExecuteContextC(ctx)
+	cancelCmdTimeout()
+	cancelCmdTimeout = cancel
+	return cmd, err
+}
+
 func newCurveCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "curve fs|bs [OPTIONS] COMMAND [ARGS...]",
@@ -57,28 +119,78 @@ func newCurveCommand() *cobra.Command {
 			return fmt.Errorf("curve: '%s' is not a curve command.\n"+
 				"See 'curve --help'", args[0])
 		},
-		SilenceUsage: false, // silence usage when an error occurs
-		CompletionOptions: cobra.CompletionOptions{
-			HiddenDefaultCmd: true,
-		},
+		PersistentPreRunE: preRun,
+		SilenceUsage:      false, // silence usage when an error occurs
 	}
 
-	cmd.Flags().BoolP("version", "v", false, "Print curve version")
+	// -v is for --verbose
+	cmd.Flags().Bool("version", false, "Print curve version")
 	cmd.PersistentFlags().BoolP("help", "h", false, "Print usage")
 	cmd.PersistentFlags().StringVarP(&config.ConfPath, "conf", "c", "", "config file (default is $HOME/.curve/curve.yaml or /etc/curve/curve.yaml)")
+	config.AddContextPFlag(cmd)
 	config.AddShowErrorPFlag(cmd)
+	config.AddNoHeadersPFlag(cmd)
+	config.AddTablePFlags(cmd)
+	config.AddWatchPFlag(cmd)
+	config.AddCmdTimeoutPFlag(cmd)
+	config.AddRecordPFlags(cmd)
+	config.AddVerbosePFlag(cmd)
+	config.AddTraceFilePFlag(cmd)
 	viper.BindPFlag("useViper", cmd.PersistentFlags().Lookup("viper"))
 
 	addSubCommands(cmd)
+	addPlugins(cmd, FindPlugins(os.Getenv("PATH")))
 	setupRootCommand(cmd)
 
 	return cmd
 }
 
-func Execute() {
-	cobra.OnInitialize(config.InitConfig)
-	res := newCurveCommand().Execute()
-	if res != nil {
-		os.Exit(1)
+// the --conf and --context of the last InitConfig, nil before the first one
+var loadedConfig *[2]string
+
+// the commands in shell share the config, it is read again only when
+// a line gives another --conf or --context
+func initConfig() {
+	current := [2]string{config.ConfPath, config.ContextName}
+	if loadedConfig != nil && *loadedConfig == current {
+		return
+	}
+	config.InitConfig()
+	loadedConfig = &current
+}
+
+// Execute runs the command and returns the exit code of the process,
+// see cmderror.EXIT_SUCCESS for all the exit codes
+func Execute() int {
+	cobra.OnInitialize(initConfig)
+	// Ctrl-C or SIGTERM cancels the running command
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	cmd, res := newCurveCommand().ExecuteContextC(ctx)
+	code := exitCode(ctx, cmd.Context(), res)
+	cancelCmdTimeout()
+	stop()
+	basecmd.CloseConnPool()
+	return code
+}
+
+// the command may fail in other ways when it is interrupted or timeout,
+// so check the context first
+func exitCode(rootCtx context.Context, cmdCtx context.Context, err error) int {
+	var stopped *basecmd.WatchStoppedError
+	var pluginExited *exec.ExitError
+	switch {
+	case err == nil:
+		return cmderror.EXIT_SUCCESS
+	case errors.As(err, &pluginExited) && pluginExited.ExitCode() >= 0:
+		// the plugin exits by itself
+		return pluginExited.ExitCode()
+	case errors.As(err, &stopped):
+		// --watch exits with the last result
+		return cmderror.ExitCode(stopped.Err)
+	case rootCtx.Err() != nil:
+		return cmderror.EXIT_INTERRUPTED
+	case cmdCtx != nil && errors.Is(cmdCtx.Err(), context.DeadlineExceeded):
+		return cmderror.EXIT_TIMEOUT
 	}
+	return cmderror.ExitCode(err)
 }
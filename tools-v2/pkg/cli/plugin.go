@@ -0,0 +1,220 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	config "github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	PLUGIN_PREFIX = "curve-"
+	// the plugin reads the config in json from this fd
+	PLUGIN_CONFIG_FD     = 3
+	PLUGIN_CONFIG_FD_ENV = "CURVE_CONFIG_FD"
+)
+
+// the config passed to the plugins
+var pluginConfigKeys = []string{
+	config.VIPER_GLOBALE_HTTPTIMEOUT,
+	config.VIPER_GLOBALE_RPCTIMEOUT,
+	config.VIPER_GLOBALE_RPCRETRYTIMES,
+	config.VIPER_GLOBALE_CMDTIMEOUT,
+	config.VIPER_GLOBALE_VERBOSE,
+	config.VIPER_CURVEFS_MDSADDR,
+	config.VIPER_CURVEFS_MDSDUMMYADDR,
+	config.VIPER_CURVEFS_ETCDADDR,
+	config.VIPER_CURVEFS_METASERVERADDR,
+	config.VIPER_CURVEBS_MDSADDR,
+	config.VIPER_CURVEBS_MDSDUMMYADDR,
+}
+
+// Plugin is the executable curve-<group>-<name> on PATH, e.g. curve-fs-backup for curve fs backup
+type Plugin struct {
+	Group string
+	Name  string
+	Path  string
+}
+
+// FindPlugins returns the plugins in the dirs of path,
+// the one in the former dir is used if there are plugins with the same name
+func FindPlugins(path string) []Plugin {
+	var plugins []Plugin
+	found := make(map[string]bool)
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry.Name(), PLUGIN_PREFIX) {
+				continue
+			}
+			group, name, ok := strings.Cut(strings.TrimPrefix(entry.Name(), PLUGIN_PREFIX), "-")
+			if !ok || group == "" || name == "" || found[group+"-"+name] {
+				continue
+			}
+			file := filepath.Join(dir, entry.Name())
+			info, err := os.Stat(file)
+			if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+				continue
+			}
+			found[group+"-"+name] = true
+			plugins = append(plugins, Plugin{Group: group, Name: name, Path: file})
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Group+"-"+plugins[i].Name < plugins[j].Group+"-"+plugins[j].Name
+	})
+	return plugins
+}
+
+// addPlugins adds the plugins to the group commands of root,
+// the group is created if it does not exist, the builtin commands are not overridden
+func addPlugins(root *cobra.Command, plugins []Plugin) {
+	for _, plugin := range plugins {
+		group, _, err := root.Find([]string{plugin.Group})
+		if err != nil || group == root {
+			group = &cobra.Command{
+				Use:   plugin.Group,
+				Short: fmt.Sprintf("Manage %s by plugins", plugin.Group),
+			}
+			root.AddCommand(group)
+		} else if group.Runnable() {
+			// e.g. curve-shell-x, shell is not a group
+			continue
+		}
+		if sub, _, err := group.Find([]string{plugin.Name}); err == nil && sub != group {
+			continue
+		}
+		group.AddCommand(newPluginCommand(plugin))
+	}
+}
+
+func newPluginCommand(plugin Plugin) *cobra.Command {
+	return &cobra.Command{
+		Use:   plugin.Name,
+		Short: "plugin " + plugin.Path,
+		// all the args are passed to the plugin
+		DisableFlagParsing: true,
+		SilenceErrors:      true,
+		SilenceUsage:       true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// the flags are not parsed, load the config given by --conf
+			// and --context for the plugin
+			confPath := pluginFlagValue(args, "conf", "c")
+			contextName := pluginFlagValue(args, config.CONTEXT, "")
+			if confPath != "" || contextName != "" {
+				if confPath != "" {
+					config.ConfPath = confPath
+				}
+				if contextName != "" {
+					config.ContextName = contextName
+				}
+				initConfig()
+				if err := config.ContextError(); err != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), "Error:", err)
+					return err
+				}
+			}
+			err := runPlugin(cmd, plugin, args)
+			if _, ok := err.(*exec.ExitError); err != nil && !ok {
+				fmt.Fprintln(cmd.ErrOrStderr(), "Error:", err)
+			}
+			return err
+		},
+	}
+}
+
+// the value of the flag in args, e.g. -c or --conf
+func pluginFlagValue(args []string, name string, shorthand string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if strings.HasPrefix(arg, "--"+name+"=") {
+			return strings.TrimPrefix(arg, "--"+name+"=")
+		}
+		isFlag := arg == "--"+name || (shorthand != "" && arg == "-"+shorthand)
+		if isFlag && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// runPlugin runs the plugin with the config in the environment variables,
+// e.g. CURVE_CURVEFS_MDSADDR, and in json from fd 3
+func runPlugin(cmd *cobra.Command, plugin Plugin, args []string) error {
+	configReader, configWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer configReader.Close()
+	data, err := json.Marshal(pluginConfig())
+	if err != nil {
+		configWriter.Close()
+		return err
+	}
+	// the config is small enough for the buffer of pipe
+	_, err = configWriter.Write(data)
+	configWriter.Close()
+	if err != nil {
+		return err
+	}
+
+	pluginCmd := exec.CommandContext(cmd.Context(), plugin.Path, args...)
+	pluginCmd.Stdin = cmd.InOrStdin()
+	pluginCmd.Stdout = cmd.OutOrStdout()
+	pluginCmd.Stderr = cmd.ErrOrStderr()
+	pluginCmd.ExtraFiles = []*os.File{configReader}
+	pluginCmd.Env = append(os.Environ(), fmt.Sprintf("%s=%d", PLUGIN_CONFIG_FD_ENV, PLUGIN_CONFIG_FD))
+	for _, key := range pluginConfigKeys {
+		pluginCmd.Env = append(pluginCmd.Env, config.EnvName(key)+"="+viper.GetString(key))
+	}
+	return pluginCmd.Run()
+}
+
+// pluginConfig is like the config file, e.g. {"curvefs": {"mdsAddr": "..."}}
+func pluginConfig() map[string]interface{} {
+	ret := make(map[string]interface{})
+	for _, key := range pluginConfigKeys {
+		section, name, _ := strings.Cut(key, ".")
+		if ret[section] == nil {
+			ret[section] = make(map[string]string)
+		}
+		ret[section].(map[string]string)[name] = viper.GetString(key)
+	}
+	return ret
+}
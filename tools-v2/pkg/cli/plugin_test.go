@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package cli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func writePlugin(dir string, name string, script string, perm os.FileMode) {
+	So(os.WriteFile(filepath.Join(dir, name), []byte(script), perm), ShouldBeNil)
+}
+
+func TestPlugin(t *testing.T) {
+	Convey("find the plugins on PATH", t, func() {
+		dir1, dir2 := t.TempDir(), t.TempDir()
+		writePlugin(dir1, "curve-fs-backup", "#!/bin/sh\n", 0755)
+		writePlugin(dir1, "curve-fs-not-executable", "#!/bin/sh\n", 0644)
+		writePlugin(dir1, "curve-fs", "#!/bin/sh\n", 0755)
+		writePlugin(dir2, "curve-fs-backup", "#!/bin/sh\n", 0755)
+		writePlugin(dir2, "curve-bs-check-disk", "#!/bin/sh\n", 0755)
+
+		plugins := FindPlugins(dir1 + string(os.PathListSeparator) + dir2)
+		So(plugins, ShouldResemble, []Plugin{
+			{Group: "bs", Name: "check-disk", Path: filepath.Join(dir2, "curve-bs-check-disk")},
+			{Group: "fs", Name: "backup", Path: filepath.Join(dir1, "curve-fs-backup")},
+		})
+
+		root := &cobra.Command{Use: "curve"}
+		fs := &cobra.Command{Use: "fs"}
+		fs.AddCommand(&cobra.Command{Use: "backup", Run: func(*cobra.Command, []string) {}})
+		root.AddCommand(fs)
+		addPlugins(root, plugins)
+		bs, _, err := root.Find([]string{"bs", "check-disk"})
+		So(err, ShouldBeNil)
+		So(bs.Short, ShouldEqual, "plugin "+filepath.Join(dir2, "curve-bs-check-disk"))
+		backup, _, _ := root.Find([]string{"fs", "backup"})
+		So(backup.DisableFlagParsing, ShouldBeFalse)
+	})
+
+	Convey("run the plugin with the config", t, func() {
+		dir := t.TempDir()
+		script := "#!/bin/sh\necho \"$CURVE_CURVEFS_MDSADDR $*\"\ncat <&3\nexit 3\n"
+		writePlugin(dir, "curve-fs-hello", script, 0755)
+		viper.Set(config.VIPER_CURVEFS_MDSADDR, "127.0.0.1:6700")
+		defer viper.Set(config.VIPER_CURVEFS_MDSADDR, nil)
+
+		root := &cobra.Command{Use: "curve"}
+		addPlugins(root, FindPlugins(dir))
+		out := &bytes.Buffer{}
+		root.SetOut(out)
+		root.SetArgs([]string{"fs", "hello", "--fsid", "1"})
+		err := root.Execute()
+		exitErr, ok := err.(*exec.ExitError)
+		So(ok, ShouldBeTrue)
+		So(exitErr.ExitCode(), ShouldEqual, 3)
+		So(out.String(), ShouldStartWith, "127.0.0.1:6700 --fsid 1\n{")
+		So(out.String(), ShouldContainSubstring, `"mdsAddr":"127.0.0.1:6700"`)
+	})
+
+	Convey("the conf path and context in args", t, func() {
+		So(pluginFlagValue([]string{"--fsid", "1", "-c", "a.yaml"}, "conf", "c"), ShouldEqual, "a.yaml")
+		So(pluginFlagValue([]string{"--conf=b.yaml"}, "conf", "c"), ShouldEqual, "b.yaml")
+		So(pluginFlagValue([]string{"--", "-c", "a.yaml"}, "conf", "c"), ShouldEqual, "")
+		So(pluginFlagValue([]string{"--context", "prod"}, "context", ""), ShouldEqual, "prod")
+		So(pluginFlagValue([]string{"-c", "prod"}, "context", ""), ShouldEqual, "")
+	})
+}
@@ -0,0 +1,82 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/testing/fakecluster"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/viper"
+)
+
+func runCurve(args ...string) (string, error) {
+	cmd := newCurveCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	Convey("the commands are replayed without the cluster", t, func() {
+		t.Setenv("HOME", t.TempDir())
+		defer basecmd.OpenRecording("", "")
+		fixture, err := fakecluster.LoadFixture("../../testing/fakecluster/testdata/cluster.yaml")
+		So(err, ShouldBeNil)
+		cluster, err := fakecluster.Start(fixture)
+		So(err, ShouldBeNil)
+		So(viper.MergeConfigMap(cluster.Config()), ShouldBeNil)
+
+		dir := t.TempDir()
+		commands := [][]string{
+			{"fs", "status", "cluster", "--format", "json"},
+			{"fs", "check", "copyset", "--copysetid", "1,2", "--poolid", "1,1", "--format", "json"},
+		}
+		var recorded []string
+		for _, args := range commands {
+			out, err := runCurve(append(args, "--record", dir)...)
+			So(err, ShouldBeNil)
+			So(out, ShouldContainSubstring, `"exitCode": 0`)
+			recorded = append(recorded, out)
+		}
+		cluster.Stop()
+		files, err := os.ReadDir(dir)
+		So(err, ShouldBeNil)
+		So(len(files), ShouldBeGreaterThan, len(commands))
+
+		for i, args := range commands {
+			out, err := runCurve(append(args, "--replay", dir)...)
+			So(err, ShouldBeNil)
+			So(out, ShouldEqual, recorded[i])
+		}
+
+		_, err = runCurve("fs", "status", "mds", "--record", dir, "--replay", dir)
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,306 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/liushuochen/gotable"
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/pkg/output"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	runExample = `$ cat steps.yaml
+steps:
+  - name: cluster is healthy
+    command: fs status cluster
+  - name: fs1 exists
+    command: fs list fs
+    expect:
+      jsonPath: '{.result.fsInfo[?(@.fsName=="fs1")].status}'
+      equals: INITED
+  - command: fs query fs --fsname nofs
+    expect:
+      exitCode: 5
+$ curve run -f steps.yaml --format json`
+)
+
+// RunFile is the steps file of run
+type RunFile struct {
+	Steps []RunStep `yaml:"steps"`
+}
+
+// RunStep is a command line without "curve" and what it should get,
+// it passes if it exits with 0 when nothing is expected
+type RunStep struct {
+	Name    string    `yaml:"name"`
+	Command string    `yaml:"command"`
+	Expect  RunExpect `yaml:"expect"`
+}
+
+// RunExpect is the exit code of the step and the value of jsonpath
+// on its json output, jsonpath should match something if equals is not set
+type RunExpect struct {
+	ExitCode *int    `yaml:"exitCode"`
+	JsonPath string  `yaml:"jsonPath"`
+	Equals   *string `yaml:"equals"`
+}
+
+type RunStepResult struct {
+	Name     string `json:"name"`
+	Command  string `json:"command"`
+	ExitCode int    `json:"exitCode"`
+	Passed   bool   `json:"passed"`
+	Message  string `json:"message,omitempty"`
+	Duration string `json:"duration"`
+	// the json output of the step
+	Output interface{} `json:"output,omitempty"`
+}
+
+type RunReport struct {
+	Total  int             `json:"total"`
+	Passed int             `json:"passed"`
+	Failed int             `json:"failed"`
+	Steps  []RunStepResult `json:"steps"`
+}
+
+type RunCommand struct {
+	basecmd.FinalCurveCmd
+	newRoot func() *cobra.Command
+	steps   []RunStep
+	args    [][]string
+	report  *RunReport
+}
+
+var _ basecmd.FinalCurveCmdFunc = (*RunCommand)(nil) // check interface
+
+func newRunCommand(newRoot func() *cobra.Command) *cobra.Command {
+	runCmd := &RunCommand{
+		FinalCurveCmd: basecmd.FinalCurveCmd{
+			Use:     "run",
+			Short:   "run the steps in the file one by one and report the result of each step",
+			Example: runExample,
+		},
+		newRoot: newRoot,
+	}
+	basecmd.NewFinalCurveCli(&runCmd.FinalCurveCmd, runCmd)
+	return runCmd.Cmd
+}
+
+func (rCmd *RunCommand) AddFlags() {
+	config.AddRunFileFlag(rCmd.Cmd)
+	config.AddFormatFlagWithoutShorthand(rCmd.Cmd)
+}
+
+func (rCmd *RunCommand) Init(cmd *cobra.Command, args []string) error {
+	file, _ := rCmd.Cmd.Flags().GetString(config.RUN_FILE)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var runFile RunFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	// the misspelled keys are not ignored
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&runFile); err != nil && err != io.EOF {
+		return fmt.Errorf("parse %s failed, the error is: %s", file, err)
+	}
+	if len(runFile.Steps) == 0 {
+		return fmt.Errorf("no steps in %s", file)
+	}
+	rCmd.steps = runFile.Steps
+	rCmd.args = make([][]string, len(rCmd.steps))
+	for i, step := range rCmd.steps {
+		stepArgs, err := SplitArgs(step.Command)
+		if err != nil {
+			return fmt.Errorf("step %d: %s", i+1, err)
+		}
+		if len(stepArgs) == 0 {
+			return fmt.Errorf("step %d: command is empty", i+1)
+		}
+		if stepArgs[0] == "shell" {
+			return fmt.Errorf("step %d: shell can not run in steps", i+1)
+		}
+		if rCmd.steps[i].Name == "" {
+			rCmd.steps[i].Name = step.Command
+		}
+		rCmd.args[i] = stepArgs
+	}
+
+	table, err := gotable.Create("step", "command", "exitCode", "result", "message")
+	if err != nil {
+		return err
+	}
+	rCmd.Table = table
+	return nil
+}
+
+func (rCmd *RunCommand) Print(cmd *cobra.Command, args []string) error {
+	return output.FinalCmdOutput(&rCmd.FinalCurveCmd, rCmd)
+}
+
+func (rCmd *RunCommand) RunCommand(cmd *cobra.Command, args []string) error {
+	ctx := rCmd.Cmd.Context()
+	rCmd.report = &RunReport{Total: len(rCmd.steps)}
+	var failed []string
+	for i, step := range rCmd.steps {
+		var result RunStepResult
+		if ctx.Err() != nil {
+			result = RunStepResult{
+				Name:    step.Name,
+				Command: step.Command,
+				Message: fmt.Sprintf("skipped: %s", ctx.Err()),
+			}
+		} else {
+			result = rCmd.runStep(ctx, step, rCmd.args[i])
+		}
+		if result.Passed {
+			rCmd.report.Passed++
+		} else {
+			rCmd.report.Failed++
+			failed = append(failed, step.Name)
+		}
+		rCmd.report.Steps = append(rCmd.report.Steps, result)
+		rCmd.Table.AddRow(map[string]string{
+			"step":     fmt.Sprintf("%d", i+1),
+			"command":  step.Command,
+			"exitCode": fmt.Sprintf("%d", result.ExitCode),
+			"result":   passedString(result.Passed),
+			"message":  strings.ReplaceAll(result.Message, "\n", " "),
+		})
+	}
+	rCmd.Result = rCmd.report
+	if len(failed) > 0 {
+		rCmd.Error = cmderror.ErrRunSteps()
+		rCmd.Error.Format(len(failed), len(rCmd.steps), strings.Join(failed, ", "))
+	} else {
+		rCmd.Error = cmderror.ErrSuccess()
+	}
+	return nil
+}
+
+// runStep runs the step in a new command tree, with json format if the command has the flag,
+// the format given in the command line is overridden
+func (rCmd *RunCommand) runStep(ctx context.Context, step RunStep, args []string) RunStepResult {
+	result := RunStepResult{
+		Name:    step.Name,
+		Command: step.Command,
+	}
+	root := rCmd.newRoot()
+	var stdout bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetErr(io.Discard)
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	if cmd, _, err := root.Find(args); err == nil && cmd.Flags().Lookup(config.FORMAT) != nil {
+		args = append(args, "--"+config.FORMAT, config.FORMAT_JSON)
+	}
+	root.SetArgs(args)
+
+	start := time.Now()
+	cmd, err := executeNested(ctx, root)
+	result.Duration = time.Since(start).Round(time.Millisecond).String()
+	var cmdCtx context.Context
+	if cmd != nil {
+		cmdCtx = cmd.Context()
+	}
+	result.ExitCode = exitCode(ctx, cmdCtx, err)
+
+	var document interface{}
+	if stdout.Len() > 0 {
+		decoder := json.NewDecoder(&stdout)
+		decoder.UseNumber()
+		if decoder.Decode(&document) == nil {
+			result.Output = document
+		} else {
+			result.Output = stdout.String()
+			document = nil
+		}
+	}
+
+	expected := cmderror.EXIT_SUCCESS
+	if step.Expect.ExitCode != nil {
+		expected = *step.Expect.ExitCode
+	}
+	if result.ExitCode != expected {
+		result.Message = fmt.Sprintf("exit code is %d, expected %d", result.ExitCode, expected)
+		if err != nil {
+			result.Message += ", the error is: " + err.Error()
+		}
+		return result
+	}
+	if step.Expect.JsonPath != "" {
+		message := checkJsonPath(document, step.Expect)
+		if message != "" {
+			result.Message = message
+			return result
+		}
+	}
+	result.Passed = true
+	return result
+}
+
+// checkJsonPath returns why document does not match expect, or "" if it matches
+func checkJsonPath(document interface{}, expect RunExpect) string {
+	if document == nil {
+		return "no json output to check " + expect.JsonPath
+	}
+	jsonPath, err := output.ParseJsonPath(expect.JsonPath)
+	if err != nil {
+		return err.Error()
+	}
+	var buf bytes.Buffer
+	if err := jsonPath.Execute(&buf, document); err != nil {
+		return err.Error()
+	}
+	value := buf.String()
+	switch {
+	case expect.Equals == nil && value == "":
+		return fmt.Sprintf("%s matches nothing", expect.JsonPath)
+	case expect.Equals != nil && value != *expect.Equals:
+		return fmt.Sprintf("%s is %q, expected %q", expect.JsonPath, value, *expect.Equals)
+	}
+	return ""
+}
+
+func passedString(passed bool) string {
+	if passed {
+		return "pass"
+	}
+	return "fail"
+}
+
+func (rCmd *RunCommand) ResultPlainOutput() error {
+	return output.FinalCmdOutputPlain(&rCmd.FinalCurveCmd, rCmd)
+}
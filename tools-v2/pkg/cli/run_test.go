@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/cobra"
+)
+
+// root -> fs -> get prints the json like FinalCurveCmd, "get missing" is not found
+func newRunTestRoot() *cobra.Command {
+	root := &cobra.Command{Use: "curve"}
+	fs := &cobra.Command{Use: "fs"}
+	get := &cobra.Command{
+		Use: "get",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString(config.FORMAT)
+			if format != config.FORMAT_JSON {
+				return fmt.Errorf("format is %s", format)
+			}
+			if len(args) > 0 && args[0] == "missing" {
+				return cmderror.ErrCopysetKey().ToError()
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), `{"result": {"fsName": "fs1", "fsId": 1}, "exitCode": 0}`)
+			return nil
+		},
+	}
+	config.AddFormatFlag(get)
+	fs.AddCommand(get)
+	root.AddCommand(fs)
+	return root
+}
+
+func TestRunCommand(t *testing.T) {
+	Convey("run the steps and report each of them", t, func() {
+		file := filepath.Join(t.TempDir(), "steps.yaml")
+		steps := `steps:
+  - command: fs get
+  - name: fsName
+    command: fs get
+    expect:
+      jsonPath: '{.result.fsName}'
+      equals: fs1
+  - command: fs get missing
+    expect:
+      exitCode: 5
+  - command: fs get
+    expect:
+      jsonPath: '{.result.owner}'
+  - command: fs get missing
+`
+		So(os.WriteFile(file, []byte(steps), 0644), ShouldBeNil)
+
+		runCmd := newRunCommand(newRunTestRoot)
+		out := &bytes.Buffer{}
+		runCmd.SetOut(out)
+		runCmd.SetErr(&bytes.Buffer{})
+		runCmd.SetArgs([]string{"-f", file, "--format", "json"})
+		err := runCmd.Execute()
+		So(err, ShouldNotBeNil)
+		So(cmderror.ExitCode(err), ShouldEqual, cmderror.EXIT_FAILURE)
+
+		var document struct {
+			Result RunReport `json:"result"`
+		}
+		So(json.Unmarshal(out.Bytes(), &document), ShouldBeNil)
+		report := document.Result
+		So(report.Total, ShouldEqual, 5)
+		So(report.Passed, ShouldEqual, 3)
+		So(report.Failed, ShouldEqual, 2)
+		So(report.Steps[0].Name, ShouldEqual, "fs get")
+		So(report.Steps[1].Name, ShouldEqual, "fsName")
+		So(report.Steps[2].ExitCode, ShouldEqual, cmderror.EXIT_NOT_FOUND)
+		So(report.Steps[2].Passed, ShouldBeTrue)
+		So(report.Steps[3].Message, ShouldEqual, "{.result.owner} matches nothing")
+		So(report.Steps[4].Passed, ShouldBeFalse)
+		So(report.Steps[4].Message, ShouldStartWith, "exit code is 5, expected 0")
+	})
+
+	Convey("the steps file is checked", t, func() {
+		file := filepath.Join(t.TempDir(), "steps.yaml")
+		So(os.WriteFile(file, []byte("steps:\n  - comand: fs get\n"), 0644), ShouldBeNil)
+		runCmd := newRunCommand(newRunTestRoot)
+		runCmd.SetOut(&bytes.Buffer{})
+		runCmd.SetErr(&bytes.Buffer{})
+		runCmd.SetArgs([]string{"-f", file})
+		So(runCmd.Execute(), ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,364 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	cobrautil "github.com/opencurve/curve/tools-v2/internal/utils"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+const (
+	SHELL_PROMPT = "curve> "
+	SHELL_HELP   = `curve shell runs the curve commands without "curve", e.g. "fs list fs".
+The config, rpc connections and mds leader are shared by the commands,
+the --conf and --context of curve shell are the session variables conf and context.
+Built-in commands:
+  set [NAME VALUE]  set the session variable, which is the default value of flag --NAME
+  unset NAME        unset the session variable
+  help              show this help, "COMMAND --help" shows the help of the command
+  exit, quit        exit the shell
+`
+)
+
+// Shell runs the curve command lines one by one in the same process
+type Shell struct {
+	// session variables, they fill the flags with the same name
+	vars    map[string]string
+	newRoot func() *cobra.Command
+	out     io.Writer
+}
+
+func NewShell(newRoot func() *cobra.Command, out io.Writer) *Shell {
+	return &Shell{
+		vars:    make(map[string]string),
+		newRoot: newRoot,
+		out:     out,
+	}
+}
+
+func newShellCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "shell",
+		Short: "run curve commands interactively, sharing the config, connections and mds leader",
+		Args:  cobrautil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			shell := NewShell(newCurveCommand, cmd.OutOrStdout())
+			// every line creates the flags again, so they are kept as session variables
+			if config.ConfPath != "" {
+				shell.vars["conf"] = config.ConfPath
+			}
+			if config.ContextName != "" {
+				shell.vars[config.CONTEXT] = config.ContextName
+			}
+			if term.IsTerminal(int(os.Stdin.Fd())) {
+				return shell.RunTerminal(os.Stdin, int(os.Stdin.Fd()))
+			}
+			return shell.Run(os.Stdin)
+		},
+	}
+}
+
+// RunTerminal reads the lines from the terminal with history and tab completion
+func (s *Shell) RunTerminal(stdin io.ReadWriter, fd int) error {
+	terminal := term.NewTerminal(stdin, SHELL_PROMPT)
+	terminal.AutoCompleteCallback = s.autoComplete
+	for {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		line, err := terminal.ReadLine()
+		term.Restore(fd, state)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if s.Execute(line) {
+			return nil
+		}
+	}
+}
+
+// Run reads the lines from r, e.g. a pipe
+func (s *Shell) Run(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if s.Execute(scanner.Text()) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// Execute runs one line, return true if the shell should exit
+func (s *Shell) Execute(line string) bool {
+	args, err := SplitArgs(line)
+	if err != nil {
+		fmt.Fprintln(s.out, "Error:", err)
+		return false
+	}
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "exit", "quit":
+		return true
+	case "help":
+		fmt.Fprint(s.out, SHELL_HELP)
+	case "set":
+		s.set(args[1:])
+	case "unset":
+		for _, name := range args[1:] {
+			delete(s.vars, name)
+		}
+	case "shell":
+		fmt.Fprintln(s.out, "Error: already in curve shell")
+	default:
+		s.run(args)
+	}
+	return false
+}
+
+func (s *Shell) set(args []string) {
+	switch len(args) {
+	case 0:
+		names := make([]string, 0, len(s.vars))
+		for name := range s.vars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(s.out, "%s=%s\n", name, s.vars[name])
+		}
+	case 2:
+		s.vars[strings.TrimPrefix(args[0], "--")] = args[1]
+	default:
+		fmt.Fprintln(s.out, "Error: usage: set NAME VALUE")
+	}
+}
+
+// run the command in a new command tree, the flags and results of the last one are not kept
+func (s *Shell) run(args []string) {
+	root := s.newRoot()
+	root.SetArgs(args)
+	if cmd, flagArgs, err := root.Find(args); err == nil {
+		s.fillFlags(cmd, flagArgs)
+	}
+	// Ctrl-C only cancels the running command
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	executeNested(ctx, root)
+	stop()
+}
+
+// the flags given in the command line override the session variables,
+// they are not filled, otherwise the slice flags append the values to them
+func (s *Shell) fillFlags(cmd *cobra.Command, args []string) {
+	given := givenFlags(cmd, args)
+	for name, value := range s.vars {
+		// the flag of cmd or the persistent flag of its parents
+		if flag := cmd.Flag(name); flag != nil && !given[flag.Name] {
+			if err := flag.Value.Set(value); err != nil {
+				fmt.Fprintf(s.out, "Error: invalid session variable %s=%s: %s\n", name, value, err)
+				continue
+			}
+			flag.Changed = true
+		}
+	}
+}
+
+// givenFlags returns the names of the flags in args, e.g. --fsid=1, --fsid 1 and -c file
+func givenFlags(cmd *cobra.Command, args []string) map[string]bool {
+	given := make(map[string]bool)
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if strings.HasPrefix(arg, "--") {
+			name := strings.SplitN(arg[2:], "=", 2)[0]
+			given[name] = true
+		} else if strings.HasPrefix(arg, "-") && len(arg) > 1 {
+			// the shorthands may be combined, e.g. -vc file
+			shorthands := strings.SplitN(arg[1:], "=", 2)[0]
+			for i := 0; i < len(shorthands); i++ {
+				flag := cmd.Flags().ShorthandLookup(shorthands[i : i+1])
+				if flag == nil {
+					flag = cmd.InheritedFlags().ShorthandLookup(shorthands[i : i+1])
+				}
+				if flag == nil {
+					break
+				}
+				given[flag.Name] = true
+				if flag.NoOptDefVal == "" {
+					// the rest is the value
+					break
+				}
+			}
+		}
+	}
+	return given
+}
+
+func (s *Shell) autoComplete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+	prefix := line[:pos]
+	words := strings.Fields(prefix)
+	partial := ""
+	if len(words) > 0 && !strings.HasSuffix(prefix, " ") {
+		partial = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+	candidates := s.Complete(words, partial)
+	if len(candidates) == 0 {
+		return "", 0, false
+	}
+	completion := candidates[0]
+	for _, candidate := range candidates[1:] {
+		for !strings.HasPrefix(candidate, completion) {
+			completion = completion[:len(completion)-1]
+		}
+	}
+	if len(candidates) == 1 {
+		completion += " "
+	}
+	if len(completion) <= len(partial) {
+		return "", 0, false
+	}
+	newPrefix := prefix[:len(prefix)-len(partial)] + completion
+	return newPrefix + line[pos:], len(newPrefix), true
+}
+
+// Complete returns the subcommands or flags after words, which start with partial
+func (s *Shell) Complete(words []string, partial string) []string {
+	var names []string
+	root := s.newRoot()
+	if len(words) > 0 && words[0] == "set" {
+		// set NAME, the flags of all commands
+		if len(words) == 1 {
+			names = flagNames(root)
+		}
+	} else if cmd, _, err := root.Find(words); err != nil {
+		return nil
+	} else if strings.HasPrefix(partial, "-") {
+		cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			if !flag.Hidden {
+				names = append(names, "--"+flag.Name)
+			}
+		})
+		cmd.InheritedFlags().VisitAll(func(flag *pflag.Flag) {
+			if !flag.Hidden {
+				names = append(names, "--"+flag.Name)
+			}
+		})
+	} else {
+		for _, sub := range cmd.Commands() {
+			if sub.IsAvailableCommand() {
+				names = append(names, sub.Name())
+			}
+		}
+		if cmd == root {
+			names = append(names, "set", "unset", "help", "exit", "quit")
+		}
+	}
+	var ret []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if strings.HasPrefix(name, partial) && !seen[name] {
+			seen[name] = true
+			ret = append(ret, name)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// the names of flags of cmd and its subcommands
+func flagNames(cmd *cobra.Command) []string {
+	var names []string
+	cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+		if !flag.Hidden {
+			names = append(names, flag.Name)
+		}
+	})
+	for _, sub := range cmd.Commands() {
+		names = append(names, flagNames(sub)...)
+	}
+	return names
+}
+
+// SplitArgs splits the line into args like shell, the quotes and backslash are supported
+func SplitArgs(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+	for _, c := range line {
+		switch {
+		case escaped:
+			current.WriteRune(c)
+			escaped = false
+		case c == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				current.WriteRune(c)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+			inArg = true
+		case c == ' ' || c == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(c)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unexpected end of line: %s", line)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,130 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/cobra"
+)
+
+// root -> fs -> list, list records the flags it runs with
+func newTestRoot(got *[]string) func() *cobra.Command {
+	return func() *cobra.Command {
+		root := &cobra.Command{Use: "curve"}
+		root.PersistentFlags().StringP("mdsaddr", "m", "", "")
+		fs := &cobra.Command{Use: "fs"}
+		list := &cobra.Command{
+			Use: "list",
+			Run: func(cmd *cobra.Command, args []string) {
+				addr, _ := cmd.Flags().GetString("mdsaddr")
+				*got = append(*got, strings.Join([]string{addr, cmd.Flag("fsid").Value.String()}, " "))
+			},
+		}
+		// like config.AddFsIdSliceOptionFlag, the slice appends the values set again
+		list.Flags().StringSlice("fsid", nil, "")
+		fs.AddCommand(list, &cobra.Command{Use: "delete", Run: func(*cobra.Command, []string) {}})
+		root.AddCommand(fs)
+		return root
+	}
+}
+
+func TestShell(t *testing.T) {
+	Convey("split args", t, func() {
+		args, err := SplitArgs(`fs  list --fsname "a b" 'c\d' e\ f`)
+		So(err, ShouldBeNil)
+		So(args, ShouldResemble, []string{"fs", "list", "--fsname", "a b", `c\d`, "e f"})
+		_, err = SplitArgs(`fs "list`)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("session variables fill the flags", t, func() {
+		var got []string
+		out := &bytes.Buffer{}
+		shell := NewShell(newTestRoot(&got), out)
+		So(shell.Execute("set fsid 3"), ShouldBeFalse)
+		So(shell.Execute("set --mdsaddr 127.0.0.1:6700"), ShouldBeFalse)
+		shell.Execute("fs list")
+		// the values in command line replace the session variables
+		shell.Execute("fs list --fsid 4")
+		shell.Execute("fs list --fsid=5,6 -m 127.0.0.1:6701")
+		shell.Execute("unset fsid")
+		shell.Execute("fs list")
+		So(got, ShouldResemble, []string{
+			"127.0.0.1:6700 [3]",
+			"127.0.0.1:6700 [4]",
+			"127.0.0.1:6701 [5,6]",
+			"127.0.0.1:6700 []",
+		})
+		shell.Execute("set")
+		So(out.String(), ShouldEqual, "mdsaddr=127.0.0.1:6700\n")
+		So(shell.Execute("exit"), ShouldBeTrue)
+	})
+
+	Convey("complete commands and flags", t, func() {
+		shell := NewShell(newTestRoot(nil), &bytes.Buffer{})
+		So(shell.Complete(nil, "s"), ShouldResemble, []string{"set"})
+		So(shell.Complete([]string{"fs"}, ""), ShouldResemble, []string{"delete", "list"})
+		So(shell.Complete([]string{"fs", "list"}, "--"), ShouldResemble, []string{"--fsid", "--mdsaddr"})
+		So(shell.Complete([]string{"set"}, "md"), ShouldResemble, []string{"mdsaddr"})
+		So(shell.Complete([]string{"set"}, "fs"), ShouldResemble, []string{"fsid"})
+
+		line, pos, ok := shell.autoComplete("fs li", 5, '\t')
+		So(ok, ShouldBeTrue)
+		So(line, ShouldEqual, "fs list ")
+		So(pos, ShouldEqual, 8)
+	})
+
+	Convey("the lines giving another --conf or --context read the config again", t, func() {
+		t.Setenv("HOME", t.TempDir())
+		path := filepath.Join(t.TempDir(), "curve.yaml")
+		contexts := "currentContext: prod\ncontexts:\n  prod: {}\n  staging: {}\n"
+		So(os.WriteFile(path, []byte(contexts), 0644), ShouldBeNil)
+		cobra.OnInitialize(initConfig)
+		// the values of the config file are not kept for the other tests
+		defer func() {
+			config.ConfPath, config.ContextName = "", ""
+			initConfig()
+		}()
+
+		out := &bytes.Buffer{}
+		shell := NewShell(func() *cobra.Command {
+			root := newCurveCommand()
+			root.SetOut(out)
+			return root
+		}, out)
+		shell.Execute("config current-context --conf " + path)
+		shell.Execute("config current-context --conf " + path + " --context staging")
+		shell.Execute("config current-context --conf " + path)
+		shell.Execute("config current-context")
+		shell.Execute("set conf " + path)
+		shell.Execute("set context staging")
+		shell.Execute("config current-context")
+		So(out.String(), ShouldEqual, "prod\nstaging\nprod\nstaging\n")
+	})
+}
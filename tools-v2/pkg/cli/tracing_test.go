@@ -0,0 +1,133 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/testing/fakecluster"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/viper"
+)
+
+type traceSpan struct {
+	TraceId      string `json:"traceId"`
+	SpanId       string `json:"spanId"`
+	ParentSpanId string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Kind         int    `json:"kind"`
+}
+
+func readTraceSpans(path string) ([]traceSpan, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	// a line for every trace
+	var spans []traceSpan
+	decoder := json.NewDecoder(file)
+	for decoder.More() {
+		var data struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []traceSpan `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		if err := decoder.Decode(&data); err != nil {
+			return nil, err
+		}
+		for _, resourceSpans := range data.ResourceSpans {
+			for _, scopeSpans := range resourceSpans.ScopeSpans {
+				spans = append(spans, scopeSpans.Spans...)
+			}
+		}
+	}
+	return spans, nil
+}
+
+func TestTraceFile(t *testing.T) {
+	Convey("the sub-commands, rpc and http of status cluster are traced", t, func() {
+		t.Setenv("HOME", t.TempDir())
+		defer basecmd.OpenTrace("")
+		fixture, err := fakecluster.LoadFixture("../../testing/fakecluster/testdata/cluster.yaml")
+		So(err, ShouldBeNil)
+		cluster, err := fakecluster.Start(fixture)
+		So(err, ShouldBeNil)
+		defer cluster.Stop()
+		So(viper.MergeConfigMap(cluster.Config()), ShouldBeNil)
+
+		path := filepath.Join(t.TempDir(), "trace.json")
+		_, err = runCurve("fs", "status", "cluster", "--trace-file", path)
+		So(err, ShouldBeNil)
+		spans, err := readTraceSpans(path)
+		So(err, ShouldBeNil)
+
+		byId := make(map[string]traceSpan)
+		var root traceSpan
+		for _, span := range spans {
+			byId[span.SpanId] = span
+			if span.ParentSpanId == "" {
+				root = span
+			}
+		}
+		So(root.Name, ShouldEqual, "curve fs status cluster")
+		children := make(map[string]bool)
+		var rpcNum, httpNum int
+		for _, span := range spans {
+			So(span.TraceId, ShouldEqual, root.TraceId)
+			if span.SpanId == root.SpanId {
+				continue
+			}
+			So(byId, ShouldContainKey, span.ParentSpanId)
+			if span.ParentSpanId == root.SpanId {
+				children[span.Name] = true
+			}
+			switch {
+			case strings.HasPrefix(span.Name, "curvefs."):
+				rpcNum++
+				So(byId[span.ParentSpanId].Name, ShouldStartWith, "GetRpcResponse ")
+			case span.Name == "HTTP GET":
+				httpNum++
+				So(byId[span.ParentSpanId].Name, ShouldStartWith, "QueryMetric ")
+			}
+		}
+		So(children, ShouldResemble, map[string]bool{"etcd": true, "mds": true, "metaserver": true, "copyset": true})
+		So(rpcNum, ShouldBeGreaterThan, 0)
+		So(httpNum, ShouldBeGreaterThan, 0)
+
+		// the next command is another trace in the same file
+		_, err = runCurve("fs", "list", "fs", "--trace-file", path)
+		So(err, ShouldBeNil)
+		spansNext, err := readTraceSpans(path)
+		So(err, ShouldBeNil)
+		So(len(spansNext), ShouldBeGreaterThan, len(spans))
+		So(spansNext[len(spansNext)-1].Name, ShouldEqual, "curve fs list fs")
+		So(spansNext[len(spansNext)-1].TraceId, ShouldNotEqual, root.TraceId)
+	})
+}
@@ -0,0 +1,215 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+// Package client is the go api of curvefs used by the curve commands,
+// it does not depend on the flags of commands, so it can be embedded in other services.
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/transport"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/common"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/mds"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/topology"
+	"google.golang.org/grpc"
+)
+
+// the same as the defaults of curve commands
+const (
+	DEFAULT_RPC_TIMEOUT     = 10000 * time.Millisecond
+	DEFAULT_RPC_RETRY_TIMES = int32(1)
+	DEFAULT_HTTP_TIMEOUT    = 500 * time.Millisecond
+)
+
+// Options is how the client connects to the curvefs cluster
+type Options struct {
+	// e.g. 127.0.0.1:6700
+	MdsAddrs []string
+	// MdsDummyAddrs[i] is the dummy address of MdsAddrs[i],
+	// the rpc to mds is only sent to the leader if they are set
+	MdsDummyAddrs []string
+	// the addresses of etcd, only used to get the status of etcd
+	EtcdAddrs     []string
+	RpcTimeout    time.Duration
+	RpcRetryTimes int32
+	// the timeout to get the mds leader from the dummy addresses
+	HttpTimeout time.Duration
+	// the file which keeps the mds leader, so that other processes can use it,
+	// "" means the leader is only kept in the process
+	MdsLeaderCacheFile string
+}
+
+// Client calls curvefs by the rpc of curve commands,
+// all the rpc connections are shared in the process.
+// The errors of rpc returned are *Error.
+type Client struct {
+	options Options
+}
+
+func New(options Options) (*Client, error) {
+	if len(options.MdsAddrs) == 0 {
+		return nil, fmt.Errorf("no mds address")
+	}
+	if options.RpcTimeout <= 0 {
+		options.RpcTimeout = DEFAULT_RPC_TIMEOUT
+	}
+	if options.RpcRetryTimes < 0 {
+		options.RpcRetryTimes = DEFAULT_RPC_RETRY_TIMES
+	}
+	if options.HttpTimeout <= 0 {
+		options.HttpTimeout = DEFAULT_HTTP_TIMEOUT
+	}
+	return &Client{options: options}, nil
+}
+
+// Options returns the options of c with the default values filled
+func (c *Client) Options() Options {
+	return c.options
+}
+
+// the rpc to mds, it is sent to the leader only if the dummy addresses are set
+func (c *Client) mdsRpc(funcName string) *transport.Rpc {
+	rpc := c.rpc(c.options.MdsAddrs, funcName)
+	if len(c.options.MdsAddrs) > 1 && len(c.options.MdsDummyAddrs) == len(c.options.MdsAddrs) {
+		rpc.Leader = transport.NewMdsLeader(c.options.MdsAddrs, c.options.MdsDummyAddrs,
+			c.options.HttpTimeout, c.options.MdsLeaderCacheFile)
+	}
+	return rpc
+}
+
+func (c *Client) rpc(addrs []string, funcName string) *transport.Rpc {
+	return transport.NewRpc(addrs, c.options.RpcTimeout, c.options.RpcRetryTimes, funcName)
+}
+
+// the error is returned as error only if it fails, it is nil otherwise
+type listFsRpc struct {
+	request   *mds.ListClusterFsInfoRequest
+	mdsClient mds.MdsServiceClient
+}
+
+var _ transport.RpcFunc = (*listFsRpc)(nil) // check interface
+
+func (r *listFsRpc) NewRpcClient(cc grpc.ClientConnInterface) {
+	r.mdsClient = mds.NewMdsServiceClient(cc)
+}
+
+func (r *listFsRpc) Stub_Func(ctx context.Context) (interface{}, error) {
+	return r.mdsClient.ListClusterFsInfo(ctx, r.request)
+}
+
+// ListFs returns all the fs in the cluster
+func (c *Client) ListFs(ctx context.Context) ([]*mds.FsInfo, error) {
+	rpc := &listFsRpc{request: &mds.ListClusterFsInfoRequest{}}
+	response, err := transport.GetRpcResponse(ctx, c.mdsRpc("ListClusterFsInfo"), rpc)
+	if err.TypeCode() != cmderror.CODE_SUCCESS {
+		return nil, newError(err)
+	}
+	return response.(*mds.ListClusterFsInfoResponse).GetFsInfo(), nil
+}
+
+type getFsInfoRpc struct {
+	request   *mds.GetFsInfoRequest
+	mdsClient mds.MdsServiceClient
+}
+
+var _ transport.RpcFunc = (*getFsInfoRpc)(nil) // check interface
+
+func (r *getFsInfoRpc) NewRpcClient(cc grpc.ClientConnInterface) {
+	r.mdsClient = mds.NewMdsServiceClient(cc)
+}
+
+func (r *getFsInfoRpc) Stub_Func(ctx context.Context) (interface{}, error) {
+	return r.mdsClient.GetFsInfo(ctx, r.request)
+}
+
+// GetFsInfo returns the fs named fsName, it fails if the fs does not exist
+func (c *Client) GetFsInfo(ctx context.Context, fsName string) (*mds.FsInfo, error) {
+	rpc := &getFsInfoRpc{request: &mds.GetFsInfoRequest{FsName: &fsName}}
+	result, err := transport.GetRpcResponse(ctx, c.mdsRpc("GetFsInfo"), rpc)
+	if err.TypeCode() != cmderror.CODE_SUCCESS {
+		return nil, newError(err)
+	}
+	response := result.(*mds.GetFsInfoResponse)
+	if response.GetStatusCode() != mds.FSStatusCode_OK {
+		errGet := cmderror.ErrGetFsInfo(int(response.GetStatusCode()))
+		errGet.Format(response.GetStatusCode().String())
+		return nil, newError(errGet)
+	}
+	return response.GetFsInfo(), nil
+}
+
+type listTopologyRpc struct {
+	request        *topology.ListTopologyRequest
+	topologyClient topology.TopologyServiceClient
+}
+
+var _ transport.RpcFunc = (*listTopologyRpc)(nil) // check interface
+
+func (r *listTopologyRpc) NewRpcClient(cc grpc.ClientConnInterface) {
+	r.topologyClient = topology.NewTopologyServiceClient(cc)
+}
+
+func (r *listTopologyRpc) Stub_Func(ctx context.Context) (interface{}, error) {
+	return r.topologyClient.ListTopology(ctx, r.request)
+}
+
+// ListTopology returns the pools, zones, servers and metaservers of the cluster
+func (c *Client) ListTopology(ctx context.Context) (*topology.ListTopologyResponse, error) {
+	rpc := &listTopologyRpc{request: &topology.ListTopologyRequest{}}
+	response, err := transport.GetRpcResponse(ctx, c.mdsRpc("ListTopology"), rpc)
+	if err.TypeCode() != cmderror.CODE_SUCCESS {
+		return nil, newError(err)
+	}
+	return response.(*topology.ListTopologyResponse), nil
+}
+
+type listPartitionRpc struct {
+	request        *topology.ListPartitionRequest
+	topologyClient topology.TopologyServiceClient
+}
+
+var _ transport.RpcFunc = (*listPartitionRpc)(nil) // check interface
+
+func (r *listPartitionRpc) NewRpcClient(cc grpc.ClientConnInterface) {
+	r.topologyClient = topology.NewTopologyServiceClient(cc)
+}
+
+func (r *listPartitionRpc) Stub_Func(ctx context.Context) (interface{}, error) {
+	return r.topologyClient.ListPartition(ctx, r.request)
+}
+
+// ListPartition returns the partitions of the fs
+func (c *Client) ListPartition(ctx context.Context, fsId uint32) ([]*common.PartitionInfo, error) {
+	rpc := &listPartitionRpc{request: &topology.ListPartitionRequest{FsId: &fsId}}
+	result, err := transport.GetRpcResponse(ctx, c.mdsRpc("ListPartition"), rpc)
+	if err.TypeCode() != cmderror.CODE_SUCCESS {
+		return nil, newError(err)
+	}
+	response := result.(*topology.ListPartitionResponse)
+	if response.GetStatusCode() != topology.TopoStatusCode_TOPO_OK {
+		return nil, newError(cmderror.ErrListPartition(int(response.GetStatusCode())))
+	}
+	return response.GetPartitionInfoList(), nil
+}
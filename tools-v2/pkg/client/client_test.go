@@ -0,0 +1,138 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/mds"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/topology"
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+)
+
+type fakeMds struct {
+	mds.UnimplementedMdsServiceServer
+}
+
+type fakeTopology struct {
+	topology.UnimplementedTopologyServiceServer
+	partitionStatus topology.TopoStatusCode
+}
+
+func (f *fakeMds) ListClusterFsInfo(ctx context.Context, request *mds.ListClusterFsInfoRequest) (*mds.ListClusterFsInfoResponse, error) {
+	return &mds.ListClusterFsInfoResponse{}, nil
+}
+
+func (f *fakeTopology) ListPartition(ctx context.Context, request *topology.ListPartitionRequest) (*topology.ListPartitionResponse, error) {
+	return &topology.ListPartitionResponse{StatusCode: &f.partitionStatus}, nil
+}
+
+// startFakeMds serves the mds and topology service on a local port, and returns its address
+func startFakeMds(fake *fakeTopology) (string, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	So(err, ShouldBeNil)
+	server := grpc.NewServer()
+	mds.RegisterMdsServiceServer(server, &fakeMds{})
+	topology.RegisterTopologyServiceServer(server, fake)
+	go server.Serve(listener)
+	return listener.Addr().String(), server.Stop
+}
+
+// the exit code of commands which fail with the err returned by client
+func exitCode(err error) int {
+	var clientErr *Error
+	So(errors.As(err, &clientErr), ShouldBeTrue)
+	return clientErr.ExitCode
+}
+
+func TestNew(t *testing.T) {
+	Convey("the mds address is required", t, func() {
+		_, err := New(Options{})
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("the default values are filled", t, func() {
+		c, err := New(Options{MdsAddrs: []string{"127.0.0.1:6700"}, RpcRetryTimes: -1})
+		So(err, ShouldBeNil)
+		So(c.Options().RpcTimeout, ShouldEqual, DEFAULT_RPC_TIMEOUT)
+		So(c.Options().RpcRetryTimes, ShouldEqual, DEFAULT_RPC_RETRY_TIMES)
+		So(c.Options().HttpTimeout, ShouldEqual, DEFAULT_HTTP_TIMEOUT)
+	})
+
+	Convey("the default values are the same as the commands", t, func() {
+		So(DEFAULT_RPC_TIMEOUT, ShouldEqual, config.DEFAULT_RPCTIMEOUT)
+		So(DEFAULT_RPC_RETRY_TIMES, ShouldEqual, config.DEFAULT_RPCRETRYTIMES)
+		So(DEFAULT_HTTP_TIMEOUT, ShouldEqual, config.DEFAULT_HTTPTIMEOUT)
+	})
+}
+
+func TestPeerAddr(t *testing.T) {
+	Convey("the address of peer is split", t, func() {
+		addr, err := peerAddr("127.0.0.1:6800:0")
+		So(err, ShouldBeNil)
+		So(addr, ShouldEqual, "127.0.0.1:6800")
+		_, err = peerAddr("127.0.0.1:6800")
+		So(err.Message, ShouldEqual, "split peer 127.0.0.1:6800 failed!")
+	})
+}
+
+func TestClient(t *testing.T) {
+	Convey("the client calls the mds", t, func() {
+		fake := &fakeTopology{partitionStatus: topology.TopoStatusCode_TOPO_OK}
+		addr, stop := startFakeMds(fake)
+		defer stop()
+		c, err := New(Options{MdsAddrs: []string{addr}, RpcTimeout: time.Second})
+		So(err, ShouldBeNil)
+		ctx := context.Background()
+
+		fsInfo, err := c.ListFs(ctx)
+		So(err, ShouldBeNil)
+		So(fsInfo, ShouldBeEmpty)
+
+		partitions, err := c.ListPartition(ctx, 1)
+		So(err, ShouldBeNil)
+		So(partitions, ShouldBeEmpty)
+
+		fake.partitionStatus = topology.TopoStatusCode_TOPO_PARTITION_NOT_FOUND
+		_, err = c.ListPartition(ctx, 1)
+		So(err, ShouldNotBeNil)
+		So(exitCode(err), ShouldEqual, cmderror.EXIT_NOT_FOUND)
+
+		_, err = c.GetInode(ctx, InodeOptions{FsId: 1, InodeId: 1})
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("the mds is unreachable", t, func() {
+		c, err := New(Options{MdsAddrs: []string{"127.0.0.1:1"}, RpcTimeout: 100 * time.Millisecond})
+		So(err, ShouldBeNil)
+		_, err = c.ListFs(context.Background())
+		So(err, ShouldNotBeNil)
+		So(exitCode(err), ShouldEqual, cmderror.EXIT_UNREACHABLE)
+	})
+}
@@ -0,0 +1,248 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package client
+
+import (
+	"context"
+	"strings"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/transport"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/copyset"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/heartbeat"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/topology"
+	"google.golang.org/grpc"
+)
+
+// CopysetKey is the pool and the copyset id of a copyset
+type CopysetKey struct {
+	PoolId    uint32
+	CopysetId uint32
+}
+
+// Key is the key of the copyset in the results, e.g. GetCopysetStatus
+func (key CopysetKey) Key() uint64 {
+	return copysetKey(key.PoolId, key.CopysetId)
+}
+
+func copysetKey(poolId uint32, copysetId uint32) uint64 {
+	return (uint64(poolId) << 32) | uint64(copysetId)
+}
+
+// CopysetStatus is the info of copyset in mds, and its status on every peer,
+// the status of the offline peer is nil
+type CopysetStatus struct {
+	Info        *heartbeat.CopySetInfo                    `json:"info,omitempty"`
+	Peer2Status map[string]*copyset.CopysetStatusResponse `json:"peer status,omitempty"`
+}
+
+type CopysetOptions struct {
+	Copysets []CopysetKey
+	// get the status of the copysets from their peers
+	PeerStatus bool
+}
+
+type getCopysetsInfoRpc struct {
+	request        *topology.GetCopysetsInfoRequest
+	topologyClient topology.TopologyServiceClient
+}
+
+var _ transport.RpcFunc = (*getCopysetsInfoRpc)(nil) // check interface
+
+func (r *getCopysetsInfoRpc) NewRpcClient(cc grpc.ClientConnInterface) {
+	r.topologyClient = topology.NewTopologyServiceClient(cc)
+}
+
+func (r *getCopysetsInfoRpc) Stub_Func(ctx context.Context) (interface{}, error) {
+	return r.topologyClient.GetCopysetsInfo(ctx, r.request)
+}
+
+type getCopysetsStatusRpc struct {
+	request       *copyset.CopysetsStatusRequest
+	copysetClient copyset.CopysetServiceClient
+}
+
+var _ transport.RpcFunc = (*getCopysetsStatusRpc)(nil) // check interface
+
+func (r *getCopysetsStatusRpc) NewRpcClient(cc grpc.ClientConnInterface) {
+	r.copysetClient = copyset.NewCopysetServiceClient(cc)
+}
+
+func (r *getCopysetsStatusRpc) Stub_Func(ctx context.Context) (interface{}, error) {
+	return r.copysetClient.GetCopysetsStatus(ctx, r.request)
+}
+
+type listCopysetRpc struct {
+	request        *topology.ListCopysetInfoRequest
+	topologyClient topology.TopologyServiceClient
+}
+
+var _ transport.RpcFunc = (*listCopysetRpc)(nil) // check interface
+
+func (r *listCopysetRpc) NewRpcClient(cc grpc.ClientConnInterface) {
+	r.topologyClient = topology.NewTopologyServiceClient(cc)
+}
+
+func (r *listCopysetRpc) Stub_Func(ctx context.Context) (interface{}, error) {
+	return r.topologyClient.ListCopysetInfo(ctx, r.request)
+}
+
+// ListCopyset returns the info of all the copysets in mds
+func (c *Client) ListCopyset(ctx context.Context) ([]*heartbeat.CopySetInfo, error) {
+	rpc := &listCopysetRpc{request: &topology.ListCopysetInfoRequest{}}
+	response, err := transport.GetRpcResponse(ctx, c.mdsRpc("ListCopysetInfo"), rpc)
+	if err.TypeCode() != cmderror.CODE_SUCCESS {
+		return nil, newError(err)
+	}
+	var ret []*heartbeat.CopySetInfo
+	for _, value := range response.(*topology.ListCopysetInfoResponse).GetCopysetValues() {
+		if info := value.GetCopysetInfo(); info != nil {
+			ret = append(ret, info)
+		}
+	}
+	return ret, nil
+}
+
+// GetCopysetStatus returns the copysets by CopysetKey.Key, the one not found is nil.
+// The copysets got are returned with the error if some of them fail,
+// nothing is returned if the copysets can not be got from mds.
+func (c *Client) GetCopysetStatus(ctx context.Context, options CopysetOptions) (map[uint64]*CopysetStatus, error) {
+	ret := make(map[uint64]*CopysetStatus)
+	request := &topology.GetCopysetsInfoRequest{}
+	for i := range options.Copysets {
+		key := options.Copysets[i]
+		request.CopysetKeys = append(request.CopysetKeys, &topology.CopysetKey{
+			PoolId:    &key.PoolId,
+			CopysetId: &key.CopysetId,
+		})
+		ret[key.Key()] = nil
+	}
+	result, err := transport.GetRpcResponse(ctx, c.mdsRpc("GetCopysetsInfo"), &getCopysetsInfoRpc{request: request})
+	if err.TypeCode() != cmderror.CODE_SUCCESS {
+		return nil, newError(err)
+	}
+
+	var errs []*cmderror.CmdError
+	var found []*topology.CopysetValue
+	for _, value := range result.(*topology.GetCopysetsInfoResponse).GetCopysetValues() {
+		if value.GetStatusCode() != topology.TopoStatusCode_TOPO_COPYSET_NOT_FOUND {
+			info := value.GetCopysetInfo()
+			key := copysetKey(info.GetPoolId(), info.GetCopysetId())
+			if ret[key] == nil {
+				ret[key] = &CopysetStatus{Info: info}
+			}
+		}
+		if value.GetStatusCode() == topology.TopoStatusCode_TOPO_OK {
+			found = append(found, value)
+		} else {
+			errs = append(errs, cmderror.ErrGetCopysetsInfo(int(value.GetStatusCode())))
+		}
+	}
+	if options.PeerStatus {
+		errs = append(errs, c.getPeerStatus(ctx, found, ret)...)
+	}
+
+	if len(errs) == 0 {
+		return ret, nil
+	}
+	retErr := cmderror.MergeCmdError(errs)
+	if len(found) > 0 {
+		return ret, newError(cmderror.PartialCmdError(&retErr))
+	}
+	return ret, newError(&retErr)
+}
+
+// getPeerStatus fills the Peer2Status of copysets, one rpc for every peer
+func (c *Client) getPeerStatus(ctx context.Context, values []*topology.CopysetValue, copysets map[uint64]*CopysetStatus) []*cmderror.CmdError {
+	var errs []*cmderror.CmdError
+	addr2Request := make(map[string]*copyset.CopysetsStatusRequest)
+	for _, value := range values {
+		info := value.GetCopysetInfo()
+		for _, peer := range info.GetPeers() {
+			addr, err := peerAddr(peer.GetAddress())
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if addr2Request[addr] == nil {
+				addr2Request[addr] = &copyset.CopysetsStatusRequest{}
+			}
+			poolId := info.GetPoolId()
+			copysetId := info.GetCopysetId()
+			addr2Request[addr].Copysets = append(addr2Request[addr].Copysets, &copyset.CopysetStatusRequest{
+				PoolId:    &poolId,
+				CopysetId: &copysetId,
+			})
+		}
+	}
+
+	type statusResult struct {
+		addr     string
+		request  *copyset.CopysetsStatusRequest
+		response *copyset.CopysetsStatusResponse
+		err      *cmderror.CmdError
+	}
+	results := make(chan statusResult, len(addr2Request))
+	for addr, request := range addr2Request {
+		go func(addr string, request *copyset.CopysetsStatusRequest) {
+			rpc := &getCopysetsStatusRpc{request: request}
+			response, err := transport.GetRpcResponse(ctx, c.rpc([]string{addr}, "GetCopysetsStatus"), rpc)
+			result := statusResult{addr: addr, request: request, err: err}
+			if err.TypeCode() == cmderror.CODE_SUCCESS {
+				result.response = response.(*copyset.CopysetsStatusResponse)
+			}
+			results <- result
+		}(addr, request)
+	}
+	for range addr2Request {
+		result := <-results
+		if result.err.TypeCode() != cmderror.CODE_SUCCESS {
+			errs = append(errs, result.err)
+		}
+		status := result.response.GetStatus()
+		for i, request := range result.request.GetCopysets() {
+			key := copysetKey(request.GetPoolId(), request.GetCopysetId())
+			copysetStatus := copysets[key]
+			if copysetStatus.Peer2Status == nil {
+				copysetStatus.Peer2Status = make(map[string]*copyset.CopysetStatusResponse)
+			}
+			// the status is nil if the peer is offline
+			if i < len(status) {
+				copysetStatus.Peer2Status[result.addr] = status[i]
+			} else {
+				copysetStatus.Peer2Status[result.addr] = nil
+			}
+		}
+	}
+	return errs
+}
+
+// the address of peer is like 127.0.0.1:6800:0
+func peerAddr(address string) (string, *cmderror.CmdError) {
+	items := strings.Split(address, ":")
+	if len(items) != 3 {
+		err := cmderror.ErrSplitPeer()
+		err.Format(address)
+		return "", err
+	}
+	return items[0] + ":" + items[1], nil
+}
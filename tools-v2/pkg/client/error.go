@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package client
+
+import (
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+)
+
+// Error is the error returned by the client, it may be wrapped with the context,
+// so get it by errors.As
+type Error struct {
+	// the code of the error in curve commands, e.g. the rpc fails
+	Code    int
+	Message string
+	// the exit code of curve commands which fail with the error,
+	// e.g. 3 if the cluster is unreachable and 4 if the result is partial
+	ExitCode int
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// newError converts the error of the rpc, it is nil if err is success
+func newError(err *cmderror.CmdError) error {
+	if err == nil || err.TypeCode() == cmderror.CODE_SUCCESS {
+		return nil
+	}
+	return &Error{
+		Code:     err.Code,
+		Message:  err.Message,
+		ExitCode: err.ExitCode(),
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/transport"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/metaserver"
+	"google.golang.org/grpc"
+)
+
+type InodeOptions struct {
+	FsId    uint32
+	InodeId uint64
+}
+
+type getInodeRpc struct {
+	request          *metaserver.GetInodeRequest
+	metaserverClient metaserver.MetaServerServiceClient
+}
+
+var _ transport.RpcFunc = (*getInodeRpc)(nil) // check interface
+
+func (r *getInodeRpc) NewRpcClient(cc grpc.ClientConnInterface) {
+	r.metaserverClient = metaserver.NewMetaServerServiceClient(cc)
+}
+
+func (r *getInodeRpc) Stub_Func(ctx context.Context) (interface{}, error) {
+	return r.metaserverClient.GetInode(ctx, r.request)
+}
+
+// GetInode gets the inode from the leader of the copyset which has the inode
+func (c *Client) GetInode(ctx context.Context, options InodeOptions) (*metaserver.Inode, error) {
+	partitions, err := c.ListPartition(ctx, options.FsId)
+	if err != nil {
+		return nil, err
+	}
+	index := -1
+	for i, partition := range partitions {
+		if partition.GetStart() <= options.InodeId && partition.GetEnd() >= options.InodeId {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		retErr := cmderror.ErrInodeNotFound()
+		retErr.Format(options.InodeId, options.FsId)
+		return nil, newError(retErr)
+	}
+	partition := partitions[index]
+
+	key := CopysetKey{PoolId: partition.GetPoolId(), CopysetId: partition.GetCopysetId()}
+	copysets, err := c.GetCopysetStatus(ctx, CopysetOptions{Copysets: []CopysetKey{key}})
+	if err != nil {
+		return nil, fmt.Errorf("query copyset info failed: %w", err)
+	}
+	if copysets[key.Key()] == nil {
+		return nil, fmt.Errorf("no copysetinfo found")
+	}
+	leader := copysets[key.Key()].Info.GetLeaderPeer()
+	addr, peerErr := peerAddr(leader.GetAddress())
+	if peerErr != nil {
+		return nil, fmt.Errorf("pares leader peer[%s] failed: %w", leader, newError(peerErr))
+	}
+
+	poolId := partition.GetPoolId()
+	copysetId := partition.GetCopysetId()
+	partitionId := partition.GetPartitionId()
+	supportStreaming := false
+	rpc := &getInodeRpc{
+		request: &metaserver.GetInodeRequest{
+			PoolId:           &poolId,
+			CopysetId:        &copysetId,
+			PartitionId:      &partitionId,
+			FsId:             &options.FsId,
+			InodeId:          &options.InodeId,
+			SupportStreaming: &supportStreaming,
+		},
+	}
+	result, errCmd := transport.GetRpcResponse(ctx, c.rpc([]string{addr}, "GetInode"), rpc)
+	if errCmd.TypeCode() != cmderror.CODE_SUCCESS {
+		return nil, fmt.Errorf("get inode failed: %w", newError(errCmd))
+	}
+	response := result.(*metaserver.GetInodeResponse)
+	if response.GetStatusCode() != metaserver.MetaStatusCode_OK {
+		return nil, newError(cmderror.ErrGetInode(int(response.GetStatusCode())))
+	}
+	return response.GetInode(), nil
+}
@@ -0,0 +1,204 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/transport"
+)
+
+const (
+	STATUS_LEADER   = "leader"
+	STATUS_FOLLOWER = "follower"
+	STATUS_ONLINE   = "online"
+	STATUS_OFFLINE  = "offline"
+	VERSION_UNKNOWN = "unknown"
+)
+
+const (
+	MDS_VERSION_SUBURI        = "/vars/curve_version"
+	METASERVER_STATUS_SUBURI  = "/vars/pid"
+	METASERVER_VERSION_SUBURI = "/vars/curve_version"
+	ETCD_STATUS_SUBURI        = "/v2/stats/self"
+	ETCD_STATUS_METRIC_KEY    = "state"
+	ETCD_VERSION_SUBURI       = "/version"
+	ETCD_VERSION_METRIC_KEY   = "etcdserver"
+)
+
+var etcdStatusMap = map[string]string{
+	"StateLeader":   STATUS_LEADER,
+	"StateFollower": STATUS_FOLLOWER,
+}
+
+// ServerStatus is the status of a server got from its metric
+type ServerStatus struct {
+	// the address of the service
+	Addr string
+	// the address of the metric, e.g. the dummy address of mds
+	MetricAddr string
+	// VERSION_UNKNOWN if the version can not be got
+	Version string
+	// STATUS_OFFLINE if the status can not be got
+	Status string
+}
+
+// metricQuery is how to get the status and the version of a kind of server
+type metricQuery struct {
+	statusUri  string
+	versionUri string
+	// parse the value from the body of the metric
+	parseStatus  func(body string) (string, *cmderror.CmdError)
+	parseVersion func(body string) (string, *cmderror.CmdError)
+	// the error of the server which is offline
+	offline func() *cmderror.CmdError
+}
+
+// queryServers gets the status and the version of servers concurrently,
+// the most important error of the offline servers is returned with the status
+func (c *Client) queryServers(ctx context.Context, servers []ServerStatus, query metricQuery) ([]ServerStatus, error) {
+	type metricResult struct {
+		index  int
+		status bool
+		value  string
+		err    *cmderror.CmdError
+	}
+	results := make(chan metricResult, 2*len(servers))
+	get := func(index int, subUri string, status bool, parse func(string) (string, *cmderror.CmdError)) {
+		metric := transport.NewMetric([]string{servers[index].MetricAddr}, subUri, c.options.HttpTimeout)
+		body, err := transport.QueryMetric(ctx, *metric)
+		var value string
+		if err.TypeCode() == cmderror.CODE_SUCCESS {
+			value, err = parse(body)
+		}
+		results <- metricResult{index: index, status: status, value: value, err: err}
+	}
+	for i := range servers {
+		servers[i].Status = STATUS_OFFLINE
+		servers[i].Version = VERSION_UNKNOWN
+		go get(i, query.statusUri, true, query.parseStatus)
+		go get(i, query.versionUri, false, query.parseVersion)
+	}
+
+	offline := make([]bool, len(servers))
+	for range servers {
+		for j := 0; j < 2; j++ {
+			result := <-results
+			if result.err.TypeCode() != cmderror.CODE_SUCCESS {
+				offline[result.index] = true
+			} else if result.status {
+				servers[result.index].Status = result.value
+			} else {
+				servers[result.index].Version = result.value
+			}
+		}
+	}
+	var errs []*cmderror.CmdError
+	for i := range servers {
+		if offline[i] {
+			err := query.offline()
+			err.Format(servers[i].MetricAddr)
+			errs = append(errs, err)
+		}
+	}
+	return servers, newError(cmderror.MostImportantCmdError(errs))
+}
+
+func metricValue(body string) (string, *cmderror.CmdError) {
+	return transport.GetMetricValue(body)
+}
+
+func jsonMetricValue(key string) func(string) (string, *cmderror.CmdError) {
+	return func(body string) (string, *cmderror.CmdError) {
+		return transport.GetKeyValueFromJsonMetric(body, key)
+	}
+}
+
+// GetMdsStatus returns the status of mds by their dummy addresses,
+// the status is the one in the metric of mds, e.g. leader
+func (c *Client) GetMdsStatus(ctx context.Context) ([]ServerStatus, error) {
+	if len(c.options.MdsDummyAddrs) != len(c.options.MdsAddrs) {
+		return nil, fmt.Errorf("the number of mds dummy addresses %d is not the same as the mds addresses %d",
+			len(c.options.MdsDummyAddrs), len(c.options.MdsAddrs))
+	}
+	var servers []ServerStatus
+	for i, addr := range c.options.MdsAddrs {
+		servers = append(servers, ServerStatus{Addr: addr, MetricAddr: c.options.MdsDummyAddrs[i]})
+	}
+	return c.queryServers(ctx, servers, metricQuery{
+		statusUri:    transport.MDS_STATUS_SUBURI,
+		versionUri:   MDS_VERSION_SUBURI,
+		parseStatus:  metricValue,
+		parseVersion: metricValue,
+		offline:      cmderror.ErrMdsOffline,
+	})
+}
+
+// GetEtcdStatus returns the status of etcd in Options.EtcdAddrs
+func (c *Client) GetEtcdStatus(ctx context.Context) ([]ServerStatus, error) {
+	if len(c.options.EtcdAddrs) == 0 {
+		return nil, fmt.Errorf("no etcd address")
+	}
+	var servers []ServerStatus
+	for _, addr := range c.options.EtcdAddrs {
+		servers = append(servers, ServerStatus{Addr: addr, MetricAddr: addr})
+	}
+	parseState := jsonMetricValue(ETCD_STATUS_METRIC_KEY)
+	return c.queryServers(ctx, servers, metricQuery{
+		statusUri:  ETCD_STATUS_SUBURI,
+		versionUri: ETCD_VERSION_SUBURI,
+		parseStatus: func(body string) (string, *cmderror.CmdError) {
+			state, err := parseState(body)
+			return etcdStatusMap[state], err
+		},
+		parseVersion: jsonMetricValue(ETCD_VERSION_METRIC_KEY),
+		offline:      cmderror.ErrEtcdOffline,
+	})
+}
+
+// GetMetaserverStatus returns the status of all the metaservers in the topology,
+// the Addr is the internal address and the MetricAddr is the external one
+func (c *Client) GetMetaserverStatus(ctx context.Context) ([]ServerStatus, error) {
+	topology, err := c.ListTopology(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var servers []ServerStatus
+	for _, metaserver := range topology.GetMetaservers().GetMetaServerInfos() {
+		servers = append(servers, ServerStatus{
+			Addr:       fmt.Sprintf("%s:%d", metaserver.GetInternalIp(), metaserver.GetInternalPort()),
+			MetricAddr: fmt.Sprintf("%s:%d", metaserver.GetExternalIp(), metaserver.GetExternalPort()),
+		})
+	}
+	return c.queryServers(ctx, servers, metricQuery{
+		statusUri:  METASERVER_STATUS_SUBURI,
+		versionUri: METASERVER_VERSION_SUBURI,
+		parseStatus: func(body string) (string, *cmderror.CmdError) {
+			// the metaserver is online if its pid is got
+			return STATUS_ONLINE, cmderror.ErrSuccess()
+		},
+		parseVersion: metricValue,
+		offline:      cmderror.ErrMetaserverOffline,
+	})
+}
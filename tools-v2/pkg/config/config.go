@@ -23,6 +23,7 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -40,20 +41,67 @@ var (
 const (
 	FORMAT = "format"
 	// global
+	SHOWERROR                   = "showerror"
 	VIPER_GLOBALE_SHOWERROR     = "global.showError"
+	NOHEADERS                   = "no-headers"
+	VIPER_GLOBALE_NOHEADERS     = "global.noHeaders"
+	COLUMNS                     = "columns"
+	VIPER_GLOBALE_COLUMNS       = "global.columns"
+	SORTBY                      = "sort-by"
+	VIPER_GLOBALE_SORTBY        = "global.sortBy"
+	FILTER                      = "filter"
+	VIPER_GLOBALE_FILTER        = "global.filter"
+	WATCH                       = "watch"
+	VIPER_GLOBALE_WATCH         = "global.watch"
+	HTTPTIMEOUT                 = "httptimeout"
 	VIPER_GLOBALE_HTTPTIMEOUT   = "global.httpTimeout"
+	DEFAULT_HTTPTIMEOUT         = 500 * time.Millisecond
 	RPCTIMEOUT                  = "rpctimeout"
 	VIPER_GLOBALE_RPCTIMEOUT    = "global.rpcTimeout"
 	DEFAULT_RPCTIMEOUT          = 10000 * time.Millisecond
 	RPCRETRYTIMES               = "rpcretrytimes"
 	VIPER_GLOBALE_RPCRETRYTIMES = "global.rpcRetryTimes"
 	DEFAULT_RPCRETRYTIMES       = int32(1)
+	// retry the rpc which changes the cluster, e.g. create fs
+	RPCRETRYMUTATING               = "rpcretrymutating"
+	VIPER_GLOBALE_RPCRETRYMUTATING = "global.rpcRetryMutating"
+	// print the rpc which changes the cluster instead of sending them
+	DRYRUN               = "dry-run"
+	VIPER_GLOBALE_DRYRUN = "global.dryRun"
+	// the json-lines file of the records of the rpc which change the cluster
+	AUDITLOG               = "auditlog"
+	VIPER_GLOBALE_AUDITLOG = "global.auditLog"
+	AUDIT_SINCE            = "since"
+	AUDIT_UNTIL            = "until"
+	AUDIT_USER             = "user"
+	AUDIT_RPC              = "rpc"
+	AUDIT_COMMAND          = "command"
+	// overwrite the existing config file by config init
+	CONFIG_FORCE = "force"
+	// idle rpc connections in the pool will be closed after it
+	VIPER_GLOBALE_CONNIDLETIMEOUT = "global.connIdleTimeout"
+	DEFAULT_CONNIDLETIMEOUT       = 60 * time.Second
+	// the deadline of the whole command, 0 means no limit
+	CMDTIMEOUT               = "cmdtimeout"
+	VIPER_GLOBALE_CMDTIMEOUT = "global.cmdTimeout"
+	// save the rpc and http traffic in the dir, or serve the commands from it
+	RECORD               = "record"
+	VIPER_GLOBALE_RECORD = "global.record"
+	REPLAY               = "replay"
+	VIPER_GLOBALE_REPLAY = "global.replay"
+	// the level of logs about rpc and http, e.g. -vv
+	VERBOSE               = "verbose"
+	VIPER_GLOBALE_VERBOSE = "global.verbose"
+	// the file of the spans in the json of OTLP
+	TRACEFILE               = "trace-file"
+	VIPER_GLOBALE_TRACEFILE = "global.traceFile"
 
 	// curvefs
 	CURVEFS_MDSADDR              = "mdsaddr"
 	VIPER_CURVEFS_MDSADDR        = "curvefs.mdsAddr"
 	CURVEFS_MDSDUMMYADDR         = "mdsdummyaddr"
 	VIPER_CURVEFS_MDSDUMMYADDR   = "curvefs.mdsDummyAddr"
+	VIPER_CURVEFS_CACHEMDSLEADER = "curvefs.cacheMdsLeader"
 	CURVEFS_ETCDADDR             = "etcdaddr"
 	VIPER_CURVEFS_ETCDADDR       = "curvefs.etcdAddr"
 	CURVEFS_METASERVERADDR       = "metaserveraddr"
@@ -139,12 +187,19 @@ const (
 	CURVEFS_VOLUME_SLICESIZE              = "volume.slicesize"
 	VIPER_CURVEFS_VOLUME_SLICESIZE        = "curvefs.volume.slicesize"
 	CURVEFS_DEFAULT_VOLUME_SLICESIZE      = "1 gib"
+
+	// curvebs
+	VIPER_CURVEBS_MDSADDR      = "curvebs.mdsAddr"
+	VIPER_CURVEBS_MDSDUMMYADDR = "curvebs.mdsDummyAddr"
 )
 
 var (
 	FLAG2VIPER = map[string]string{
 		RPCTIMEOUT:             VIPER_GLOBALE_RPCTIMEOUT,
 		RPCRETRYTIMES:          VIPER_GLOBALE_RPCRETRYTIMES,
+		RPCRETRYMUTATING:       VIPER_GLOBALE_RPCRETRYMUTATING,
+		DRYRUN:                 VIPER_GLOBALE_DRYRUN,
+		AUDITLOG:               VIPER_GLOBALE_AUDITLOG,
 		CURVEFS_MDSADDR:        VIPER_CURVEFS_MDSADDR,
 		CURVEFS_MDSDUMMYADDR:   VIPER_CURVEFS_MDSDUMMYADDR,
 		CURVEFS_ETCDADDR:       VIPER_CURVEFS_ETCDADDR,
@@ -208,24 +263,74 @@ var (
 	}
 )
 
-func InitConfig() {
-	if ConfPath != "" {
-		viper.SetConfigFile(ConfPath)
-	} else {
-		// using home directory and /etc/curve as default configuration file path
-		home, err := os.UserHomeDir()
-		cobra.CheckErr(err)
-		viper.AddConfigPath(home + "/.curve")
-		viper.AddConfigPath("/etc/curve")
-		viper.SetConfigType("yaml")
-		viper.SetConfigName("curve")
+const (
+	ENV_PREFIX = "CURVE"
+)
+
+// EnvName is the environment variable of the viper key, e.g. CURVE_CURVEFS_MDSADDR for curvefs.mdsAddr,
+// it overrides the config file and is overridden by the flag
+func EnvName(key string) string {
+	return ENV_PREFIX + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// GetCacheDir returns the dir to keep the cache of curve tool, it's $HOME/.curve/cache
+func GetCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
 	}
+	return filepath.Join(home, ".curve", "cache"), nil
+}
+
+// GetAuditLogPath returns the audit log file, default is $HOME/.curve/audit.log
+func GetAuditLogPath() (string, error) {
+	if path := viper.GetString(VIPER_GLOBALE_AUDITLOG); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".curve", "audit.log"), nil
+}
+
+// InitConfig reads the config file again, it is run again in shell
+// when a line gives another --conf or --context
+func InitConfig() {
+	path, err := configFile()
+	cobra.CheckErr(err)
+	// viper keeps the file of the last InitConfig, so it is always set
+	viper.SetConfigFile(path)
 
 	// viper.SetDefault("format", "plain")
+	// the environment variables override the config file, see EnvName
+	viper.SetEnvPrefix(ENV_PREFIX)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
-	if err := viper.ReadInConfig(); err == nil {
-		cobra.CheckErr(err)
+	if err := viper.ReadInConfig(); err != nil {
+		// the values of the last config file are not kept
+		viper.ReadConfig(strings.NewReader(""))
 	}
+	contextErr = applyContext()
+}
+
+// configFile returns the file given by --conf, or the first one existing
+// in $HOME/.curve and /etc/curve, default is $HOME/.curve/curve.yaml
+func configFile() (string, error) {
+	if ConfPath != "" {
+		return ConfPath, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	for _, dir := range []string{filepath.Join(home, ".curve"), "/etc/curve"} {
+		path := filepath.Join(dir, "curve.yaml")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return filepath.Join(home, ".curve", "curve.yaml"), nil
 }
 
 func AddStringOptionFlag(cmd *cobra.Command, name string, usage string) {
@@ -370,20 +475,45 @@ const (
 	FORMAT_JSON  = "json"
 	FORMAT_PLAIN = "plain"
 	FORMAT_NOOUT = "noout"
+	FORMAT_YAML  = "yaml"
+	FORMAT_CSV   = "csv"
+	FORMAT_TSV   = "tsv"
+	// with the argument, e.g. template={{.result}}
+	FORMAT_TEMPLATE = "template"
+	FORMAT_JSONPATH = "jsonpath"
 )
 
 func AddFormatFlag(cmd *cobra.Command) {
-	cmd.Flags().StringP("format", "f", FORMAT_PLAIN, "Output format (json|plain)")
-	err := viper.BindPFlag("format", cmd.Flags().Lookup("format"))
+	addFormatFlag(cmd, "f")
+}
+
+// AddFormatFlagWithoutShorthand is for the command using -f for others, e.g. run -f steps.yaml
+func AddFormatFlagWithoutShorthand(cmd *cobra.Command) {
+	addFormatFlag(cmd, "")
+}
+
+func addFormatFlag(cmd *cobra.Command, shorthand string) {
+	cmd.Flags().StringP(FORMAT, shorthand, FORMAT_PLAIN, "Output format (json|plain|yaml|csv|tsv|template=...|jsonpath=...)")
+	err := viper.BindPFlag(FORMAT, cmd.Flags().Lookup(FORMAT))
 	if err != nil {
 		cobra.CheckErr(err)
 	}
 }
 
+// run
+const (
+	RUN_FILE = "file"
+)
+
+func AddRunFileFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP(RUN_FILE, "f", "", "the yaml file of the steps to run"+color.Red.Sprint("[required]"))
+	cmd.MarkFlagRequired(RUN_FILE)
+}
+
 // http timeout
 func AddHttpTimeoutFlag(cmd *cobra.Command) {
-	cmd.Flags().Duration("httptimeout", 500*time.Millisecond, "http timeout")
-	err := viper.BindPFlag("global.httpTimeout", cmd.Flags().Lookup("httptimeout"))
+	cmd.Flags().Duration(HTTPTIMEOUT, DEFAULT_HTTPTIMEOUT, "http timeout")
+	err := viper.BindPFlag(VIPER_GLOBALE_HTTPTIMEOUT, cmd.Flags().Lookup(HTTPTIMEOUT))
 	if err != nil {
 		cobra.CheckErr(err)
 	}
@@ -399,6 +529,34 @@ func AddRpcRetryTimesFlag(cmd *cobra.Command) {
 	AddInt32OptionFlag(cmd, RPCRETRYTIMES, "rpc retry times")
 }
 
+// retry the rpc which is not idempotent
+func AddRpcRetryMutatingFlag(cmd *cobra.Command) {
+	AddBoolOptionFlag(cmd, RPCRETRYMUTATING, "also retry the rpc which changes the cluster, it may be executed more than once")
+}
+
+// dry run
+func AddDryRunFlag(cmd *cobra.Command) {
+	AddBoolOptionFlag(cmd, DRYRUN, "print the requests which change the cluster instead of sending them")
+}
+
+// audit
+func AddAuditLogFlag(cmd *cobra.Command) {
+	AddStringOptionFlag(cmd, AUDITLOG, "the audit log file (default is $HOME/.curve/audit.log)")
+}
+
+func AddAuditFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().String(AUDIT_SINCE, "", "only show the records after the time, e.g. 24h, 2006-01-02 or 2006-01-02T15:04:05+08:00")
+	cmd.Flags().String(AUDIT_UNTIL, "", "only show the records before the time, the format is the same as --since")
+	cmd.Flags().String(AUDIT_USER, "", "only show the records of the os user")
+	cmd.Flags().String(AUDIT_RPC, "", "only show the records of the rpc, e.g. DeleteFs")
+	cmd.Flags().String(AUDIT_COMMAND, "", "only show the records whose command line contains it, e.g. \"delete fs\"")
+}
+
+// config
+func AddConfigForceFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(CONFIG_FORCE, false, "overwrite the existing config file")
+}
+
 // channel size
 func MaxChannelSize() int {
 	return viper.GetInt("global.maxChannelSize")
@@ -406,8 +564,85 @@ func MaxChannelSize() int {
 
 // show errors
 func AddShowErrorPFlag(cmd *cobra.Command) {
-	cmd.PersistentFlags().Bool("showerror", false, "display all errors in command")
-	err := viper.BindPFlag("global.showError", cmd.PersistentFlags().Lookup("showerror"))
+	cmd.PersistentFlags().Bool(SHOWERROR, false, "display all errors in command")
+	err := viper.BindPFlag(VIPER_GLOBALE_SHOWERROR, cmd.PersistentFlags().Lookup(SHOWERROR))
+	if err != nil {
+		cobra.CheckErr(err)
+	}
+}
+
+// no headers
+func AddNoHeadersPFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().Bool(NOHEADERS, false, "do not print the header of the table")
+	err := viper.BindPFlag(VIPER_GLOBALE_NOHEADERS, cmd.PersistentFlags().Lookup(NOHEADERS))
+	if err != nil {
+		cobra.CheckErr(err)
+	}
+}
+
+// columns, sort-by and filter of the table,
+// the output in all the formats is the rows of the table if any of them is set
+func AddTablePFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(COLUMNS, "", "the columns of the table to show, e.g. \"addr,status\"")
+	cmd.PersistentFlags().String(SORTBY, "", "sort the table by the column, \"-\" prefix means descending, e.g. \"-id\"")
+	cmd.PersistentFlags().String(FILTER, "", "only show the rows matching all the conditions, e.g. \"status!=online,zone=1\"")
+	for flag, key := range map[string]string{
+		COLUMNS: VIPER_GLOBALE_COLUMNS,
+		SORTBY:  VIPER_GLOBALE_SORTBY,
+		FILTER:  VIPER_GLOBALE_FILTER,
+	} {
+		err := viper.BindPFlag(key, cmd.PersistentFlags().Lookup(flag))
+		if err != nil {
+			cobra.CheckErr(err)
+		}
+	}
+}
+
+// watch
+func AddWatchPFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().Duration(WATCH, 0, "run the command every interval until it is interrupted, e.g. 2s")
+	err := viper.BindPFlag(VIPER_GLOBALE_WATCH, cmd.PersistentFlags().Lookup(WATCH))
+	if err != nil {
+		cobra.CheckErr(err)
+	}
+}
+
+// command timeout
+func AddCmdTimeoutPFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().Duration(CMDTIMEOUT, 0, "timeout of the whole command, 0 means no limit")
+	err := viper.BindPFlag(VIPER_GLOBALE_CMDTIMEOUT, cmd.PersistentFlags().Lookup(CMDTIMEOUT))
+	if err != nil {
+		cobra.CheckErr(err)
+	}
+}
+
+// verbose
+func AddVerbosePFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().CountP(VERBOSE, "v", "log the rpc and http to stderr, -v for the rpc, -vv with the requests and responses, -vvv with the http of metrics")
+	err := viper.BindPFlag(VIPER_GLOBALE_VERBOSE, cmd.PersistentFlags().Lookup(VERBOSE))
+	if err != nil {
+		cobra.CheckErr(err)
+	}
+}
+
+// trace file
+func AddTraceFilePFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(TRACEFILE, "", "write the spans of the commands, rpc and http to the file in the json lines of OTLP, a line for every command")
+	err := viper.BindPFlag(VIPER_GLOBALE_TRACEFILE, cmd.PersistentFlags().Lookup(TRACEFILE))
+	if err != nil {
+		cobra.CheckErr(err)
+	}
+}
+
+// record and replay
+func AddRecordPFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(RECORD, "", "save the rpc and http traffic of the command in the dir, it can be replayed by --replay")
+	err := viper.BindPFlag(VIPER_GLOBALE_RECORD, cmd.PersistentFlags().Lookup(RECORD))
+	if err != nil {
+		cobra.CheckErr(err)
+	}
+	cmd.PersistentFlags().String(REPLAY, "", "serve the command by the traffic saved by --record in the dir instead of the cluster")
+	err = viper.BindPFlag(VIPER_GLOBALE_REPLAY, cmd.PersistentFlags().Lookup(REPLAY))
 	if err != nil {
 		cobra.CheckErr(err)
 	}
@@ -423,9 +658,17 @@ func AddFsMdsAddrFlag(cmd *cobra.Command) {
 	}
 }
 
+// the flag may not be added to cmd, then the value comes from viper.
+// The default of the flag is used if the env and config file do not set it,
+// as viper only keeps the default of the last flag bound to the key.
+func useFlagValue(cmd *cobra.Command, flagName string) bool {
+	flag := cmd.Flag(flagName)
+	return flag != nil && (flag.Changed || !viper.IsSet(FLAG2VIPER[flagName]))
+}
+
 func GetAddrSlice(cmd *cobra.Command, addrType string) ([]string, *cmderror.CmdError) {
 	var addrsStr string
-	if cmd.Flag(addrType).Changed {
+	if useFlagValue(cmd, addrType) {
 		addrsStr = cmd.Flag(addrType).Value.String()
 	} else {
 		addrsStr = viper.GetString(FLAG2VIPER[addrType])
@@ -443,7 +686,7 @@ func GetAddrSlice(cmd *cobra.Command, addrType string) ([]string, *cmderror.CmdE
 
 func GetFlagString(cmd *cobra.Command, flagName string) string {
 	var value string
-	if cmd.Flag(flagName).Changed {
+	if useFlagValue(cmd, flagName) {
 		value = cmd.Flag(flagName).Value.String()
 	} else {
 		value = viper.GetString(FLAG2VIPER[flagName])
@@ -453,7 +696,7 @@ func GetFlagString(cmd *cobra.Command, flagName string) string {
 
 func GetFlagBool(cmd *cobra.Command, flagName string) bool {
 	var value bool
-	if cmd.Flag(flagName).Changed {
+	if useFlagValue(cmd, flagName) {
 		value, _ = cmd.Flags().GetBool(flagName)
 	} else {
 		value = viper.GetBool(FLAG2VIPER[flagName])
@@ -463,7 +706,7 @@ func GetFlagBool(cmd *cobra.Command, flagName string) bool {
 
 func GetFlagUint64(cmd *cobra.Command, flagName string) uint64 {
 	var value uint64
-	if cmd.Flag(flagName).Changed {
+	if useFlagValue(cmd, flagName) {
 		value, _ = cmd.Flags().GetUint64(flagName)
 	} else {
 		value = viper.GetUint64(FLAG2VIPER[flagName])
@@ -473,7 +716,7 @@ func GetFlagUint64(cmd *cobra.Command, flagName string) uint64 {
 
 func GetFlagUint32(cmd *cobra.Command, flagName string) uint32 {
 	var value uint32
-	if cmd.Flag(flagName).Changed {
+	if useFlagValue(cmd, flagName) {
 		value, _ = cmd.Flags().GetUint32(flagName)
 	} else {
 		value = viper.GetUint32(FLAG2VIPER[flagName])
@@ -483,7 +726,7 @@ func GetFlagUint32(cmd *cobra.Command, flagName string) uint32 {
 
 func GetFlagStringSlice(cmd *cobra.Command, flagName string) []string {
 	var value []string
-	if cmd.Flag(flagName).Changed {
+	if useFlagValue(cmd, flagName) {
 		value, _ = cmd.Flags().GetStringSlice(flagName)
 	} else {
 		value = viper.GetStringSlice(FLAG2VIPER[flagName])
@@ -493,7 +736,7 @@ func GetFlagStringSlice(cmd *cobra.Command, flagName string) []string {
 
 func GetFlagDuration(cmd *cobra.Command, flagName string) time.Duration {
 	var value time.Duration
-	if cmd.Flag(flagName).Changed {
+	if useFlagValue(cmd, flagName) {
 		value, _ = cmd.Flags().GetDuration(flagName)
 	} else {
 		value = viper.GetDuration(FLAG2VIPER[flagName])
@@ -503,7 +746,7 @@ func GetFlagDuration(cmd *cobra.Command, flagName string) time.Duration {
 
 func GetFlagInt32(cmd *cobra.Command, flagName string) int32 {
 	var value int32
-	if cmd.Flag(flagName).Changed {
+	if useFlagValue(cmd, flagName) {
 		value, _ = cmd.Flags().GetInt32(flagName)
 	} else {
 		value = viper.GetInt32(FLAG2VIPER[flagName])
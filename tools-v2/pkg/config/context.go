@@ -0,0 +1,118 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// the config file may keep several clusters as contexts, e.g.
+//
+//	currentContext: prod
+//	contexts:
+//	  prod:
+//	    curvefs:
+//	      mdsAddr: 10.0.0.1:6700,10.0.0.2:6700
+//	  staging:
+//	    curvefs:
+//	      mdsAddr: 10.0.1.1:6700
+//
+// the values of the active context override the top-level ones.
+const (
+	CONTEXT               = "context"
+	VIPER_CONTEXTS        = "contexts"
+	VIPER_CURRENT_CONTEXT = "currentContext"
+)
+
+var (
+	ContextName string // the context given by --context
+	// the context applied by the last InitConfig
+	activeContext string
+	// the context is not found by the last InitConfig
+	contextErr error
+)
+
+func AddContextPFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&ContextName, CONTEXT, "", "the context in config file to use, default is the currentContext in it")
+}
+
+// ContextNames returns the sorted names of the contexts in config file,
+// the names are lower case as the other keys of viper
+func ContextNames() []string {
+	names := make([]string, 0)
+	for name := range viper.GetStringMap(VIPER_CONTEXTS) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ContextSettings returns the values of context, nil if it does not exist
+func ContextSettings(name string) map[string]interface{} {
+	if name == "" || !viper.IsSet(VIPER_CONTEXTS+"."+name) {
+		return nil
+	}
+	return viper.GetStringMap(VIPER_CONTEXTS + "." + name)
+}
+
+// CurrentContext returns the context given by --context or the
+// currentContext in config file, empty means no context is used
+func CurrentContext() string {
+	if ContextName != "" {
+		return ContextName
+	}
+	return viper.GetString(VIPER_CURRENT_CONTEXT)
+}
+
+// ActiveContext returns the context whose values are in use
+func ActiveContext() string {
+	return activeContext
+}
+
+// ContextError returns the error of the last InitConfig if the
+// current context is not found, then only the top-level values are used
+func ContextError() error {
+	return contextErr
+}
+
+// applyContext merges the values of the current context over the
+// top-level ones of config file, the flags still take precedence
+func applyContext() error {
+	activeContext = ""
+	name := CurrentContext()
+	if name == "" {
+		return nil
+	}
+	settings := ContextSettings(name)
+	if settings == nil {
+		return fmt.Errorf("context %s is not found in config file %s", name, viper.ConfigFileUsed())
+	}
+	if err := viper.MergeConfigMap(settings); err != nil {
+		return err
+	}
+	activeContext = name
+	return nil
+}
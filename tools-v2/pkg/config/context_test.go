@@ -0,0 +1,116 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/viper"
+)
+
+const contextConfig = `# clusters
+currentContext: prod
+curvefs:
+  mdsAddr: 127.0.0.1:6700
+  cacheMdsLeader: true
+contexts:
+  prod:
+    curvefs:
+      mdsAddr: 10.0.0.1:6700 # the leader
+  staging:
+    curvefs:
+      mdsAddr: 10.0.1.1:6700
+`
+
+func TestContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "curve.yaml")
+	if err := os.WriteFile(path, []byte(contextConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		viper.Reset()
+		ConfPath, ContextName = "", ""
+		activeContext = ""
+	}()
+
+	Convey("the values are resolved through the current context", t, func() {
+		ConfPath = path
+		InitConfig()
+		So(ActiveContext(), ShouldEqual, "prod")
+		So(ContextNames(), ShouldResemble, []string{"prod", "staging"})
+		So(viper.GetString(VIPER_CURVEFS_MDSADDR), ShouldEqual, "10.0.0.1:6700")
+		// the top-level values not in context are kept
+		So(viper.GetBool(VIPER_CURVEFS_CACHEMDSLEADER), ShouldBeTrue)
+
+		ContextName = "staging"
+		InitConfig()
+		So(ActiveContext(), ShouldEqual, "staging")
+		So(viper.GetString(VIPER_CURVEFS_MDSADDR), ShouldEqual, "10.0.1.1:6700")
+		ContextName = ""
+	})
+
+	Convey("the current context is set in config file", t, func() {
+		So(SetConfigFileValue(path, VIPER_CURRENT_CONTEXT, "staging"), ShouldBeNil)
+		So(SetConfigFileValue(path, "contexts.staging.curvefs.mdsDummyAddr", "10.0.1.1:7700"), ShouldBeNil)
+		data, err := os.ReadFile(path)
+		So(err, ShouldBeNil)
+		So(string(data), ShouldStartWith, "# clusters\ncurrentContext: staging\n")
+		So(string(data), ShouldContainSubstring, "mdsAddr: 10.0.0.1:6700 # the leader\n")
+		So(string(data), ShouldContainSubstring, "      mdsDummyAddr: 10.0.1.1:7700\n")
+
+		InitConfig()
+		So(ActiveContext(), ShouldEqual, "staging")
+		So(viper.GetString(VIPER_CURVEFS_MDSDUMMYADDR), ShouldEqual, "10.0.1.1:7700")
+
+		So(SetConfigFileValue(path, "curvefs.mdsAddr.x", "y"), ShouldNotBeNil)
+	})
+
+	Convey("the file is created if it does not exist", t, func() {
+		newPath := filepath.Join(t.TempDir(), "dir", "curve.yaml")
+		So(SetConfigFileValue(newPath, VIPER_CURRENT_CONTEXT, "prod"), ShouldBeNil)
+		data, err := os.ReadFile(newPath)
+		So(err, ShouldBeNil)
+		So(string(data), ShouldEqual, "currentContext: prod\n")
+	})
+}
+
+func TestContextNotFound(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "curve.yaml")
+	if err := os.WriteFile(path, []byte(contextConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		viper.Reset()
+		ConfPath, ContextName = "", ""
+		activeContext, contextErr = "", nil
+	}()
+
+	Convey("the top-level values are used if the context is not found", t, func() {
+		ConfPath, ContextName = path, "test"
+		InitConfig()
+		So(ContextError(), ShouldNotBeNil)
+		So(ActiveContext(), ShouldBeEmpty)
+		So(viper.GetString(VIPER_CURVEFS_MDSADDR), ShouldEqual, "127.0.0.1:6700")
+	})
+}
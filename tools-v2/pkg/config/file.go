@@ -0,0 +1,130 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// GetConfigFilePath returns the config file in use, or the one given by --conf,
+// default is $HOME/.curve/curve.yaml
+func GetConfigFilePath() (string, error) {
+	if path := viper.ConfigFileUsed(); path != "" {
+		return path, nil
+	}
+	return GetUserConfigFilePath()
+}
+
+// GetUserConfigFilePath returns the config file given by --conf,
+// default is $HOME/.curve/curve.yaml even if /etc/curve/curve.yaml is in use
+func GetUserConfigFilePath() (string, error) {
+	if ConfPath != "" {
+		return ConfPath, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".curve", "curve.yaml"), nil
+}
+
+// SetConfigFileValue sets the key like curvefs.mdsAddr in the yaml file,
+// the other contents and comments in it are kept
+func SetConfigFileValue(path string, key string, value interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if doc.Kind == 0 {
+		doc.Kind = yaml.DocumentNode
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	var valueNode yaml.Node
+	if err := valueNode.Encode(value); err != nil {
+		return err
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("invalid config file %s: it is not a map", path)
+	}
+	if err := setYamlValue(doc.Content[0], strings.Split(key, "."), &valueNode); err != nil {
+		return fmt.Errorf("failed to set %s in %s: %w", key, path, err)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	return WriteConfigFile(path, buf.Bytes())
+}
+
+// WriteConfigFile writes the config file, the new one is only accessible by
+// the user as it may keep the secrets, e.g. curvefs.s3.sk
+func WriteConfigFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// the keys are matched case-insensitively like viper,
+// the missing ones are created
+func setYamlValue(node *yaml.Node, keys []string, value *yaml.Node) error {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !strings.EqualFold(node.Content[i].Value, keys[0]) {
+			continue
+		}
+		if len(keys) == 1 {
+			old := node.Content[i+1]
+			value.HeadComment, value.LineComment, value.FootComment = old.HeadComment, old.LineComment, old.FootComment
+			node.Content[i+1] = value
+			return nil
+		}
+		if node.Content[i+1].Kind != yaml.MappingNode {
+			return fmt.Errorf("%s is not a map", node.Content[i].Value)
+		}
+		return setYamlValue(node.Content[i+1], keys[1:], value)
+	}
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keys[0]}
+	if len(keys) == 1 {
+		node.Content = append(node.Content, keyNode, value)
+		return nil
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	node.Content = append(node.Content, keyNode, child)
+	return setYamlValue(child, keys[1:], value)
+}
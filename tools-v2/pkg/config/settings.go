@@ -0,0 +1,229 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+package config
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	cobrautil "github.com/opencurve/curve/tools-v2/internal/utils"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// where the effective value of a key comes from, in the order of precedence
+const (
+	SOURCE_FLAG    = "flag"
+	SOURCE_ENV     = "env"
+	SOURCE_FILE    = "file"
+	SOURCE_DEFAULT = "default"
+)
+
+//go:embed template.yaml
+var templateConfig []byte
+
+var (
+	// the flags of root command which are bound to viper
+	PFLAG2VIPER = map[string]string{
+		SHOWERROR:   VIPER_GLOBALE_SHOWERROR,
+		NOHEADERS:   VIPER_GLOBALE_NOHEADERS,
+		COLUMNS:     VIPER_GLOBALE_COLUMNS,
+		SORTBY:      VIPER_GLOBALE_SORTBY,
+		FILTER:      VIPER_GLOBALE_FILTER,
+		WATCH:       VIPER_GLOBALE_WATCH,
+		CMDTIMEOUT:  VIPER_GLOBALE_CMDTIMEOUT,
+		HTTPTIMEOUT: VIPER_GLOBALE_HTTPTIMEOUT,
+		RECORD:      VIPER_GLOBALE_RECORD,
+		REPLAY:      VIPER_GLOBALE_REPLAY,
+		VERBOSE:     VIPER_GLOBALE_VERBOSE,
+		TRACEFILE:   VIPER_GLOBALE_TRACEFILE,
+	}
+
+	// the keys whose value is a duration, e.g. 500ms
+	durationKeys = []string{
+		VIPER_GLOBALE_HTTPTIMEOUT,
+		VIPER_GLOBALE_RPCTIMEOUT,
+		VIPER_GLOBALE_CONNIDLETIMEOUT,
+		VIPER_GLOBALE_CMDTIMEOUT,
+		VIPER_GLOBALE_WATCH,
+	}
+)
+
+// TemplateConfig returns the content of template.yaml
+func TemplateConfig() []byte {
+	return templateConfig
+}
+
+// KnownKeys returns the sorted keys which can be set in config file,
+// they are the ones in template.yaml and the ones bound to flags
+func KnownKeys() []string {
+	keys := make(map[string]string)
+	add := func(key string) {
+		if _, ok := keys[strings.ToLower(key)]; !ok {
+			keys[strings.ToLower(key)] = key
+		}
+	}
+	for _, key := range FLAG2VIPER {
+		add(key)
+	}
+	for _, key := range PFLAG2VIPER {
+		add(key)
+	}
+	var template map[string]interface{}
+	if err := yaml.Unmarshal(templateConfig, &template); err == nil {
+		for _, key := range flattenKeys("", template) {
+			add(key)
+		}
+	}
+	ret := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ret = append(ret, key)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return strings.ToLower(ret[i]) < strings.ToLower(ret[j])
+	})
+	return ret
+}
+
+func flattenKeys(prefix string, settings map[string]interface{}) []string {
+	var keys []string
+	for key, value := range settings {
+		if child, ok := value.(map[string]interface{}); ok {
+			keys = append(keys, flattenKeys(prefix+key+".", child)...)
+		} else {
+			keys = append(keys, prefix+key)
+		}
+	}
+	return keys
+}
+
+// SettingKey returns the known key of key in config file, the prefix of
+// context is removed, e.g. curvefs.mdsAddr for contexts.prod.curvefs.mdsaddr
+func SettingKey(key string) (string, bool) {
+	if strings.EqualFold(key, VIPER_CURRENT_CONTEXT) {
+		return VIPER_CURRENT_CONTEXT, true
+	}
+	parts := strings.SplitN(key, ".", 3)
+	if len(parts) == 3 && strings.EqualFold(parts[0], VIPER_CONTEXTS) {
+		key = parts[2]
+	}
+	for _, known := range KnownKeys() {
+		if strings.EqualFold(known, key) {
+			return known, true
+		}
+	}
+	return key, false
+}
+
+// GetValueSource returns where the effective value of key comes from,
+// the flags of cmd, the environment variables, config file or default
+func GetValueSource(cmd *cobra.Command, key string) string {
+	source := ""
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		flagKey, ok := FLAG2VIPER[flag.Name]
+		if !ok {
+			flagKey = PFLAG2VIPER[flag.Name]
+		}
+		if strings.EqualFold(flagKey, key) {
+			source = SOURCE_FLAG
+		}
+	})
+	if source != "" {
+		return source
+	}
+	if value, ok := os.LookupEnv(EnvName(key)); ok && value != "" {
+		return SOURCE_ENV
+	}
+	if viper.InConfig(key) {
+		return SOURCE_FILE
+	}
+	return SOURCE_DEFAULT
+}
+
+// ValidateValue checks the value of the known key,
+// e.g. the addresses and the durations
+func ValidateValue(key string, value interface{}) error {
+	if strings.HasSuffix(strings.ToLower(key), "addr") {
+		addrs := cast.ToString(value)
+		if addrs == "" {
+			return nil
+		}
+		for _, addr := range strings.Split(addrs, ",") {
+			if strings.ContainsAny(addr, " \t") || !cobrautil.IsValidAddr(addr) {
+				return fmt.Errorf("invalid address %q, it should be like 127.0.0.1:6700,127.0.0.1:6701", addr)
+			}
+		}
+		return nil
+	}
+	for _, durationKey := range durationKeys {
+		if !strings.EqualFold(key, durationKey) {
+			continue
+		}
+		if _, err := cast.ToDurationE(value); err != nil {
+			return fmt.Errorf("invalid duration %v, it should be like 500ms or 10s", value)
+		}
+	}
+	return nil
+}
+
+// ConfigProblem is a problem of the key in config file
+type ConfigProblem struct {
+	Key     string `json:"key"`
+	Problem string `json:"problem"`
+}
+
+// ValidateConfigFile checks the unknown keys, the values of the known
+// keys and the current context in config file
+func ValidateConfigFile(path string) ([]ConfigProblem, error) {
+	fileViper := viper.New()
+	fileViper.SetConfigFile(path)
+	fileViper.SetConfigType("yaml")
+	if err := fileViper.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	problems := make([]ConfigProblem, 0)
+	keys := fileViper.AllKeys()
+	sort.Strings(keys)
+	for _, key := range keys {
+		known, ok := SettingKey(key)
+		if !ok {
+			problems = append(problems, ConfigProblem{Key: key, Problem: "unknown key"})
+			continue
+		}
+		if err := ValidateValue(known, fileViper.Get(key)); err != nil {
+			problems = append(problems, ConfigProblem{Key: key, Problem: err.Error()})
+		}
+	}
+	if name := fileViper.GetString(VIPER_CURRENT_CONTEXT); name != "" &&
+		!fileViper.IsSet(VIPER_CONTEXTS+"."+name) {
+		problems = append(problems, ConfigProblem{
+			Key:     VIPER_CURRENT_CONTEXT,
+			Problem: fmt.Sprintf("context %s is not found", name),
+		})
+	}
+	return problems, nil
+}
@@ -0,0 +1,154 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestKnownKeys(t *testing.T) {
+	Convey("the keys in template and bound to flags are known", t, func() {
+		keys := KnownKeys()
+		So(keys, ShouldContain, VIPER_CURVEFS_MDSADDR)
+		So(keys, ShouldContain, VIPER_GLOBALE_WATCH)
+		So(keys, ShouldContain, "global.maxChannelSize")
+		So(keys, ShouldContain, "curvefs.s3.sk")
+
+		key, ok := SettingKey("contexts.prod.curvefs.mdsaddr")
+		So(ok, ShouldBeTrue)
+		So(key, ShouldEqual, VIPER_CURVEFS_MDSADDR)
+		_, ok = SettingKey("curvefs.mdsaddrs")
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("the template is valid", t, func() {
+		path := filepath.Join(t.TempDir(), "curve.yaml")
+		So(WriteConfigFile(path, TemplateConfig()), ShouldBeNil)
+		problems, err := ValidateConfigFile(path)
+		So(err, ShouldBeNil)
+		So(problems, ShouldBeEmpty)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	Convey("the values are checked", t, func() {
+		So(ValidateValue(VIPER_CURVEFS_MDSADDR, "127.0.0.1:6700,127.0.0.1:6701"), ShouldBeNil)
+		So(ValidateValue(VIPER_CURVEFS_MDSADDR, "127.0.0.1:6700 127.0.0.1:6701"), ShouldNotBeNil)
+		So(ValidateValue(VIPER_CURVEBS_MDSDUMMYADDR, "localhost"), ShouldNotBeNil)
+		So(ValidateValue(VIPER_GLOBALE_RPCTIMEOUT, "500ms"), ShouldBeNil)
+		So(ValidateValue(VIPER_GLOBALE_RPCTIMEOUT, "5 seconds"), ShouldNotBeNil)
+		So(ValidateValue(VIPER_CURVEFS_FSNAME, "5 seconds"), ShouldBeNil)
+	})
+
+	Convey("the problems of config file", t, func() {
+		path := filepath.Join(t.TempDir(), "curve.yaml")
+		content := `currentContext: test
+global:
+  rpcTimeout: 1x
+  unknown: 1
+curvefs:
+  mdsAddr: 127.0.0.1:6700
+contexts:
+  prod:
+    curvefs:
+      mdsAddr: localhost:6700
+`
+		So(os.WriteFile(path, []byte(content), 0600), ShouldBeNil)
+		problems, err := ValidateConfigFile(path)
+		So(err, ShouldBeNil)
+		So(problems, ShouldHaveLength, 4)
+		So(problems[0].Key, ShouldEqual, "contexts.prod.curvefs.mdsaddr")
+		So(problems[1].Key, ShouldEqual, "global.rpctimeout")
+		So(problems[2], ShouldResemble, ConfigProblem{Key: "global.unknown", Problem: "unknown key"})
+		So(problems[3].Key, ShouldEqual, VIPER_CURRENT_CONTEXT)
+	})
+}
+
+func TestValueSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "curve.yaml")
+	if err := os.WriteFile(path, []byte("curvefs:\n  mdsAddr: 127.0.0.1:6700\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		viper.Reset()
+		ConfPath = ""
+	}()
+
+	Convey("the source of the effective value", t, func() {
+		ConfPath = path
+		InitConfig()
+		cmd := &cobra.Command{Use: "test", Run: func(cmd *cobra.Command, args []string) {}}
+		AddRpcTimeoutFlag(cmd)
+		AddFsMdsDummyAddrFlag(cmd)
+		cmd.SetArgs([]string{"--rpctimeout", "1s"})
+		So(cmd.Execute(), ShouldBeNil)
+
+		So(GetValueSource(cmd, VIPER_GLOBALE_RPCTIMEOUT), ShouldEqual, SOURCE_FLAG)
+		So(GetValueSource(cmd, VIPER_CURVEFS_MDSADDR), ShouldEqual, SOURCE_FILE)
+		So(GetValueSource(cmd, VIPER_CURVEFS_MDSDUMMYADDR), ShouldEqual, SOURCE_DEFAULT)
+	})
+}
+
+func TestEnvOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "curve.yaml")
+	content := "global:\n  rpcTimeout: 1s\ncurvefs:\n  mdsAddr: 127.0.0.1:6700\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CURVE_CURVEFS_MDSADDR", "127.0.0.1:6800,127.0.0.1:6801")
+	t.Setenv("CURVE_GLOBAL_RPCTIMEOUT", "3s")
+	t.Setenv("CURVE_CURVEFS_MDSDUMMYADDR", "127.0.0.1:7800")
+	defer func() {
+		viper.Reset()
+		ConfPath = ""
+	}()
+
+	Convey("the environment variables override the config file", t, func() {
+		So(EnvName(VIPER_GLOBALE_RPCTIMEOUT), ShouldEqual, "CURVE_GLOBAL_RPCTIMEOUT")
+		ConfPath = path
+		InitConfig()
+		cmd := &cobra.Command{Use: "test", Run: func(cmd *cobra.Command, args []string) {}}
+		AddRpcTimeoutFlag(cmd)
+		AddFsMdsAddrFlag(cmd)
+		AddFsMdsDummyAddrFlag(cmd)
+		cmd.SetArgs([]string{"--mdsdummyaddr", "127.0.0.1:7900"})
+		So(cmd.Execute(), ShouldBeNil)
+
+		addrs, err := GetFsMdsAddrSlice(cmd)
+		So(err.TypeCode(), ShouldEqual, cmderror.CODE_SUCCESS)
+		So(addrs, ShouldResemble, []string{"127.0.0.1:6800", "127.0.0.1:6801"})
+		So(GetValueSource(cmd, VIPER_CURVEFS_MDSADDR), ShouldEqual, SOURCE_ENV)
+		So(GetFlagDuration(cmd, RPCTIMEOUT), ShouldEqual, 3*time.Second)
+		So(GetValueSource(cmd, VIPER_GLOBALE_RPCTIMEOUT), ShouldEqual, SOURCE_ENV)
+		// the flag takes precedence
+		addrs, _ = GetFsMdsDummyAddrSlice(cmd)
+		So(addrs, ShouldResemble, []string{"127.0.0.1:7900"})
+		So(GetValueSource(cmd, VIPER_CURVEFS_MDSDUMMYADDR), ShouldEqual, SOURCE_FLAG)
+	})
+}
@@ -0,0 +1,546 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JsonPath is a kubectl style jsonpath template, such as:
+//
+//	{.result[*].fsId}
+//	{range .result[*]}{.fsName}{"\t"}{.status}{"\n"}{end}
+//	{.result[?(@.status=="online")].addr}
+//
+// It supports fields, ".." recursive descent, "*" wildcard, index, slice,
+// union and filter. The missing keys are ignored, the results of one
+// expression are separated by space.
+type JsonPath struct {
+	nodes []jsonPathNode
+}
+
+type jsonPathNode struct {
+	text  string // literal text
+	path  []jsonPathStep
+	isRef bool           // the node is a path
+	body  []jsonPathNode // the nodes in range
+	rng   bool
+}
+
+type jsonPathStepType int
+
+const (
+	stepField jsonPathStepType = iota
+	stepRecursive
+	stepWildcard
+	stepIndex
+	stepSlice
+	stepFilter
+)
+
+type jsonPathStep struct {
+	typ    jsonPathStepType
+	names  []string // stepField, stepRecursive
+	index  []int    // stepIndex
+	slice  [2]*int  // stepSlice
+	filter *jsonPathFilter
+}
+
+type jsonPathFilter struct {
+	path  []jsonPathStep
+	op    string // empty means the path exists
+	value interface{}
+}
+
+func ParseJsonPath(text string) (*JsonPath, error) {
+	nodes, rest, err := parseJsonPathNodes(text, false)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("jsonpath: unexpected {end}")
+	}
+	return &JsonPath{nodes: nodes}, nil
+}
+
+// parse until the end of text or {end} if inRange, return the text after it
+func parseJsonPathNodes(text string, inRange bool) ([]jsonPathNode, string, error) {
+	var nodes []jsonPathNode
+	for text != "" {
+		start := strings.IndexByte(text, '{')
+		if start < 0 {
+			nodes = append(nodes, jsonPathNode{text: text})
+			break
+		}
+		if start > 0 {
+			nodes = append(nodes, jsonPathNode{text: text[:start]})
+		}
+		end, err := matchBrace(text, start)
+		if err != nil {
+			return nil, "", err
+		}
+		expr := strings.TrimSpace(text[start+1 : end])
+		text = text[end+1:]
+		switch {
+		case expr == "end":
+			if !inRange {
+				return nil, "", fmt.Errorf("jsonpath: unexpected {end}")
+			}
+			return nodes, text, nil
+		case strings.HasPrefix(expr, "range "):
+			path, err := parseJsonPathSteps(strings.TrimSpace(expr[len("range "):]))
+			if err != nil {
+				return nil, "", err
+			}
+			var body []jsonPathNode
+			body, text, err = parseJsonPathNodes(text, true)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, jsonPathNode{path: path, rng: true, body: body})
+		case strings.HasPrefix(expr, "\""):
+			literal, err := strconv.Unquote(expr)
+			if err != nil {
+				return nil, "", fmt.Errorf("jsonpath: invalid string %s", expr)
+			}
+			nodes = append(nodes, jsonPathNode{text: literal})
+		default:
+			path, err := parseJsonPathSteps(expr)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, jsonPathNode{path: path, isRef: true})
+		}
+	}
+	if inRange {
+		return nil, "", fmt.Errorf("jsonpath: {range} is not closed by {end}")
+	}
+	return nodes, "", nil
+}
+
+// return the index of the '}' matching text[start]
+func matchBrace(text string, start int) (int, error) {
+	depth := 0
+	var quote byte
+	for i := start; i < len(text); i++ {
+		c := text[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("jsonpath: unclosed expression %s", text[start:])
+}
+
+func parseJsonPathSteps(expr string) ([]jsonPathStep, error) {
+	origin := expr
+	if strings.HasPrefix(expr, "$") || strings.HasPrefix(expr, "@") {
+		expr = expr[1:]
+	}
+	var steps []jsonPathStep
+	for expr != "" {
+		switch {
+		case strings.HasPrefix(expr, ".."):
+			name, rest := splitJsonPathName(expr[2:])
+			if name == "" {
+				return nil, fmt.Errorf("jsonpath: invalid expression %s", origin)
+			}
+			steps = append(steps, jsonPathStep{typ: stepRecursive, names: []string{name}})
+			expr = rest
+		case strings.HasPrefix(expr, ".*"):
+			steps = append(steps, jsonPathStep{typ: stepWildcard})
+			expr = expr[2:]
+		case expr[0] == '.':
+			name, rest := splitJsonPathName(expr[1:])
+			if name != "" {
+				steps = append(steps, jsonPathStep{typ: stepField, names: []string{name}})
+			}
+			expr = rest
+		case expr[0] == '[':
+			end := matchBracket(expr)
+			if end < 0 {
+				return nil, fmt.Errorf("jsonpath: unclosed [ in %s", origin)
+			}
+			step, err := parseJsonPathBracket(strings.TrimSpace(expr[1:end]))
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+			expr = expr[end+1:]
+		default:
+			return nil, fmt.Errorf("jsonpath: invalid expression %s", origin)
+		}
+	}
+	return steps, nil
+}
+
+func splitJsonPathName(expr string) (string, string) {
+	i := strings.IndexAny(expr, ".[")
+	if i < 0 {
+		return expr, ""
+	}
+	return expr[:i], expr[i:]
+}
+
+func matchBracket(expr string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseJsonPathBracket(expr string) (jsonPathStep, error) {
+	switch {
+	case expr == "*":
+		return jsonPathStep{typ: stepWildcard}, nil
+	case strings.HasPrefix(expr, "?(") && strings.HasSuffix(expr, ")"):
+		filter, err := parseJsonPathFilter(strings.TrimSpace(expr[2 : len(expr)-1]))
+		return jsonPathStep{typ: stepFilter, filter: filter}, err
+	case strings.HasPrefix(expr, "'") || strings.HasPrefix(expr, "\""):
+		var names []string
+		for _, name := range strings.Split(expr, ",") {
+			name = strings.TrimSpace(name)
+			if len(name) < 2 || name[0] != name[len(name)-1] {
+				return jsonPathStep{}, fmt.Errorf("jsonpath: invalid key %s", name)
+			}
+			names = append(names, name[1:len(name)-1])
+		}
+		return jsonPathStep{typ: stepField, names: names}, nil
+	case strings.Contains(expr, ":"):
+		var step jsonPathStep
+		step.typ = stepSlice
+		parts := strings.Split(expr, ":")
+		if len(parts) > 2 {
+			return step, fmt.Errorf("jsonpath: slice with step is not supported: %s", expr)
+		}
+		for i, part := range parts {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return step, fmt.Errorf("jsonpath: invalid slice %s", expr)
+			}
+			step.slice[i] = &n
+		}
+		return step, nil
+	default:
+		var step jsonPathStep
+		step.typ = stepIndex
+		for _, part := range strings.Split(expr, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return step, fmt.Errorf("jsonpath: invalid index %s", expr)
+			}
+			step.index = append(step.index, n)
+		}
+		return step, nil
+	}
+}
+
+func parseJsonPathFilter(expr string) (*jsonPathFilter, error) {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		i := strings.Index(expr, op)
+		if i < 0 {
+			continue
+		}
+		path, err := parseJsonPathSteps(strings.TrimSpace(expr[:i]))
+		if err != nil {
+			return nil, err
+		}
+		value, err := parseJsonPathLiteral(strings.TrimSpace(expr[i+len(op):]))
+		if err != nil {
+			return nil, err
+		}
+		return &jsonPathFilter{path: path, op: op, value: value}, nil
+	}
+	path, err := parseJsonPathSteps(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonPathFilter{path: path}, nil
+}
+
+func parseJsonPathLiteral(literal string) (interface{}, error) {
+	switch {
+	case literal == "true" || literal == "false":
+		return literal == "true", nil
+	case literal == "null":
+		return nil, nil
+	case strings.HasPrefix(literal, "'") && strings.HasSuffix(literal, "'") && len(literal) >= 2:
+		return literal[1 : len(literal)-1], nil
+	case strings.HasPrefix(literal, "\""):
+		return strconv.Unquote(literal)
+	}
+	n, err := strconv.ParseFloat(literal, 64)
+	if err != nil {
+		return nil, fmt.Errorf("jsonpath: invalid literal %s", literal)
+	}
+	return n, nil
+}
+
+// Execute writes the result of jp on data, which is decoded from json
+func (jp *JsonPath) Execute(w io.Writer, data interface{}) error {
+	return executeJsonPathNodes(w, jp.nodes, data)
+}
+
+func executeJsonPathNodes(w io.Writer, nodes []jsonPathNode, data interface{}) error {
+	for _, node := range nodes {
+		var err error
+		switch {
+		case node.rng:
+			for _, value := range evalJsonPath(node.path, data) {
+				if err = executeJsonPathNodes(w, node.body, value); err != nil {
+					break
+				}
+			}
+		case node.isRef:
+			var texts []string
+			for _, value := range evalJsonPath(node.path, data) {
+				text, errText := jsonPathText(value)
+				if errText != nil {
+					return errText
+				}
+				texts = append(texts, text)
+			}
+			_, err = io.WriteString(w, strings.Join(texts, " "))
+		default:
+			_, err = io.WriteString(w, node.text)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func jsonPathText(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case nil:
+		return "null", nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	text, err := json.Marshal(value)
+	return string(text), err
+}
+
+func evalJsonPath(steps []jsonPathStep, data interface{}) []interface{} {
+	values := []interface{}{data}
+	for _, step := range steps {
+		var next []interface{}
+		for _, value := range values {
+			next = append(next, evalJsonPathStep(step, value)...)
+		}
+		values = next
+	}
+	return values
+}
+
+func evalJsonPathStep(step jsonPathStep, value interface{}) []interface{} {
+	var ret []interface{}
+	switch step.typ {
+	case stepField:
+		if m, ok := value.(map[string]interface{}); ok {
+			for _, name := range step.names {
+				if v, ok := m[name]; ok {
+					ret = append(ret, v)
+				}
+			}
+		}
+	case stepRecursive:
+		ret = append(ret, evalJsonPathStep(jsonPathStep{typ: stepField, names: step.names}, value)...)
+		for _, child := range jsonPathChildren(value) {
+			ret = append(ret, evalJsonPathStep(step, child)...)
+		}
+	case stepWildcard:
+		ret = jsonPathChildren(value)
+	case stepIndex:
+		if a, ok := value.([]interface{}); ok {
+			for _, i := range step.index {
+				if i < 0 {
+					i += len(a)
+				}
+				if i >= 0 && i < len(a) {
+					ret = append(ret, a[i])
+				}
+			}
+		}
+	case stepSlice:
+		if a, ok := value.([]interface{}); ok {
+			start, end := 0, len(a)
+			if step.slice[0] != nil {
+				start = clampJsonPathIndex(*step.slice[0], len(a))
+			}
+			if step.slice[1] != nil {
+				end = clampJsonPathIndex(*step.slice[1], len(a))
+			}
+			if start < end {
+				ret = append(ret, a[start:end]...)
+			}
+		}
+	case stepFilter:
+		for _, child := range jsonPathChildren(value) {
+			if step.filter.match(child) {
+				ret = append(ret, child)
+			}
+		}
+	}
+	return ret
+}
+
+func clampJsonPathIndex(i int, length int) int {
+	if i < 0 {
+		i += length
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > length {
+		return length
+	}
+	return i
+}
+
+// the elements of array or the values of map sorted by key
+func jsonPathChildren(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		ret := make([]interface{}, 0, len(v))
+		for _, key := range keys {
+			ret = append(ret, v[key])
+		}
+		return ret
+	}
+	return nil
+}
+
+func (f *jsonPathFilter) match(value interface{}) bool {
+	for _, v := range evalJsonPath(f.path, value) {
+		if f.op == "" || compareJsonPath(v, f.op, f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+func compareJsonPath(left interface{}, op string, right interface{}) bool {
+	if n, ok := left.(json.Number); ok {
+		if r, ok := right.(float64); ok {
+			l, err := n.Float64()
+			if err != nil {
+				return false
+			}
+			switch op {
+			case "==":
+				return l == r
+			case "!=":
+				return l != r
+			case "<":
+				return l < r
+			case "<=":
+				return l <= r
+			case ">":
+				return l > r
+			case ">=":
+				return l >= r
+			}
+		}
+		left = n.String()
+	}
+	if l, ok := left.(string); ok {
+		if r, ok := right.(string); ok {
+			switch op {
+			case "==":
+				return l == r
+			case "!=":
+				return l != r
+			case "<":
+				return l < r
+			case "<=":
+				return l <= r
+			case ">":
+				return l > r
+			case ">=":
+				return l >= r
+			}
+		}
+	}
+	switch op {
+	case "==":
+		return left == right
+	case "!=":
+		return left != right
+	}
+	return false
+}
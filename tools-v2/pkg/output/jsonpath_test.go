@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/common"
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/protobuf/proto"
+)
+
+func execJsonPath(text string, document string) (string, error) {
+	jsonPath, err := ParseJsonPath(text)
+	if err != nil {
+		return "", err
+	}
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+	var data interface{}
+	So(decoder.Decode(&data), ShouldBeNil)
+	var buf bytes.Buffer
+	err = jsonPath.Execute(&buf, data)
+	return buf.String(), err
+}
+
+func TestJsonPath(t *testing.T) {
+	Convey("TestJsonPath", t, func() {
+		document := `{"result": [
+			{"fsId": 1, "fsName": "fs1", "status": "online", "addr": {"host": "127.0.0.1", "port": 6700}},
+			{"fsId": 2, "fsName": "fs2", "status": "offline", "addr": {"host": "127.0.0.2", "port": 6701}},
+			{"fsId": 12345678901234567890, "fsName": "fs3", "status": "online"}
+		], "exitCode": 0}`
+		cases := []struct {
+			path string
+			want string
+		}{
+			{"{.exitCode}", "0"},
+			{"{.result[*].fsId}", "1 2 12345678901234567890"},
+			{"{$.result[0].fsName}", "fs1"},
+			{"{.result[-1].fsName}", "fs3"},
+			{"{.result[0,2].fsName}", "fs1 fs3"},
+			{"{.result[1:].fsName}", "fs2 fs3"},
+			{"{.result[0]['fsName','status']}", "fs1 online"},
+			{"{.result[0].addr.*}", "127.0.0.1 6700"},
+			{"{..port}", "6700 6701"},
+			{"{.result[0].addr}", `{"host":"127.0.0.1","port":6700}`},
+			{"{.result[0].missing}", ""},
+			{`{.result[?(@.status=="offline")].fsName}`, "fs2"},
+			{`{.result[?(@.fsId>1)].fsName}`, "fs2 fs3"},
+			{`{.result[?(@.addr)].fsName}`, "fs1 fs2"},
+			{`fs: {range .result[*]}{.fsName}{"\t"}{.status}{"\n"}{end}`, "fs: fs1\tonline\nfs2\toffline\nfs3\tonline\n"},
+		}
+		for _, c := range cases {
+			got, err := execJsonPath(c.path, document)
+			So(err, ShouldBeNil)
+			So(got, ShouldEqual, c.want)
+		}
+
+		for _, path := range []string{"{.result", "{range .result[*]}{.fsName}", "{end}", "{.result[a]}", "{result}"} {
+			_, err := execJsonPath(path, document)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+func TestFinalCmdOutputTemplateAndJsonPath(t *testing.T) {
+	Convey("template and jsonpath on the result from proto", t, func() {
+		finalCmd, out := newTestFinalCmd()
+		peer := &common.Peer{
+			Id:      proto.Uint64(1 << 40),
+			Address: proto.String("127.0.0.1:6800:0"),
+		}
+		result, err := MarshalProtoJson(peer)
+		So(err, ShouldBeNil)
+		finalCmd.Result = []interface{}{result}
+
+		Convey("template", func() {
+			So(FinalCmdOutputTemplate(finalCmd, `{{range .result}}{{.id}} {{.address}}{{end}}`), ShouldBeNil)
+			So(out.String(), ShouldEqual, "1099511627776 127.0.0.1:6800:0")
+		})
+
+		Convey("jsonpath", func() {
+			So(FinalCmdOutputJsonPath(finalCmd, `{.result[*].address}`), ShouldBeNil)
+			So(out.String(), ShouldEqual, "127.0.0.1:6800:0")
+		})
+
+		Convey("empty", func() {
+			So(FinalCmdOutputTemplate(finalCmd, ""), ShouldNotBeNil)
+			So(FinalCmdOutputJsonPath(finalCmd, ""), ShouldNotBeNil)
+		})
+	})
+}
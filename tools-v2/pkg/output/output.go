@@ -23,61 +23,233 @@
 package output
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"strings"
+	"text/template"
 
 	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	cobrautil "github.com/opencurve/curve/tools-v2/internal/utils"
 	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
 	"github.com/opencurve/curve/tools-v2/pkg/config"
 	"github.com/spf13/viper"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
 )
 
+// the document printed by json and yaml format
+func finalCmdDocument(finalCmd *basecmd.FinalCurveCmd) interface{} {
+	if viper.GetBool(config.VIPER_GLOBALE_SHOWERROR) {
+		return struct {
+			*basecmd.FinalCurveCmd
+			Errors []cmderror.ErrorEntry `json:"errors"`
+		}{finalCmd, finalCmd.ErrorEntries()}
+	}
+	return finalCmd
+}
+
 func FinalCmdOutputJson(finalCmd *basecmd.FinalCurveCmd) error {
-	output, err := json.MarshalIndent(finalCmd, "", "  ")
+	output, err := json.MarshalIndent(finalCmdDocument(finalCmd), "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(finalCmd.Cmd.OutOrStdout(), string(output))
+	return nil
+}
+
+// FinalCmdOutputYaml prints the same document as json format,
+// the keys are in the same order as json.
+func FinalCmdOutputYaml(finalCmd *basecmd.FinalCurveCmd) error {
+	output, err := JsonToYaml(finalCmdDocument(finalCmd))
 	if err != nil {
 		return err
 	}
-	fmt.Println(string(output))
+	fmt.Fprint(finalCmd.Cmd.OutOrStdout(), string(output))
 	return nil
 }
 
+// JsonToYaml marshals value by the json tags and converts it to yaml
+func JsonToYaml(value interface{}) ([]byte, error) {
+	jsonByte, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	// json is a subset of yaml, parse it into node to keep the order of keys
+	var node yaml.Node
+	err = yaml.Unmarshal(jsonByte, &node)
+	if err != nil {
+		return nil, err
+	}
+	clearYamlStyle(&node)
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	err = encoder.Encode(&node)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Close()
+	return buf.Bytes(), err
+}
+
+// use the block style instead of the flow style of json,
+// the strings will be quoted again if needed
+func clearYamlStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYamlStyle(child)
+	}
+}
+
+// the document decoded from json, the numbers are kept as json.Number
+func finalCmdGenericDocument(finalCmd *basecmd.FinalCurveCmd) (interface{}, error) {
+	jsonByte, err := json.Marshal(finalCmdDocument(finalCmd))
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonByte))
+	decoder.UseNumber()
+	var ret interface{}
+	err = decoder.Decode(&ret)
+	return ret, err
+}
+
+// FinalCmdOutputTemplate executes the go template on the document of json format,
+// e.g. {{range .result}}{{.fsName}}{{"\n"}}{{end}}
+func FinalCmdOutputTemplate(finalCmd *basecmd.FinalCurveCmd, text string) error {
+	if text == "" {
+		return fmt.Errorf("template format should be like %s=<template>", config.FORMAT_TEMPLATE)
+	}
+	tmpl, err := template.New(config.FORMAT_TEMPLATE).Parse(text)
+	if err != nil {
+		return err
+	}
+	document, err := finalCmdGenericDocument(finalCmd)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(finalCmd.Cmd.OutOrStdout(), document)
+}
+
+// FinalCmdOutputJsonPath executes the jsonpath on the document of json format,
+// e.g. {.result[*].fsId}
+func FinalCmdOutputJsonPath(finalCmd *basecmd.FinalCurveCmd, text string) error {
+	if text == "" {
+		return fmt.Errorf("jsonpath format should be like %s=<jsonpath>", config.FORMAT_JSONPATH)
+	}
+	jsonPath, err := ParseJsonPath(text)
+	if err != nil {
+		return err
+	}
+	document, err := finalCmdGenericDocument(finalCmd)
+	if err != nil {
+		return err
+	}
+	return jsonPath.Execute(finalCmd.Cmd.OutOrStdout(), document)
+}
+
+// FinalCmdOutputSeparated prints finalCmd.Table as csv or tsv,
+// the fields are quoted as RFC 4180 if needed.
+func FinalCmdOutputSeparated(finalCmd *basecmd.FinalCurveCmd, comma rune) error {
+	if finalCmd.Table == nil {
+		return fmt.Errorf("the command has no table to output")
+	}
+	writer := csv.NewWriter(finalCmd.Cmd.OutOrStdout())
+	writer.Comma = comma
+	columns := finalCmd.Table.GetColumns()
+	if !viper.GetBool(config.VIPER_GLOBALE_NOHEADERS) {
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+	}
+	for _, row := range finalCmd.Table.GetValues() {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = row[column]
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
 func FinalCmdOutputPlain(finalCmd *basecmd.FinalCurveCmd,
 	funcs basecmd.FinalCurveCmdFunc) error {
 	if len(finalCmd.Table.Row) > 0 {
-		fmt.Println(finalCmd.Table)
+		fmt.Fprint(finalCmd.Cmd.OutOrStdout(), TableStringChanged(finalCmd.Table, finalCmd.LastTable))
 	}
 	if finalCmd.Error.Code != cmderror.CODE_SUCCESS {
 		// result error
 		// do not show how to use the command
 		finalCmd.Cmd.SilenceUsage = true
-		return errors.New(finalCmd.Error.Message)
+		return finalCmd.Error.ToError()
 	}
 	return nil
 }
 
+// apply --columns, --sort-by and --filter to the table, the result is
+// replaced by the rows of the table then, so that the json and yaml output
+// honor them too instead of the whole result of the command
+func applyTableOption(finalCmd *basecmd.FinalCurveCmd) error {
+	option, err := GetTableOption()
+	if err != nil || option.Empty() {
+		return err
+	}
+	if finalCmd.Table == nil {
+		return fmt.Errorf("the command has no table to apply --%s, --%s or --%s",
+			config.COLUMNS, config.SORTBY, config.FILTER)
+	}
+	finalCmd.Table, err = option.Apply(finalCmd.Table)
+	if err != nil {
+		return err
+	}
+	finalCmd.Result, err = cobrautil.TableToResult(finalCmd.Table)
+	return err
+}
+
 func FinalCmdOutput(finalCmd *basecmd.FinalCurveCmd,
 	funcs basecmd.FinalCurveCmdFunc) error {
 	format := finalCmd.Cmd.Flag("format").Value.String()
-	var err error
-	switch format {
+	finalCmd.ExitCode = cmderror.EXIT_SUCCESS
+	if finalCmd.Error != nil {
+		finalCmd.ExitCode = finalCmd.Error.ExitCode()
+	}
+	formatName, formatArg, _ := strings.Cut(format, "=")
+	if formatName == config.FORMAT_NOOUT {
+		return nil
+	}
+	err := applyTableOption(finalCmd)
+	if err != nil {
+		return err
+	}
+	switch formatName {
 	case config.FORMAT_JSON:
 		err = FinalCmdOutputJson(finalCmd)
 	case config.FORMAT_PLAIN:
 		err = funcs.ResultPlainOutput()
-	case config.FORMAT_NOOUT:
-		err = nil
+		finalCmd.ShowErrors()
+	case config.FORMAT_YAML:
+		err = FinalCmdOutputYaml(finalCmd)
+	case config.FORMAT_CSV:
+		err = FinalCmdOutputSeparated(finalCmd, ',')
+	case config.FORMAT_TSV:
+		err = FinalCmdOutputSeparated(finalCmd, '\t')
+	case config.FORMAT_TEMPLATE:
+		err = FinalCmdOutputTemplate(finalCmd, formatArg)
+	case config.FORMAT_JSONPATH:
+		err = FinalCmdOutputJsonPath(finalCmd, formatArg)
 	default:
 		err = fmt.Errorf("the output format %s is not recognized", format)
 	}
-	if viper.GetBool(config.VIPER_GLOBALE_SHOWERROR) {
-		for _, output := range cmderror.AllError {
-			if output.TypeCode() != cmderror.CODE_SUCCESS {
-				fmt.Printf("%+v\n", *output)
-			}
-		}
+	if err == nil && finalCmd.ExitCode != cmderror.EXIT_SUCCESS {
+		// the result is printed, exit with the error of it
+		finalCmd.Cmd.SilenceUsage = true
+		err = finalCmd.Error.ToError()
 	}
 	return err
 }
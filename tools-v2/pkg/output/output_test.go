@@ -0,0 +1,94 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/liushuochen/gotable"
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	basecmd "github.com/opencurve/curve/tools-v2/pkg/cli/command"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func newTestFinalCmd() (*basecmd.FinalCurveCmd, *bytes.Buffer) {
+	finalCmd := &basecmd.FinalCurveCmd{
+		Cmd:   &cobra.Command{},
+		Error: cmderror.ErrSuccess(),
+	}
+	out := &bytes.Buffer{}
+	finalCmd.Cmd.SetOut(out)
+	return finalCmd, out
+}
+
+func TestFinalCmdOutputYaml(t *testing.T) {
+	Convey("FinalCmdOutputYaml", t, func() {
+		finalCmd, out := newTestFinalCmd()
+		finalCmd.Result = []map[string]interface{}{
+			{"fsName": "test1", "fsId": 1, "status": "123", "detail": map[string]interface{}{"mounted": true}},
+		}
+		So(FinalCmdOutputYaml(finalCmd), ShouldBeNil)
+		So(out.String(), ShouldEqual, `error:
+  code: 0
+  message: success
+result:
+  - detail:
+      mounted: true
+    fsId: 1
+    fsName: test1
+    status: "123"
+exitCode: 0
+`)
+	})
+}
+
+func TestFinalCmdOutputSeparated(t *testing.T) {
+	Convey("FinalCmdOutputSeparated", t, func() {
+		finalCmd, out := newTestFinalCmd()
+		table, err := gotable.Create("name", "status")
+		So(err, ShouldBeNil)
+		table.AddRow(map[string]string{"name": "fs,1", "status": "say \"hi\""})
+		table.AddRow(map[string]string{"name": "fs\t2", "status": "online"})
+		finalCmd.Table = table
+
+		Convey("csv", func() {
+			So(FinalCmdOutputSeparated(finalCmd, ','), ShouldBeNil)
+			So(out.String(), ShouldEqual, "name,status\n\"fs,1\",\"say \"\"hi\"\"\"\nfs\t2,online\n")
+		})
+
+		Convey("tsv without headers", func() {
+			viper.Set(config.VIPER_GLOBALE_NOHEADERS, true)
+			defer viper.Set(config.VIPER_GLOBALE_NOHEADERS, false)
+			So(FinalCmdOutputSeparated(finalCmd, '\t'), ShouldBeNil)
+			So(out.String(), ShouldEqual, "fs,1\t\"say \"\"hi\"\"\"\n\"fs\t2\"\tonline\n")
+		})
+
+		Convey("no table", func() {
+			finalCmd.Table = nil
+			So(FinalCmdOutputSeparated(finalCmd, ','), ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,254 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/liushuochen/gotable"
+	"github.com/liushuochen/gotable/table"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	"github.com/spf13/viper"
+)
+
+// TableOption is how to show the table, it comes from
+// --columns, --sort-by and --filter
+type TableOption struct {
+	Columns []string
+	SortBy  string
+	Desc    bool
+	Filters []TableFilter
+}
+
+// TableFilter matches the rows whose Column equals (or not equals) Value
+type TableFilter struct {
+	Column string
+	Value  string
+	Not    bool
+}
+
+func GetTableOption() (*TableOption, error) {
+	option := &TableOption{}
+	for _, column := range strings.Split(viper.GetString(config.VIPER_GLOBALE_COLUMNS), ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			option.Columns = append(option.Columns, column)
+		}
+	}
+	option.SortBy = strings.TrimSpace(viper.GetString(config.VIPER_GLOBALE_SORTBY))
+	if strings.HasPrefix(option.SortBy, "-") {
+		option.SortBy = option.SortBy[1:]
+		option.Desc = true
+	}
+	for _, cond := range strings.Split(viper.GetString(config.VIPER_GLOBALE_FILTER), ",") {
+		if cond = strings.TrimSpace(cond); cond == "" {
+			continue
+		}
+		filter, err := parseTableFilter(cond)
+		if err != nil {
+			return nil, err
+		}
+		option.Filters = append(option.Filters, filter)
+	}
+	return option, nil
+}
+
+func parseTableFilter(cond string) (TableFilter, error) {
+	var filter TableFilter
+	for _, op := range []string{"!=", "==", "="} {
+		column, value, found := strings.Cut(cond, op)
+		if found {
+			filter.Column = strings.TrimSpace(column)
+			filter.Value = strings.TrimSpace(value)
+			filter.Not = op == "!="
+			return filter, nil
+		}
+	}
+	return filter, fmt.Errorf("invalid filter %s, it should be like column=value or column!=value", cond)
+}
+
+// Empty reports whether the table is shown as it is
+func (option *TableOption) Empty() bool {
+	return len(option.Columns) == 0 && option.SortBy == "" && len(option.Filters) == 0
+}
+
+// the column of t matching name case-insensitively
+func findColumn(t *table.Table, name string) (string, error) {
+	columns := t.GetColumns()
+	for _, column := range columns {
+		if strings.EqualFold(column, name) {
+			return column, nil
+		}
+	}
+	return "", fmt.Errorf("column %s is not found, the columns are: %s", name, strings.Join(columns, ","))
+}
+
+// Apply returns a new table with the columns, filters and order of option
+func (option *TableOption) Apply(t *table.Table) (*table.Table, error) {
+	if t == nil || option.Empty() {
+		return t, nil
+	}
+	columns := t.GetColumns()
+	if len(option.Columns) > 0 {
+		columns = nil
+		for _, name := range option.Columns {
+			column, err := findColumn(t, name)
+			if err != nil {
+				return nil, err
+			}
+			columns = append(columns, column)
+		}
+	}
+	for i := range option.Filters {
+		column, err := findColumn(t, option.Filters[i].Column)
+		if err != nil {
+			return nil, err
+		}
+		option.Filters[i].Column = column
+	}
+	sortBy := ""
+	if option.SortBy != "" {
+		column, err := findColumn(t, option.SortBy)
+		if err != nil {
+			return nil, err
+		}
+		sortBy = column
+	}
+
+	var rows []map[string]string
+	for _, row := range t.GetValues() {
+		if option.match(row) {
+			rows = append(rows, row)
+		}
+	}
+	if sortBy != "" {
+		sort.SliceStable(rows, func(i, j int) bool {
+			if option.Desc {
+				return lessCell(rows[j][sortBy], rows[i][sortBy])
+			}
+			return lessCell(rows[i][sortBy], rows[j][sortBy])
+		})
+	}
+
+	ret, err := gotable.Create(columns...)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		selected := make(map[string]string, len(columns))
+		for _, column := range columns {
+			selected[column] = row[column]
+		}
+		ret.AddRow(selected)
+	}
+	return ret, nil
+}
+
+func (option *TableOption) match(row map[string]string) bool {
+	for _, filter := range option.Filters {
+		if (row[filter.Column] == filter.Value) == filter.Not {
+			return false
+		}
+	}
+	return true
+}
+
+// compare as numbers if both are numbers
+func lessCell(a string, b string) bool {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return fa < fb
+	}
+	return a < b
+}
+
+// TableString is the plain text of t, the header is removed by --no-headers
+func TableString(t *table.Table) string {
+	text := t.String()
+	if !viper.GetBool(config.VIPER_GLOBALE_NOHEADERS) {
+		return text
+	}
+	// the border, the header and the border under header
+	lines := strings.SplitAfter(text, "\n")
+	if len(lines) < 3 {
+		return text
+	}
+	return lines[0] + strings.Join(lines[3:], "")
+}
+
+const (
+	ANSI_REVERSE = "\x1b[7m"
+	ANSI_RESET   = "\x1b[0m"
+)
+
+// TableStringChanged is the plain text of t whose rows are highlighted
+// if they are new or changed since last, the rows are matched by the first column.
+func TableStringChanged(t *table.Table, last *table.Table) string {
+	text := TableString(t)
+	if last == nil {
+		return text
+	}
+	columns := t.GetColumns()
+	if len(columns) == 0 {
+		return text
+	}
+	lastRows := make(map[string][]map[string]string)
+	for _, row := range last.GetValues() {
+		key := row[columns[0]]
+		lastRows[key] = append(lastRows[key], row)
+	}
+	// the border, the header and the border under header
+	lines := strings.SplitAfter(text, "\n")
+	offset := 3
+	if viper.GetBool(config.VIPER_GLOBALE_NOHEADERS) {
+		offset = 1
+	}
+	for i, row := range t.GetValues() {
+		key := row[columns[0]]
+		var lastRow map[string]string
+		if len(lastRows[key]) > 0 {
+			lastRow = lastRows[key][0]
+			lastRows[key] = lastRows[key][1:]
+		}
+		if !rowChanged(columns, lastRow, row) || offset+i >= len(lines) {
+			continue
+		}
+		line := strings.TrimSuffix(lines[offset+i], "\n")
+		lines[offset+i] = ANSI_REVERSE + line + ANSI_RESET + "\n"
+	}
+	return strings.Join(lines, "")
+}
+
+func rowChanged(columns []string, last map[string]string, row map[string]string) bool {
+	if last == nil {
+		return true
+	}
+	for _, column := range columns {
+		if last[column] != row[column] {
+			return true
+		}
+	}
+	return false
+}
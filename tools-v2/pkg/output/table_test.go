@@ -0,0 +1,135 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package output
+
+import (
+	"testing"
+
+	"github.com/liushuochen/gotable"
+	"github.com/opencurve/curve/tools-v2/pkg/config"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/viper"
+)
+
+func setTableOption(columns string, sortBy string, filter string) {
+	viper.Set(config.VIPER_GLOBALE_COLUMNS, columns)
+	viper.Set(config.VIPER_GLOBALE_SORTBY, sortBy)
+	viper.Set(config.VIPER_GLOBALE_FILTER, filter)
+}
+
+func TestTableOption(t *testing.T) {
+	Convey("TestTableOption", t, func() {
+		defer setTableOption("", "", "")
+		table, err := gotable.Create("id", "addr", "status")
+		So(err, ShouldBeNil)
+		table.AddRows([]map[string]string{
+			{"id": "10", "addr": "127.0.0.1:6801", "status": "online"},
+			{"id": "9", "addr": "127.0.0.1:6802", "status": "offline"},
+			{"id": "11", "addr": "127.0.0.1:6803", "status": "online"},
+		})
+
+		apply := func() ([]map[string]string, []string, error) {
+			option, err := GetTableOption()
+			if err != nil {
+				return nil, nil, err
+			}
+			ret, err := option.Apply(table)
+			if err != nil {
+				return nil, nil, err
+			}
+			return ret.GetValues(), ret.GetColumns(), nil
+		}
+
+		Convey("columns", func() {
+			setTableOption("Status, id", "", "")
+			rows, columns, err := apply()
+			So(err, ShouldBeNil)
+			So(columns, ShouldResemble, []string{"status", "id"})
+			So(rows[0], ShouldResemble, map[string]string{"status": "online", "id": "10"})
+		})
+
+		Convey("sort by number", func() {
+			setTableOption("id", "id", "")
+			rows, _, err := apply()
+			So(err, ShouldBeNil)
+			So(rows, ShouldResemble, []map[string]string{{"id": "9"}, {"id": "10"}, {"id": "11"}})
+
+			setTableOption("id", "-id", "")
+			rows, _, err = apply()
+			So(err, ShouldBeNil)
+			So(rows, ShouldResemble, []map[string]string{{"id": "11"}, {"id": "10"}, {"id": "9"}})
+		})
+
+		Convey("filter", func() {
+			setTableOption("id", "", "status!=offline")
+			rows, _, err := apply()
+			So(err, ShouldBeNil)
+			So(rows, ShouldResemble, []map[string]string{{"id": "10"}, {"id": "11"}})
+
+			setTableOption("id", "", "status==online, addr=127.0.0.1:6803")
+			rows, _, err = apply()
+			So(err, ShouldBeNil)
+			So(rows, ShouldResemble, []map[string]string{{"id": "11"}})
+		})
+
+		Convey("invalid", func() {
+			for _, option := range [][]string{{"zone", "", ""}, {"", "zone", ""}, {"", "", "zone=1"}, {"", "", "status"}} {
+				setTableOption(option[0], option[1], option[2])
+				_, _, err := apply()
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+func TestTableString(t *testing.T) {
+	Convey("TestTableString", t, func() {
+		table, err := gotable.Create("id")
+		So(err, ShouldBeNil)
+		table.AddRow(map[string]string{"id": "1"})
+		So(TableString(table), ShouldEqual, "+----+\n| id |\n+----+\n| 1  |\n+----+\n")
+
+		viper.Set(config.VIPER_GLOBALE_NOHEADERS, true)
+		defer viper.Set(config.VIPER_GLOBALE_NOHEADERS, false)
+		So(TableString(table), ShouldEqual, "+----+\n| 1  |\n+----+\n")
+	})
+}
+
+func TestTableStringChanged(t *testing.T) {
+	Convey("TestTableStringChanged", t, func() {
+		last, err := gotable.Create("id", "status")
+		So(err, ShouldBeNil)
+		last.AddRows([]map[string]string{{"id": "1", "status": "online"}, {"id": "2", "status": "online"}})
+		table, err := gotable.Create("id", "status")
+		So(err, ShouldBeNil)
+		table.AddRows([]map[string]string{{"id": "1", "status": "online"}, {"id": "2", "status": "offline"}, {"id": "3", "status": "online"}})
+
+		So(TableStringChanged(table, nil), ShouldEqual, TableString(table))
+		So(TableStringChanged(table, last), ShouldEqual, "+----+---------+\n"+
+			"| id | status  |\n"+
+			"+----+---------+\n"+
+			"| 1  | online  |\n"+
+			ANSI_REVERSE+"| 2  | offline |"+ANSI_RESET+"\n"+
+			ANSI_REVERSE+"| 3  | online  |"+ANSI_RESET+"\n"+
+			"+----+---------+\n")
+	})
+}
@@ -0,0 +1,291 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+// Package recording saves the rpc and http traffic of the commands in a dir,
+// and serves the commands from the dir later without the cluster.
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/opencurve/curve/tools-v2/pkg/audit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	KIND_RPC  = "rpc"
+	KIND_HTTP = "http"
+
+	FILE_EXT = ".json"
+)
+
+var (
+	// the message may not set all the required fields of proto2
+	marshalOptions   = protojson.MarshalOptions{AllowPartial: true}
+	unmarshalOptions = protojson.UnmarshalOptions{AllowPartial: true}
+)
+
+// Exchange is a request and its response, it is saved in a file of the dir
+type Exchange struct {
+	Kind string `json:"kind"`
+	Addr string `json:"addr"`
+	// the full method of rpc, e.g. /curvefs.mds.MdsService/GetFsInfo,
+	// or the uri of http, e.g. /vars/curve_version
+	Method   string          `json:"method"`
+	Request  json.RawMessage `json:"request,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+	// the grpc code of the failed rpc
+	Code codes.Code `json:"code,omitempty"`
+	// the status code and body of http
+	StatusCode int    `json:"statusCode,omitempty"`
+	Body       string `json:"body,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Recorder saves the exchanges in dir, the files are numbered
+// after the ones in it, so several commands can be recorded in a dir
+type Recorder struct {
+	dir   string
+	mutex sync.Mutex
+	seq   int
+}
+
+func NewRecorder(dir string) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	files, err := exchangeFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	r := &Recorder{dir: dir}
+	if len(files) > 0 {
+		r.seq, _ = fileSeq(files[len(files)-1])
+	}
+	return r, nil
+}
+
+func (r *Recorder) Dir() string {
+	return r.dir
+}
+
+// RecordRpc saves the rpc sent to addr, err is the error returned by grpc,
+// the secrets in request and response are redacted as the audit log
+func (r *Recorder) RecordRpc(addr string, method string, request proto.Message, response proto.Message, err error) error {
+	exchange := Exchange{Kind: KIND_RPC, Addr: addr, Method: method}
+	var errMarshal error
+	if exchange.Request, errMarshal = marshalRedacted(request); errMarshal != nil {
+		return errMarshal
+	}
+	if err != nil {
+		s := status.Convert(err)
+		exchange.Code = s.Code()
+		exchange.Error = s.Message()
+	} else if exchange.Response, errMarshal = marshalRedacted(response); errMarshal != nil {
+		return errMarshal
+	}
+	return r.save(exchange, method[strings.LastIndex(method, "/")+1:])
+}
+
+// marshalRedacted returns the json of message whose secret fields are audit.REDACTED
+func marshalRedacted(message proto.Message) (json.RawMessage, error) {
+	decoded, err := decodeRedacted(message)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(decoded)
+}
+
+func decodeRedacted(message proto.Message) (interface{}, error) {
+	data, err := marshalOptions.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return audit.Redact(decoded), nil
+}
+
+// RecordHttp saves the http get of uri from addr, err is the error of http client
+func (r *Recorder) RecordHttp(addr string, uri string, statusCode int, body string, err error) error {
+	exchange := Exchange{Kind: KIND_HTTP, Addr: addr, Method: uri}
+	if err != nil {
+		exchange.Error = err.Error()
+	} else {
+		exchange.StatusCode = statusCode
+		exchange.Body = body
+	}
+	return r.save(exchange, KIND_HTTP)
+}
+
+// the file is like 000001-GetFsInfo.json
+func (r *Recorder) save(exchange Exchange, name string) error {
+	data, err := json.MarshalIndent(exchange, "", "  ")
+	if err != nil {
+		return err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.seq++
+	path := filepath.Join(r.dir, fmt.Sprintf("%06d-%s%s", r.seq, name, FILE_EXT))
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// Player serves the requests by the exchanges recorded in dir. The same
+// requests get the responses in the order they are recorded, and the last
+// one after that, e.g. the runs of --watch.
+type Player struct {
+	dir       string
+	mutex     sync.Mutex
+	exchanges []*Exchange
+	// the times the exchange is used
+	used map[*Exchange]int
+}
+
+func NewPlayer(dir string) (*Player, error) {
+	files, err := exchangeFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no recording is found in %s", dir)
+	}
+	p := &Player{dir: dir, used: make(map[*Exchange]int)}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			return nil, err
+		}
+		exchange := &Exchange{}
+		if err := json.Unmarshal(data, exchange); err != nil {
+			return nil, fmt.Errorf("invalid recording %s: %w", filepath.Join(dir, file), err)
+		}
+		p.exchanges = append(p.exchanges, exchange)
+	}
+	return p, nil
+}
+
+func (p *Player) Dir() string {
+	return p.dir
+}
+
+// PlayRpc fills response by the rpc recorded, the returned error is the one
+// of grpc, it is Unavailable if the rpc is not recorded
+func (p *Player) PlayRpc(addr string, method string, request proto.Message, response proto.Message) error {
+	// the secrets are redacted in recording, so the request is compared after
+	// they are redacted too
+	redacted, err := decodeRedacted(request)
+	if err != nil {
+		return status.Errorf(codes.Internal, "invalid request of %s to %s: %v", method, addr, err)
+	}
+	exchange := p.next(KIND_RPC, addr, method, func(e *Exchange) bool {
+		var recorded interface{}
+		return json.Unmarshal(e.Request, &recorded) == nil && reflect.DeepEqual(recorded, redacted)
+	})
+	if exchange == nil {
+		return status.Errorf(codes.Unavailable, "no recording of %s to %s in %s", method, addr, p.dir)
+	}
+	if exchange.Code != codes.OK {
+		return status.Error(exchange.Code, exchange.Error)
+	}
+	if err := unmarshalOptions.Unmarshal(exchange.Response, response); err != nil {
+		return status.Errorf(codes.Internal, "invalid recording of %s to %s: %v", method, addr, err)
+	}
+	return nil
+}
+
+// PlayHttp returns the status code and body of the http get recorded
+func (p *Player) PlayHttp(addr string, uri string) (int, string, error) {
+	exchange := p.next(KIND_HTTP, addr, uri, func(*Exchange) bool {
+		return true
+	})
+	if exchange == nil {
+		return 0, "", fmt.Errorf("no recording of http://%s%s in %s", addr, uri, p.dir)
+	}
+	if exchange.Error != "" {
+		return 0, "", fmt.Errorf("%s", exchange.Error)
+	}
+	return exchange.StatusCode, exchange.Body, nil
+}
+
+// next returns the first exchange matched which is not used,
+// or the last one if all of them are used
+func (p *Player) next(kind string, addr string, method string, match func(*Exchange) bool) *Exchange {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	var last *Exchange
+	for _, exchange := range p.exchanges {
+		if exchange.Kind != kind || exchange.Addr != addr || exchange.Method != method || !match(exchange) {
+			continue
+		}
+		if p.used[exchange] == 0 {
+			p.used[exchange]++
+			return exchange
+		}
+		last = exchange
+	}
+	if last != nil {
+		p.used[last]++
+	}
+	return last
+}
+
+// the recording files in dir sorted by their numbers
+func exchangeFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if _, ok := fileSeq(entry.Name()); ok && !entry.IsDir() {
+			files = append(files, entry.Name())
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		seqI, _ := fileSeq(files[i])
+		seqJ, _ := fileSeq(files[j])
+		return seqI < seqJ
+	})
+	return files, nil
+}
+
+func fileSeq(name string) (int, bool) {
+	if !strings.HasSuffix(name, FILE_EXT) {
+		return 0, false
+	}
+	prefix, _, _ := strings.Cut(name, "-")
+	seq, err := strconv.Atoi(prefix)
+	return seq, err == nil
+}
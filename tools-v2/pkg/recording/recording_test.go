@@ -0,0 +1,127 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package recording
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencurve/curve/tools-v2/pkg/audit"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/common"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/mds"
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	GET_FS_INFO = "/curvefs.mds.MdsService/GetFsInfo"
+	CREATE_FS   = "/curvefs.mds.MdsService/CreateFs"
+)
+
+func fsInfoResponse(fsId uint32) *mds.GetFsInfoResponse {
+	code := mds.FSStatusCode_OK
+	return &mds.GetFsInfoResponse{StatusCode: &code, FsInfo: &mds.FsInfo{FsId: proto.Uint32(fsId)}}
+}
+
+func TestRecording(t *testing.T) {
+	Convey("the rpc and http are replayed as recorded", t, func() {
+		dir := t.TempDir()
+		recorder, err := NewRecorder(dir)
+		So(err, ShouldBeNil)
+		request := &mds.GetFsInfoRequest{FsName: proto.String("test1")}
+		So(recorder.RecordRpc("127.0.0.1:6700", GET_FS_INFO, request, fsInfoResponse(1), nil), ShouldBeNil)
+		So(recorder.RecordRpc("127.0.0.1:6700", GET_FS_INFO, request, fsInfoResponse(2), nil), ShouldBeNil)
+		So(recorder.RecordRpc("127.0.0.1:6701", GET_FS_INFO, request, nil, status.Error(codes.Unavailable, "connection refused")), ShouldBeNil)
+		So(recorder.RecordHttp("127.0.0.1:7700", "/vars/curve_version", 200, "curve_version : 2.5.0", nil), ShouldBeNil)
+
+		// the recording continues in the same dir
+		recorder, err = NewRecorder(dir)
+		So(err, ShouldBeNil)
+		So(recorder.RecordHttp("127.0.0.1:7701", "/vars/curve_version", 404, "", nil), ShouldBeNil)
+
+		player, err := NewPlayer(dir)
+		So(err, ShouldBeNil)
+		// the responses of the same request are in order, then the last one
+		for _, fsId := range []uint32{1, 2, 2} {
+			response := &mds.GetFsInfoResponse{}
+			So(player.PlayRpc("127.0.0.1:6700", GET_FS_INFO, request, response), ShouldBeNil)
+			So(response.GetFsInfo().GetFsId(), ShouldEqual, fsId)
+		}
+		err = player.PlayRpc("127.0.0.1:6701", GET_FS_INFO, request, &mds.GetFsInfoResponse{})
+		So(status.Code(err), ShouldEqual, codes.Unavailable)
+		So(status.Convert(err).Message(), ShouldEqual, "connection refused")
+		// the request is not recorded
+		err = player.PlayRpc("127.0.0.1:6700", GET_FS_INFO, &mds.GetFsInfoRequest{FsName: proto.String("test2")}, &mds.GetFsInfoResponse{})
+		So(status.Code(err), ShouldEqual, codes.Unavailable)
+
+		statusCode, body, err := player.PlayHttp("127.0.0.1:7700", "/vars/curve_version")
+		So(err, ShouldBeNil)
+		So(statusCode, ShouldEqual, 200)
+		So(body, ShouldEqual, "curve_version : 2.5.0")
+		statusCode, _, err = player.PlayHttp("127.0.0.1:7701", "/vars/curve_version")
+		So(err, ShouldBeNil)
+		So(statusCode, ShouldEqual, 404)
+		_, _, err = player.PlayHttp("127.0.0.1:7702", "/vars/curve_version")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("the secrets are not saved and the request with them is replayed", t, func() {
+		dir := t.TempDir()
+		recorder, err := NewRecorder(dir)
+		So(err, ShouldBeNil)
+		detail := &mds.FsDetail{
+			Volume: &common.Volume{VolumeName: proto.String("volume1"), Password: proto.String("secret-password")},
+			S3Info: &common.S3Info{Ak: proto.String("secret-ak"), Sk: proto.String("secret-sk")},
+		}
+		request := &mds.CreateFsRequest{FsName: proto.String("test1"), FsDetail: detail}
+		response := fsInfoResponse(1)
+		response.FsInfo.Detail = detail
+		So(recorder.RecordRpc("127.0.0.1:6700", CREATE_FS, request, response, nil), ShouldBeNil)
+
+		files, err := exchangeFiles(dir)
+		So(err, ShouldBeNil)
+		So(files, ShouldHaveLength, 1)
+		data, err := os.ReadFile(filepath.Join(dir, files[0]))
+		So(err, ShouldBeNil)
+		So(string(data), ShouldContainSubstring, "volume1")
+		So(string(data), ShouldNotContainSubstring, "secret-")
+
+		player, err := NewPlayer(dir)
+		So(err, ShouldBeNil)
+		played := &mds.GetFsInfoResponse{}
+		So(player.PlayRpc("127.0.0.1:6700", CREATE_FS, request, played), ShouldBeNil)
+		So(played.GetFsInfo().GetDetail().GetS3Info().GetSk(), ShouldEqual, audit.REDACTED)
+		So(played.GetFsInfo().GetDetail().GetVolume().GetVolumeName(), ShouldEqual, "volume1")
+		// the other fields of request are still compared
+		request.FsName = proto.String("test2")
+		err = player.PlayRpc("127.0.0.1:6700", CREATE_FS, request, &mds.GetFsInfoResponse{})
+		So(status.Code(err), ShouldEqual, codes.Unavailable)
+	})
+
+	Convey("the dir without recording can not be replayed", t, func() {
+		_, err := NewPlayer(t.TempDir())
+		So(err, ShouldNotBeNil)
+	})
+}
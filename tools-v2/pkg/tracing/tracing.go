@@ -0,0 +1,339 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+// Package tracing collects the spans of the commands, rpc and http, and
+// writes them to a file in the json lines of OTLP, so that the trace can be opened
+// by the trace viewers, e.g. jaeger.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	SERVICE_NAME = "curve"
+	SCOPE_NAME   = "github.com/opencurve/curve/tools-v2"
+)
+
+// SpanKind is the kind of span in OTLP
+type SpanKind int
+
+const (
+	SPAN_KIND_INTERNAL SpanKind = 1
+	SPAN_KIND_CLIENT   SpanKind = 3
+)
+
+// the status code of span in OTLP
+const (
+	STATUS_CODE_UNSET = 0
+	STATUS_CODE_ERROR = 2
+)
+
+// Tracer keeps the spans ended until their root span ends, then the trace is
+// appended to the file as a line, e.g. every run of --watch and every line of shell.
+// The file is in json lines like the file exporter of opentelemetry collector.
+type Tracer struct {
+	path  string
+	mutex sync.Mutex
+	// the spans whose root span has not ended
+	spans []*Span
+	// the file is truncated by the first write
+	written bool
+}
+
+func NewTracer(path string) *Tracer {
+	return &Tracer{path: path}
+}
+
+// Path returns the file which the trace is written to
+func (t *Tracer) Path() string {
+	return t.path
+}
+
+// Span is a timed operation, all the methods of nil span do nothing,
+// so the code traced needs not to check whether the tracing is enabled
+type Span struct {
+	tracer       *Tracer
+	traceId      [16]byte
+	spanId       [8]byte
+	parentSpanId [8]byte
+	name         string
+	kind         SpanKind
+	start        time.Time
+
+	mutex         sync.Mutex
+	end           time.Time
+	ended         bool
+	attributes    []attribute
+	statusCode    int
+	statusMessage string
+}
+
+type attribute struct {
+	key   string
+	value interface{}
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns the context whose spans started later are the children of span
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the current span of ctx, nil if there is none
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// Start starts a span as the child of the span in ctx, or the root of a new trace,
+// the nil tracer starts nothing
+func (t *Tracer) Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+	span := &Span{
+		tracer: t,
+		name:   name,
+		kind:   kind,
+		start:  time.Now(),
+	}
+	if parent := SpanFromContext(ctx); parent != nil && parent.tracer == t {
+		span.traceId = parent.traceId
+		span.parentSpanId = parent.spanId
+	} else {
+		rand.Read(span.traceId[:])
+	}
+	rand.Read(span.spanId[:])
+	return ContextWithSpan(ctx, span), span
+}
+
+// Start starts a span as the child of the span in ctx by its tracer,
+// nothing is started if ctx has no span, e.g. the rpc sent by the client library
+func Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	parent := SpanFromContext(ctx)
+	if parent == nil {
+		return ctx, nil
+	}
+	return parent.tracer.Start(ctx, name, kind)
+}
+
+// SetAttribute sets the attribute of span, e.g. rpc.method,
+// the value is a string, bool, integer or float
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i := range s.attributes {
+		if s.attributes[i].key == key {
+			s.attributes[i].value = value
+			return
+		}
+	}
+	s.attributes = append(s.attributes, attribute{key: key, value: value})
+}
+
+// SetError marks the span failed with the message
+func (s *Span) SetError(message string) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.statusCode = STATUS_CODE_ERROR
+	s.statusMessage = message
+}
+
+// End ends the span, only the first call counts. The trace is written to
+// the file when the root span ends, and the error of writing is returned.
+func (s *Span) End() error {
+	if s == nil {
+		return nil
+	}
+	s.mutex.Lock()
+	if s.ended {
+		s.mutex.Unlock()
+		return nil
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mutex.Unlock()
+
+	s.tracer.mutex.Lock()
+	defer s.tracer.mutex.Unlock()
+	s.tracer.spans = append(s.tracer.spans, s)
+	if s.parentSpanId != [8]byte{} {
+		return nil
+	}
+	return s.tracer.write(s.traceId)
+}
+
+// write appends the spans of the trace to the file and releases them,
+// the spans of the other traces are kept, e.g. the commands run concurrently.
+// It must be called with t.mutex held.
+func (t *Tracer) write(traceId [16]byte) error {
+	spans := make([]otlpSpan, 0)
+	kept := t.spans[:0]
+	for _, span := range t.spans {
+		if span.traceId == traceId {
+			spans = append(spans, span.otlp())
+		} else {
+			kept = append(kept, span)
+		}
+	}
+	for i := len(kept); i < len(t.spans); i++ {
+		t.spans[i] = nil
+	}
+	t.spans = kept
+	data := otlpData{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: []otlpAttribute{newAttribute("service.name", SERVICE_NAME)},
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: SCOPE_NAME},
+				Spans: spans,
+			}},
+		}},
+	}
+	jsonByte, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	flag := os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	if !t.written {
+		flag |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(t.path, flag, 0644)
+	if err != nil {
+		return err
+	}
+	t.written = true
+	_, err = file.Write(append(jsonByte, '\n'))
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	return err
+}
+
+// the json of OTLP, see opentelemetry/proto/trace/v1/trace.proto,
+// the ids are in hex and the 64-bit integers are strings
+type otlpData struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceId           string          `json:"traceId"`
+	SpanId            string          `json:"spanId"`
+	ParentSpanId      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              SpanKind        `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (s *Span) otlp() otlpSpan {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	ret := otlpSpan{
+		TraceId:           hex.EncodeToString(s.traceId[:]),
+		SpanId:            hex.EncodeToString(s.spanId[:]),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		Status:            otlpStatus{Code: s.statusCode, Message: s.statusMessage},
+	}
+	if s.parentSpanId != [8]byte{} {
+		ret.ParentSpanId = hex.EncodeToString(s.parentSpanId[:])
+	}
+	for _, attr := range s.attributes {
+		ret.Attributes = append(ret.Attributes, newAttribute(attr.key, attr.value))
+	}
+	return ret
+}
+
+func newAttribute(key string, value interface{}) otlpAttribute {
+	var ret otlpValue
+	switch v := value.(type) {
+	case string:
+		ret.StringValue = &v
+	case bool:
+		ret.BoolValue = &v
+	case int, int32, int64, uint32, uint64:
+		intValue := fmt.Sprintf("%d", v)
+		ret.IntValue = &intValue
+	case float64:
+		ret.DoubleValue = &v
+	default:
+		stringValue := fmt.Sprint(v)
+		ret.StringValue = &stringValue
+	}
+	return otlpAttribute{Key: key, Value: ret}
+}
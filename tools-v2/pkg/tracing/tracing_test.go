@@ -0,0 +1,137 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// readTrace returns the lines of file, every line is a trace
+func readTrace(path string) ([]otlpData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var traces []otlpData
+	decoder := json.NewDecoder(file)
+	for decoder.More() {
+		var data otlpData
+		if err := decoder.Decode(&data); err != nil {
+			return nil, err
+		}
+		traces = append(traces, data)
+	}
+	return traces, nil
+}
+
+func TestTracing(t *testing.T) {
+	Convey("the spans are written when the root ends", t, func() {
+		path := filepath.Join(t.TempDir(), "trace.json")
+		tracer := NewTracer(path)
+		ctx, root := tracer.Start(context.Background(), "curve fs status cluster", SPAN_KIND_INTERNAL)
+		childCtx, child := tracer.Start(ctx, "/curvefs.mds.MdsService/GetFsInfo", SPAN_KIND_CLIENT)
+		So(SpanFromContext(childCtx), ShouldEqual, child)
+		child.SetAttribute("rpc.system", "grpc")
+		child.SetAttribute("rpc.grpc.status_code", 14)
+		child.SetAttribute("rpc.grpc.status_code", 0)
+		child.SetError("connection refused")
+		So(child.End(), ShouldBeNil)
+		_, err := os.Stat(path)
+		So(os.IsNotExist(err), ShouldBeTrue)
+
+		root.SetAttribute("curve.retry", true)
+		So(root.End(), ShouldBeNil)
+		// only the first end counts
+		So(root.End(), ShouldBeNil)
+
+		traces, err := readTrace(path)
+		So(err, ShouldBeNil)
+		So(len(traces), ShouldEqual, 1)
+		data := traces[0]
+		So(len(data.ResourceSpans), ShouldEqual, 1)
+		So(*data.ResourceSpans[0].Resource.Attributes[0].Value.StringValue, ShouldEqual, SERVICE_NAME)
+		spans := data.ResourceSpans[0].ScopeSpans[0].Spans
+		So(len(spans), ShouldEqual, 2)
+		So(spans[0].Name, ShouldEqual, "/curvefs.mds.MdsService/GetFsInfo")
+		So(spans[0].Kind, ShouldEqual, SPAN_KIND_CLIENT)
+		So(spans[0].TraceId, ShouldEqual, spans[1].TraceId)
+		So(spans[0].ParentSpanId, ShouldEqual, spans[1].SpanId)
+		So(spans[0].Status, ShouldResemble, otlpStatus{Code: STATUS_CODE_ERROR, Message: "connection refused"})
+		So(len(spans[0].Attributes), ShouldEqual, 2)
+		So(*spans[0].Attributes[1].Value.IntValue, ShouldEqual, "0")
+		So(spans[1].ParentSpanId, ShouldEqual, "")
+		So(len(spans[1].TraceId), ShouldEqual, 32)
+		So(len(spans[1].SpanId), ShouldEqual, 16)
+		So(*spans[1].Attributes[0].Value.BoolValue, ShouldBeTrue)
+		So(spans[1].StartTimeUnixNano <= spans[1].EndTimeUnixNano, ShouldBeTrue)
+
+		// the spans written are released
+		So(tracer.spans, ShouldBeEmpty)
+
+		// the next root appends a new trace to the same file,
+		// the spans of the root not ended are kept
+		otherCtx, other := tracer.Start(context.Background(), "curve fs query fs", SPAN_KIND_INTERNAL)
+		_, otherChild := tracer.Start(otherCtx, "/curvefs.mds.MdsService/GetFsInfo", SPAN_KIND_CLIENT)
+		So(otherChild.End(), ShouldBeNil)
+		_, root = tracer.Start(context.Background(), "curve fs list fs", SPAN_KIND_INTERNAL)
+		So(root.End(), ShouldBeNil)
+		So(tracer.spans, ShouldResemble, []*Span{otherChild})
+		So(other.End(), ShouldBeNil)
+		So(tracer.spans, ShouldBeEmpty)
+
+		traces, err = readTrace(path)
+		So(err, ShouldBeNil)
+		So(len(traces), ShouldEqual, 3)
+		So(traces[0].ResourceSpans[0].ScopeSpans[0].Spans, ShouldResemble, spans)
+		listSpans := traces[1].ResourceSpans[0].ScopeSpans[0].Spans
+		So(len(listSpans), ShouldEqual, 1)
+		So(listSpans[0].Name, ShouldEqual, "curve fs list fs")
+		So(listSpans[0].TraceId, ShouldNotEqual, spans[1].TraceId)
+		querySpans := traces[2].ResourceSpans[0].ScopeSpans[0].Spans
+		So(len(querySpans), ShouldEqual, 2)
+		So(querySpans[1].Name, ShouldEqual, "curve fs query fs")
+
+		// the file of a new tracer is truncated
+		_, root = NewTracer(path).Start(context.Background(), "curve fs list fs", SPAN_KIND_INTERNAL)
+		So(root.End(), ShouldBeNil)
+		traces, err = readTrace(path)
+		So(err, ShouldBeNil)
+		So(len(traces), ShouldEqual, 1)
+	})
+
+	Convey("nothing is traced by the nil tracer", t, func() {
+		var tracer *Tracer
+		ctx, span := tracer.Start(context.Background(), "curve fs list fs", SPAN_KIND_INTERNAL)
+		So(span, ShouldBeNil)
+		So(SpanFromContext(ctx), ShouldBeNil)
+		span.SetAttribute("rpc.system", "grpc")
+		span.SetError("failed")
+		So(span.End(), ShouldBeNil)
+	})
+}
@@ -0,0 +1,195 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package transport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	DEFAULT_CONN_IDLE_TIMEOUT = 60 * time.Second
+)
+
+type connEntry struct {
+	conn     *grpc.ClientConn
+	lastUsed time.Time
+}
+
+// ConnPool keeps one grpc.ClientConn per address for the whole process,
+// so every RpcFunc sent to the same server shares the same connection.
+// Connections which are not used for idleTimeout are closed by a
+// background goroutine, Close releases all of them.
+type ConnPool struct {
+	mutex       sync.Mutex
+	conns       map[string]*connEntry
+	idleTimeout time.Duration
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+var (
+	connPool      *ConnPool
+	connPoolMutex sync.Mutex
+	// the idle timeout of the pool created later
+	connIdleTimeout = DEFAULT_CONN_IDLE_TIMEOUT
+)
+
+func NewConnPool(idleTimeout time.Duration) *ConnPool {
+	if idleTimeout <= 0 {
+		idleTimeout = DEFAULT_CONN_IDLE_TIMEOUT
+	}
+	return &ConnPool{
+		conns:       make(map[string]*connEntry),
+		idleTimeout: idleTimeout,
+	}
+}
+
+// GetConnPool returns the process-wide connection pool,
+// the pool is created at the first call.
+func GetConnPool() *ConnPool {
+	connPoolMutex.Lock()
+	defer connPoolMutex.Unlock()
+	if connPool == nil {
+		connPool = NewConnPool(connIdleTimeout)
+	}
+	return connPool
+}
+
+// SetConnIdleTimeout sets the idle timeout of the process-wide pool,
+// it takes effect when the pool is created again, e.g. after CloseConnPool
+func SetConnIdleTimeout(idleTimeout time.Duration) {
+	connPoolMutex.Lock()
+	defer connPoolMutex.Unlock()
+	connIdleTimeout = idleTimeout
+}
+
+// CloseConnPool closes all connections of the process-wide pool,
+// a new pool will be created if GetConnPool is called later.
+func CloseConnPool() {
+	connPoolMutex.Lock()
+	pool := connPool
+	connPool = nil
+	connPoolMutex.Unlock()
+	if pool != nil {
+		pool.Close()
+	}
+}
+
+// Get returns the connection to addr, dial it if there is none or
+// the previous one has been shut down.
+func (p *ConnPool) Get(ctx context.Context, addr string) (*grpc.ClientConn, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if entry, ok := p.conns[addr]; ok {
+		switch entry.conn.GetState() {
+		case connectivity.Shutdown:
+			delete(p.conns, addr)
+		case connectivity.TransientFailure:
+			// the server may be back, do not wait for the reconnect backoff
+			entry.conn.ResetConnectBackoff()
+			entry.lastUsed = time.Now()
+			return entry.conn, nil
+		default:
+			entry.lastUsed = time.Now()
+			return entry.conn, nil
+		}
+	}
+
+	conn, err := grpc.DialContext(ctx, addr, currentDialOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	p.conns[addr] = &connEntry{
+		conn:     conn,
+		lastUsed: time.Now(),
+	}
+	p.startEvictor()
+	return conn, nil
+}
+
+// the options added by AddDialOptions are after the tracing,
+// so their interceptors are called inside the span of rpc
+func currentDialOptions() []grpc.DialOption {
+	hookMutex.Lock()
+	defer hookMutex.Unlock()
+	options := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(tracingInterceptor),
+	}
+	return append(options, dialOptions...)
+}
+
+// must be called with p.mutex held
+func (p *ConnPool) startEvictor() {
+	if p.stop != nil {
+		return
+	}
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	go func(stop chan struct{}, done chan struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(p.idleTimeout / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				p.evictIdle()
+			}
+		}
+	}(p.stop, p.done)
+}
+
+func (p *ConnPool) evictIdle() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	now := time.Now()
+	for addr, entry := range p.conns {
+		if now.Sub(entry.lastUsed) >= p.idleTimeout {
+			entry.conn.Close()
+			delete(p.conns, addr)
+		}
+	}
+}
+
+// Close closes all connections and stops the background eviction.
+func (p *ConnPool) Close() {
+	p.mutex.Lock()
+	stop, done := p.stop, p.done
+	p.stop, p.done = nil, nil
+	for addr, entry := range p.conns {
+		entry.conn.Close()
+		delete(p.conns, addr)
+	}
+	p.mutex.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
@@ -0,0 +1,190 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	MDS_STATUS_SUBURI     = "/vars/curvefs_mds_status"
+	MDS_STATUS_LEADER     = "leader"
+	MDS_LEADER_CACHE_FILE = "mds_leader.json"
+)
+
+// LeaderResolver finds the leader of a cluster,
+// the Rpc which has a LeaderResolver is only sent to the leader.
+type LeaderResolver interface {
+	// Leader returns the leader address,
+	// stale is the leader which is known to be out of date,
+	// it will be resolved again if the cached leader is stale.
+	Leader(ctx context.Context, stale string) (string, *cmderror.CmdError)
+}
+
+// MdsLeader finds the mds leader from the status metric
+// on the dummy port, dummyAddrs[i] is the dummy address of addrs[i].
+type MdsLeader struct {
+	addrs      []string
+	dummyAddrs []string
+	timeout    time.Duration
+	// the file which keeps the leaders for other processes, "" means no file
+	cacheFile string
+}
+
+var _ LeaderResolver = (*MdsLeader)(nil) // check interface
+
+var (
+	// mds addrs -> leader
+	mdsLeaderCache = make(map[string]string)
+	mdsLeaderMutex sync.Mutex
+)
+
+func NewMdsLeader(addrs []string, dummyAddrs []string, timeout time.Duration, cacheFile string) *MdsLeader {
+	return &MdsLeader{
+		addrs:      addrs,
+		dummyAddrs: dummyAddrs,
+		timeout:    timeout,
+		cacheFile:  cacheFile,
+	}
+}
+
+// Leader queries the dummy addresses without holding the lock, so the
+// concurrent rpc to the other clusters are not blocked by a slow mds
+func (mLeader *MdsLeader) Leader(ctx context.Context, stale string) (string, *cmderror.CmdError) {
+	key := strings.Join(mLeader.addrs, ",")
+	mdsLeaderMutex.Lock()
+	cached := mdsLeaderCache[key]
+	mdsLeaderMutex.Unlock()
+	leader := cached
+	if leader == "" && stale == "" && mLeader.cacheFile != "" {
+		leader = loadMdsLeader(mLeader.cacheFile, key)
+	}
+	if leader != "" && leader != stale && slices.Contains(mLeader.addrs, leader) {
+		mLeader.setCache(key, cached, leader)
+		return leader, cmderror.ErrSuccess()
+	}
+
+	leader, err := mLeader.queryLeader(ctx)
+	if err.TypeCode() != cmderror.CODE_SUCCESS {
+		mLeader.setCache(key, cached, "")
+		return "", err
+	}
+	mLeader.setCache(key, cached, leader)
+	if mLeader.cacheFile != "" {
+		storeMdsLeader(mLeader.cacheFile, key, leader)
+	}
+	return leader, cmderror.ErrSuccess()
+}
+
+// setCache replaces the cached leader if it is still old,
+// the one set by others during the query is newer, "" means to delete it
+func (mLeader *MdsLeader) setCache(key string, old string, leader string) {
+	mdsLeaderMutex.Lock()
+	defer mdsLeaderMutex.Unlock()
+	if mdsLeaderCache[key] != old {
+		return
+	}
+	if leader == "" {
+		delete(mdsLeaderCache, key)
+	} else {
+		mdsLeaderCache[key] = leader
+	}
+}
+
+func (mLeader *MdsLeader) queryLeader(ctx context.Context) (string, *cmderror.CmdError) {
+	results := make(chan MetricResult, len(mLeader.dummyAddrs))
+	for i, addr := range mLeader.dummyAddrs {
+		go func(i int, addr string) {
+			metric := NewMetric([]string{addr}, MDS_STATUS_SUBURI, mLeader.timeout)
+			result, err := QueryMetric(ctx, *metric)
+			var value string
+			if err.TypeCode() == cmderror.CODE_SUCCESS {
+				value, err = GetMetricValue(result)
+			}
+			results <- MetricResult{
+				Addr:  mLeader.addrs[i],
+				Value: value,
+				Err:   err,
+			}
+		}(i, addr)
+	}
+
+	for range mLeader.dummyAddrs {
+		res := <-results
+		if res.Err.TypeCode() == cmderror.CODE_SUCCESS && res.Value == MDS_STATUS_LEADER {
+			return res.Addr, cmderror.ErrSuccess()
+		}
+	}
+	retErr := cmderror.ErrMdsLeader()
+	retErr.Format(strings.Join(mLeader.addrs, ","))
+	return "", retErr
+}
+
+func readMdsLeaderCache(path string) map[string]string {
+	leaders := make(map[string]string)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return leaders
+	}
+	if json.Unmarshal(data, &leaders) != nil {
+		return make(map[string]string)
+	}
+	return leaders
+}
+
+func loadMdsLeader(path string, key string) string {
+	return readMdsLeaderCache(path)[key]
+}
+
+// the cache is only a hint, so the error is ignored
+func storeMdsLeader(path string, key string, leader string) {
+	leaders := readMdsLeaderCache(path)
+	leaders[key] = leader
+	data, err := json.Marshal(leaders)
+	if err != nil {
+		return
+	}
+	if os.MkdirAll(filepath.Dir(path), 0755) != nil {
+		return
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path))
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil || os.Rename(tmp.Name(), path) != nil {
+		os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,113 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/codes"
+)
+
+// fakeLeader returns the leaders in turn, the last one is repeated,
+// and records the stale leader of every call
+type fakeLeader struct {
+	leaders []string
+	stales  []string
+}
+
+func (fLeader *fakeLeader) Leader(ctx context.Context, stale string) (string, *cmderror.CmdError) {
+	fLeader.stales = append(fLeader.stales, stale)
+	leader := fLeader.leaders[0]
+	if len(fLeader.leaders) > 1 {
+		fLeader.leaders = fLeader.leaders[1:]
+	}
+	return leader, cmderror.ErrSuccess()
+}
+
+func TestLeaderRpc(t *testing.T) {
+	defer CloseConnPool()
+	Convey("the leader is resolved again only if the rpc is unavailable", t, func() {
+		leader := &fakeLeader{leaders: []string{SCRIPTED_ADDR, "127.0.0.1:2"}}
+		rpc := NewRpc([]string{SCRIPTED_ADDR, "127.0.0.1:2"}, time.Second, 0, "Check")
+		rpc.Leader = leader
+
+		Convey("the follower does not serve the rpc", func() {
+			rpcFunc := newScriptedRpc(false, codes.Unavailable, codes.OK)
+			_, err := GetRpcResponse(context.Background(), rpc, rpcFunc)
+			So(err.TypeCode(), ShouldEqual, cmderror.CODE_SUCCESS)
+			So(*rpcFunc.calls, ShouldEqual, 2)
+			So(leader.stales, ShouldResemble, []string{"", SCRIPTED_ADDR})
+		})
+
+		Convey("the other errors are returned by the leader", func() {
+			rpcFunc := newScriptedRpc(false, codes.InvalidArgument, codes.OK)
+			_, err := GetRpcResponse(context.Background(), rpc, rpcFunc)
+			So(err.Code, ShouldEqual, cmderror.ErrRpcCall().Code)
+			So(*rpcFunc.calls, ShouldEqual, 1)
+			So(leader.stales, ShouldResemble, []string{""})
+		})
+	})
+}
+
+func TestMdsLeaderNotBlocked(t *testing.T) {
+	Convey("the slow mds of a cluster does not block the leader of the others", t, func() {
+		queried := make(chan struct{}, 1)
+		release := make(chan struct{})
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			queried <- struct{}{}
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer slow.Close()
+		defer close(release)
+		fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("curvefs_mds_status : \"leader\""))
+		}))
+		defer fast.Close()
+
+		slowLeader := NewMdsLeader([]string{"127.0.0.1:1"}, []string{strings.TrimPrefix(slow.URL, "http://")}, 10*time.Second, "")
+		go slowLeader.Leader(context.Background(), "")
+		<-queried
+
+		fastLeader := NewMdsLeader([]string{"127.0.0.1:2"}, []string{strings.TrimPrefix(fast.URL, "http://")}, 10*time.Second, "")
+		done := make(chan string, 1)
+		go func() {
+			leader, _ := fastLeader.Leader(context.Background(), "")
+			done <- leader
+		}()
+		var leader string
+		select {
+		case leader = <-done:
+		case <-time.After(5 * time.Second):
+		}
+		So(leader, ShouldEqual, "127.0.0.1:2")
+	})
+}
@@ -0,0 +1,198 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/tracing"
+)
+
+const (
+	CURL_VERSION = "curl/7.54.0"
+)
+
+type Metric struct {
+	Addrs   []string
+	SubUri  string
+	timeout time.Duration
+}
+
+type MetricResult struct {
+	Addr  string
+	Key   string
+	Value string
+	Err   *cmderror.CmdError
+}
+
+// HttpTransport wraps the transport of the http get, e.g. to record the traffic
+type HttpTransport func(next http.RoundTripper) http.RoundTripper
+
+var httpTransports []HttpTransport
+
+// AddHttpTransport adds the wrapper of the http transport, the one added first is the outermost
+func AddHttpTransport(wrap HttpTransport) {
+	hookMutex.Lock()
+	defer hookMutex.Unlock()
+	httpTransports = append(httpTransports, wrap)
+}
+
+func currentHttpTransport() http.RoundTripper {
+	hookMutex.Lock()
+	defer hookMutex.Unlock()
+	transport := http.DefaultTransport
+	for i := len(httpTransports) - 1; i >= 0; i-- {
+		transport = httpTransports[i](transport)
+	}
+	return transport
+}
+
+func NewMetric(addrs []string, subUri string, timeout time.Duration) *Metric {
+	return &Metric{
+		Addrs:   addrs,
+		SubUri:  subUri,
+		timeout: timeout,
+	}
+}
+
+// QueryMetric gets the metric from the first host which responds,
+// the requests to the other hosts are canceled then.
+func QueryMetric(ctx context.Context, m Metric) (value string, retErr *cmderror.CmdError) {
+	ctx, span := tracing.Start(ctx, "QueryMetric "+m.SubUri, tracing.SPAN_KIND_INTERNAL)
+	span.SetAttribute("curve.addrs", strings.Join(m.Addrs, ","))
+	defer func() {
+		endSpan(span, retErr)
+	}()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan MetricResult, len(m.Addrs))
+	for _, host := range m.Addrs {
+		go func(host string) {
+			url := "http://" + host + m.SubUri
+			value, err := httpGet(ctx, url, m.timeout)
+			results <- MetricResult{
+				Addr:  host,
+				Value: value,
+				Err:   err,
+			}
+		}(host)
+	}
+
+	var vecErrs []*cmderror.CmdError
+	for range m.Addrs {
+		res := <-results
+		if res.Err == nil {
+			return "", ContextCmdError(ctx)
+		}
+		if res.Err.TypeCode() == cmderror.CODE_SUCCESS {
+			return res.Value, res.Err
+		}
+		cmderror.FromContext(ctx).Add(cmderror.ErrorEntry{Err: res.Err, Addr: res.Addr, Rpc: m.SubUri})
+		vecErrs = append(vecErrs, res.Err)
+	}
+	return "", cmderror.MostImportantCmdError(vecErrs)
+}
+
+func GetMetricValue(metricRet string) (string, *cmderror.CmdError) {
+	kv := strings.Join(strings.Fields(metricRet), "")
+	kvVec := strings.Split(kv, ":")
+	if len(kvVec) != 2 {
+		err := cmderror.ErrParseMetric()
+		err.Format(metricRet)
+		return "", err
+	}
+	kvVec[1] = strings.Replace(kvVec[1], "\"", "", -1)
+	return kvVec[1], cmderror.ErrSuccess()
+}
+
+func GetKeyValueFromJsonMetric(metricRet string, key string) (string, *cmderror.CmdError) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(metricRet), &data); err != nil {
+		err := cmderror.ErrParseMetric()
+		err.Format(metricRet)
+		return "", err
+	}
+	value, ok := data[key].(string)
+	if !ok {
+		err := cmderror.ErrDataNoExpected()
+		err.Format(metricRet, fmt.Sprintf("the value of %s is not a string", key))
+		return "", err
+	}
+	return value, cmderror.ErrSuccess()
+}
+
+// the returned error is nil if ctx is done
+func httpGet(ctx context.Context, url string, timeout time.Duration) (body string, retErr *cmderror.CmdError) {
+	ctx, span := tracing.Start(ctx, "HTTP GET", tracing.SPAN_KIND_CLIENT)
+	span.SetAttribute("http.method", http.MethodGet)
+	span.SetAttribute("http.url", url)
+	defer func() {
+		endSpan(span, retErr)
+	}()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		interErr := cmderror.ErrHttpCreateGetRequest()
+		interErr.Format(err.Error())
+		return "", interErr
+	}
+	// for get curl url
+	req.Header.Set("User-Agent", CURL_VERSION)
+	client := http.Client{
+		Transport: currentHttpTransport(),
+		Timeout:   timeout,
+	}
+	resp, err := client.Do(req)
+	if ctx.Err() != nil {
+		if err == nil {
+			resp.Body.Close()
+		}
+		return "", nil
+	}
+	if err != nil {
+		interErr := cmderror.ErrHttpClient()
+		interErr.Format(err.Error())
+		return "", interErr
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		statusErr := cmderror.ErrHttpStatus(resp.StatusCode)
+		statusErr.Format(url, resp.StatusCode)
+		return "", statusErr
+	}
+	bodyByte, err := ioutil.ReadAll(resp.Body)
+	if ctx.Err() != nil {
+		return "", nil
+	}
+	if err != nil {
+		interErr := cmderror.ErrHttpUnreadableResult()
+		interErr.Format(url, err.Error())
+		return "", interErr
+	}
+	return string(bodyByte), cmderror.ErrSuccess()
+}
@@ -0,0 +1,161 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package transport
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	RPC_RETRY_BASE_BACKOFF = 100 * time.Millisecond
+	RPC_RETRY_MAX_BACKOFF  = 3 * time.Second
+)
+
+// MutatingRpcFunc is implemented by the RpcFunc which changes the cluster,
+// such as CreateFs or DeleteFs.
+// These rpc are not idempotent, so they are not retried
+// unless Rpc.RetryMutating is set.
+type MutatingRpcFunc interface {
+	RpcFunc
+	IsMutating() bool
+	// GetRequest returns the request to send, e.g. for --dry-run and the audit log
+	GetRequest() proto.Message
+}
+
+var (
+	backoffRand  = rand.New(rand.NewSource(time.Now().UnixNano()))
+	backoffMutex sync.Mutex
+)
+
+func IsMutatingRpcFunc(rpcFunc RpcFunc) bool {
+	mutating, ok := rpcFunc.(MutatingRpcFunc)
+	return ok && mutating.IsMutating()
+}
+
+// only the errors that the request may not reach the server
+// or the server may be back soon are worth retrying
+func isRetryableRpcError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// exponential backoff with jitter, the result is in [d/2, d),
+// d = RPC_RETRY_BASE_BACKOFF * 2^retry and not more than RPC_RETRY_MAX_BACKOFF
+func retryBackoff(retry int32) time.Duration {
+	backoff := RPC_RETRY_BASE_BACKOFF
+	for i := int32(0); i < retry && backoff < RPC_RETRY_MAX_BACKOFF; i++ {
+		backoff *= 2
+	}
+	if backoff > RPC_RETRY_MAX_BACKOFF {
+		backoff = RPC_RETRY_MAX_BACKOFF
+	}
+	half := int64(backoff / 2)
+	backoffMutex.Lock()
+	jitter := backoffRand.Int63n(half)
+	backoffMutex.Unlock()
+	return time.Duration(half + jitter)
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// callRpcWithRetry sends the rpc to addr, it will be retried at most
+// rpc.RpcRetryTimes times when the server is unavailable or the call
+// is timeout. Every failed attempt is added to the Collector in ctx.
+// The returned error is nil if ctx is done, the caller decides
+// whether it is an error or the rpc is just not needed any more.
+func callRpcWithRetry(ctx context.Context, rpc *Rpc, rpcFunc RpcFunc, addr string) (interface{}, *cmderror.CmdError) {
+	res, _, err := callRpcWithCode(ctx, rpc, rpcFunc, addr)
+	return res, err
+}
+
+// callRpcWithCode is callRpcWithRetry which also returns the grpc code of
+// the last attempt, the failure to dial is codes.Unavailable
+func callRpcWithCode(ctx context.Context, rpc *Rpc, rpcFunc RpcFunc, addr string) (interface{}, codes.Code, *cmderror.CmdError) {
+	errs := cmderror.FromContext(ctx)
+	retryTimes := rpc.RpcRetryTimes
+	if retryTimes < 0 || (IsMutatingRpcFunc(rpcFunc) && !rpc.RetryMutating) {
+		retryTimes = 0
+	}
+	for retry := int32(0); ; retry++ {
+		conn, err := GetConnPool().Get(ctx, addr)
+		if ctx.Err() != nil {
+			return nil, codes.Canceled, nil
+		}
+		if err != nil {
+			errDial := cmderror.ErrRpcDial()
+			errDial.Format(addr, err.Error())
+			errs.Add(cmderror.ErrorEntry{Err: errDial, Addr: addr, Rpc: rpc.RpcFuncName, Retry: retry})
+			return nil, codes.Unavailable, errDial
+		}
+		rpcFunc.NewRpcClient(conn)
+		res, err := callRpc(ctx, rpc, rpcFunc)
+		if err == nil {
+			return res, codes.OK, cmderror.ErrSuccess()
+		}
+		if ctx.Err() != nil {
+			return nil, codes.Canceled, nil
+		}
+		if retry >= retryTimes || !isRetryableRpcError(err) {
+			errRpc := cmderror.ErrRpcCall()
+			errRpc.Format(addr, rpc.RpcFuncName, err.Error())
+			if isRetryableRpcError(err) {
+				errRpc.WithExitCode(cmderror.EXIT_UNREACHABLE)
+			}
+			errs.Add(cmderror.ErrorEntry{Err: errRpc, Addr: addr, Rpc: rpc.RpcFuncName, Retry: retry})
+			return nil, status.Code(err), errRpc
+		}
+		errRetry := cmderror.ErrRpcRetry()
+		errRetry.Format(retry+1, retryTimes, addr, rpc.RpcFuncName, err.Error())
+		errs.Add(cmderror.ErrorEntry{Err: errRetry, Addr: addr, Rpc: rpc.RpcFuncName, Retry: retry})
+		if !sleepWithContext(ctx, retryBackoff(retry)) {
+			return nil, codes.Canceled, nil
+		}
+	}
+}
+
+// every attempt has its own rpc.RpcTimeout
+func callRpc(ctx context.Context, rpc *Rpc, rpcFunc RpcFunc) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, rpc.RpcTimeout)
+	defer cancel()
+	return rpcFunc.Stub_Func(ctx)
+}
@@ -0,0 +1,163 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package transport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// scriptedRpc fails with the codes in turn without sending anything,
+// codes.OK means the attempt succeeds, the last code is repeated
+type scriptedRpc struct {
+	codes    []codes.Code
+	calls    *int
+	mutating bool
+}
+
+func newScriptedRpc(mutating bool, attempts ...codes.Code) *scriptedRpc {
+	return &scriptedRpc{codes: attempts, calls: new(int), mutating: mutating}
+}
+
+func (sRpc *scriptedRpc) NewRpcClient(cc grpc.ClientConnInterface) {}
+
+func (sRpc *scriptedRpc) Stub_Func(ctx context.Context) (interface{}, error) {
+	i := *sRpc.calls
+	*sRpc.calls++
+	if i >= len(sRpc.codes) {
+		i = len(sRpc.codes) - 1
+	}
+	if sRpc.codes[i] == codes.OK {
+		return &healthpb.HealthCheckResponse{}, nil
+	}
+	return nil, status.Error(sRpc.codes[i], "scripted")
+}
+
+func (sRpc *scriptedRpc) IsMutating() bool {
+	return sRpc.mutating
+}
+
+func (sRpc *scriptedRpc) GetRequest() proto.Message {
+	return nil
+}
+
+// the address is never connected as scriptedRpc sends nothing
+const SCRIPTED_ADDR = "127.0.0.1:1"
+
+func callScripted(rpc *Rpc, rpcFunc *scriptedRpc) (*cmderror.CmdError, []cmderror.ErrorEntry) {
+	errs := cmderror.NewCollector()
+	ctx := cmderror.NewContext(context.Background(), errs)
+	_, err := callRpcWithRetry(ctx, rpc, rpcFunc, SCRIPTED_ADDR)
+	return err, errs.Entries()
+}
+
+func TestCallRpcWithRetry(t *testing.T) {
+	defer CloseConnPool()
+	retryCode := cmderror.ErrRpcRetry().Code
+	callCode := cmderror.ErrRpcCall().Code
+
+	Convey("the unavailable and timeout rpc are retried", t, func() {
+		rpcFunc := newScriptedRpc(false, codes.Unavailable, codes.DeadlineExceeded, codes.OK)
+		err, entries := callScripted(NewRpc(nil, time.Second, 3, "Check"), rpcFunc)
+		So(err.TypeCode(), ShouldEqual, cmderror.CODE_SUCCESS)
+		So(*rpcFunc.calls, ShouldEqual, 3)
+		// every failed attempt is in the trail
+		So(len(entries), ShouldEqual, 2)
+		for i, entry := range entries {
+			So(entry.Err.Code, ShouldEqual, retryCode)
+			So(entry.Retry, ShouldEqual, i)
+			So(entry.Addr, ShouldEqual, SCRIPTED_ADDR)
+			So(entry.Rpc, ShouldEqual, "Check")
+		}
+	})
+
+	Convey("the rpc fails as unreachable after the retries", t, func() {
+		rpcFunc := newScriptedRpc(false, codes.Unavailable)
+		err, entries := callScripted(NewRpc(nil, time.Second, 2, "Check"), rpcFunc)
+		So(err.Code, ShouldEqual, callCode)
+		So(err.ExitCode(), ShouldEqual, cmderror.EXIT_UNREACHABLE)
+		So(*rpcFunc.calls, ShouldEqual, 3)
+		So(len(entries), ShouldEqual, 3)
+		So(entries[0].Err.Code, ShouldEqual, retryCode)
+		So(entries[1].Err.Code, ShouldEqual, retryCode)
+		So(entries[2].Err.Code, ShouldEqual, callCode)
+		So(entries[2].Retry, ShouldEqual, 2)
+	})
+
+	Convey("the other errors are not retried", t, func() {
+		for _, code := range []codes.Code{codes.InvalidArgument, codes.NotFound, codes.Internal, codes.PermissionDenied} {
+			rpcFunc := newScriptedRpc(false, code, codes.OK)
+			err, entries := callScripted(NewRpc(nil, time.Second, 3, "Check"), rpcFunc)
+			So(err.Code, ShouldEqual, callCode)
+			So(err.ExitCode(), ShouldNotEqual, cmderror.EXIT_UNREACHABLE)
+			So(*rpcFunc.calls, ShouldEqual, 1)
+			So(len(entries), ShouldEqual, 1)
+		}
+	})
+
+	Convey("the mutating rpc is retried only with RetryMutating", t, func() {
+		rpcFunc := newScriptedRpc(true, codes.Unavailable, codes.OK)
+		rpc := NewRpc(nil, time.Second, 3, "CreateFs")
+		err, _ := callScripted(rpc, rpcFunc)
+		So(err.Code, ShouldEqual, callCode)
+		So(*rpcFunc.calls, ShouldEqual, 1)
+
+		rpcFunc = newScriptedRpc(true, codes.Unavailable, codes.OK)
+		rpc.RetryMutating = true
+		err, _ = callScripted(rpc, rpcFunc)
+		So(err.TypeCode(), ShouldEqual, cmderror.CODE_SUCCESS)
+		So(*rpcFunc.calls, ShouldEqual, 2)
+	})
+
+	Convey("the negative retry times means no retry", t, func() {
+		rpcFunc := newScriptedRpc(false, codes.Unavailable, codes.OK)
+		err, _ := callScripted(NewRpc(nil, time.Second, -1, "Check"), rpcFunc)
+		So(err.Code, ShouldEqual, callCode)
+		So(*rpcFunc.calls, ShouldEqual, 1)
+	})
+}
+
+func TestRetryBackoff(t *testing.T) {
+	Convey("the backoff is in [d/2, d) and d is capped", t, func() {
+		for retry := int32(0); retry < 10; retry++ {
+			d := RPC_RETRY_BASE_BACKOFF << retry
+			if d > RPC_RETRY_MAX_BACKOFF {
+				d = RPC_RETRY_MAX_BACKOFF
+			}
+			for i := 0; i < 100; i++ {
+				backoff := retryBackoff(retry)
+				So(backoff, ShouldBeGreaterThanOrEqualTo, d/2)
+				So(backoff, ShouldBeLessThan, d)
+			}
+		}
+		So(retryBackoff(1000), ShouldBeLessThan, RPC_RETRY_MAX_BACKOFF)
+	})
+}
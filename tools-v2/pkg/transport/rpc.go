@@ -0,0 +1,237 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+// Package transport sends the rpc and http of curve, with the retries, the
+// routing to the mds leader and the connections shared in the process.
+// It does not depend on the commands, the features of commands like the
+// dry-run and the recording are added by the hooks.
+package transport
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+type Rpc struct {
+	Addrs         []string
+	RpcTimeout    time.Duration
+	RpcRetryTimes int32
+	RpcFuncName   string
+	// retry the MutatingRpcFunc too
+	RetryMutating bool
+	// send the rpc to the leader only, nil means to all Addrs
+	Leader LeaderResolver
+}
+
+func NewRpc(addrs []string, timeout time.Duration, retryTimes int32, funcName string) *Rpc {
+	return &Rpc{
+		Addrs:         addrs,
+		RpcTimeout:    timeout,
+		RpcRetryTimes: retryTimes,
+		RpcFuncName:   funcName,
+	}
+}
+
+type RpcFunc interface {
+	NewRpcClient(cc grpc.ClientConnInterface)
+	Stub_Func(ctx context.Context) (interface{}, error)
+}
+
+type RpcResult struct {
+	Response interface{}
+	Error    *cmderror.CmdError
+}
+
+// RpcCall sends the rpc by GetRpcResponse
+type RpcCall func(ctx context.Context) (interface{}, *cmderror.CmdError)
+
+// RpcHook wraps every GetRpcResponse, it may refuse the rpc or
+// do something after call, e.g. the dry-run and audit log of commands
+type RpcHook func(ctx context.Context, rpc *Rpc, rpcFunc RpcFunc, call RpcCall) (interface{}, *cmderror.CmdError)
+
+var (
+	hookMutex   sync.Mutex
+	rpcHooks    []RpcHook
+	dialOptions []grpc.DialOption
+)
+
+// AddRpcHook adds the hook of GetRpcResponse, the one added first is the outermost
+func AddRpcHook(hook RpcHook) {
+	hookMutex.Lock()
+	defer hookMutex.Unlock()
+	rpcHooks = append(rpcHooks, hook)
+}
+
+// AddDialOptions adds the options of the connections dialed later, e.g. the interceptors
+func AddDialOptions(options ...grpc.DialOption) {
+	hookMutex.Lock()
+	defer hookMutex.Unlock()
+	dialOptions = append(dialOptions, options...)
+}
+
+func currentRpcHooks() []RpcHook {
+	hookMutex.Lock()
+	defer hookMutex.Unlock()
+	return rpcHooks
+}
+
+// GetRpcResponse sends the rpc to all rpc.Addrs (or the leader only) and
+// returns the first success response, the rpc to the other addrs are
+// canceled then.
+func GetRpcResponse(ctx context.Context, rpc *Rpc, rpcFunc RpcFunc) (res interface{}, err *cmderror.CmdError) {
+	ctx, span := tracing.Start(ctx, "GetRpcResponse "+rpc.RpcFuncName, tracing.SPAN_KIND_INTERNAL)
+	span.SetAttribute("curve.addrs", strings.Join(rpc.Addrs, ","))
+	defer func() {
+		endSpan(span, err)
+	}()
+	call := func(ctx context.Context) (interface{}, *cmderror.CmdError) {
+		return getRpcResponse(ctx, rpc, rpcFunc)
+	}
+	hooks := currentRpcHooks()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hook, next := hooks[i], call
+		call = func(ctx context.Context) (interface{}, *cmderror.CmdError) {
+			return hook(ctx, rpc, rpcFunc, next)
+		}
+	}
+	return call(ctx)
+}
+
+func getRpcResponse(ctx context.Context, rpc *Rpc, rpcFunc RpcFunc) (interface{}, *cmderror.CmdError) {
+	if rpc.Leader != nil {
+		leader, err := rpc.Leader.Leader(ctx, "")
+		if err.TypeCode() == cmderror.CODE_SUCCESS {
+			return getLeaderRpcResponse(ctx, rpc, rpcFunc, leader)
+		}
+		// the leader is unknown, try all addrs
+		cmderror.FromContext(ctx).Add(cmderror.ErrorEntry{Err: err, Rpc: rpc.RpcFuncName})
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan RpcResult, len(rpc.Addrs))
+	for _, addr := range rpc.Addrs {
+		go func(addr string, rpcFunc RpcFunc) {
+			res, err := callRpcWithRetry(ctx, rpc, rpcFunc, addr)
+			results <- RpcResult{res, err}
+		}(addr, cloneRpcFunc(rpcFunc))
+	}
+
+	var vecErrs []*cmderror.CmdError
+	for range rpc.Addrs {
+		result := <-results
+		if result.Error == nil {
+			return nil, ContextCmdError(ctx)
+		}
+		if result.Error.TypeCode() == cmderror.CODE_SUCCESS {
+			return result.Response, result.Error
+		}
+		vecErrs = append(vecErrs, result.Error)
+	}
+	retErr := cmderror.MostImportantCmdError(vecErrs)
+	return nil, retErr
+}
+
+func getLeaderRpcResponse(ctx context.Context, rpc *Rpc, rpcFunc RpcFunc, leader string) (interface{}, *cmderror.CmdError) {
+	res, code, err := callRpcWithCode(ctx, rpc, cloneRpcFunc(rpcFunc), leader)
+	if err == nil {
+		return nil, ContextCmdError(ctx)
+	}
+	// the follower does not serve the rpc, so the leader may have changed if
+	// the rpc is unavailable, the other errors are returned by the leader
+	if err.TypeCode() == cmderror.CODE_SUCCESS || code != codes.Unavailable {
+		return res, err
+	}
+	newLeader, errLeader := rpc.Leader.Leader(ctx, leader)
+	if errLeader.TypeCode() != cmderror.CODE_SUCCESS || newLeader == leader {
+		return nil, err
+	}
+	res, err = callRpcWithRetry(ctx, rpc, cloneRpcFunc(rpcFunc), newLeader)
+	if err == nil {
+		return nil, ContextCmdError(ctx)
+	}
+	return res, err
+}
+
+// Every address gets its own copy of rpcFunc,
+// so that the client bound by NewRpcClient is not shared between goroutines.
+func cloneRpcFunc(rpcFunc RpcFunc) RpcFunc {
+	value := reflect.ValueOf(rpcFunc)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return rpcFunc
+	}
+	clone := reflect.New(value.Elem().Type())
+	clone.Elem().Set(value.Elem())
+	return clone.Interface().(RpcFunc)
+}
+
+// GetRpcListResponse sends the rpc concurrently, the responses are in the
+// order of rpcList so that the output is the same every time
+func GetRpcListResponse(ctx context.Context, rpcList []*Rpc, rpcFunc []RpcFunc) ([]interface{}, *cmderror.CmdError) {
+	results := make([]RpcResult, len(rpcList))
+	var wg sync.WaitGroup
+	for i := range rpcList {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			res, err := GetRpcResponse(ctx, rpcList[i], rpcFunc[i])
+			results[i] = RpcResult{res, err}
+		}(i)
+	}
+	wg.Wait()
+
+	var retRes []interface{}
+	var vecErrs []*cmderror.CmdError
+	for _, res := range results {
+		if res.Error.TypeCode() != cmderror.CODE_SUCCESS {
+			// get fail
+			vecErrs = append(vecErrs, res.Error)
+		} else {
+			retRes = append(retRes, res.Response)
+		}
+	}
+	retErr := cmderror.MergeCmdError(vecErrs)
+	if len(retRes) > 0 && len(vecErrs) > 0 {
+		return retRes, cmderror.PartialCmdError(&retErr)
+	}
+	return retRes, &retErr
+}
+
+// ContextCmdError returns the reason why ctx is done
+func ContextCmdError(ctx context.Context) *cmderror.CmdError {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		retErr := cmderror.ErrCmdTimeout()
+		retErr.Format(ctx.Err().Error())
+		return retErr
+	}
+	retErr := cmderror.ErrCmdCanceled()
+	retErr.Format(ctx.Err().Error())
+	return retErr
+}
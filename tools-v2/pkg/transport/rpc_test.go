@@ -0,0 +1,200 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+package transport
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type healthRpc struct {
+	client healthpb.HealthClient
+}
+
+func (hRpc *healthRpc) NewRpcClient(cc grpc.ClientConnInterface) {
+	hRpc.client = healthpb.NewHealthClient(cc)
+}
+
+func (hRpc *healthRpc) Stub_Func(ctx context.Context) (interface{}, error) {
+	return hRpc.client.Check(ctx, &healthpb.HealthCheckRequest{})
+}
+
+func startHealthServer() (*grpc.Server, string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	So(err, ShouldBeNil)
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(lis)
+	return server, lis.Addr().String()
+}
+
+// blackhole accepts the connections but never answers,
+// so the request to it only ends when it is canceled
+func startBlackhole() (net.Listener, chan net.Conn) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	So(err, ShouldBeNil)
+	conns := make(chan net.Conn, 64)
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				close(conns)
+				return
+			}
+			conns <- conn
+		}
+	}()
+	return lis, conns
+}
+
+func closeBlackhole(lis net.Listener, conns chan net.Conn) {
+	lis.Close()
+	for conn := range conns {
+		conn.Close()
+	}
+}
+
+// wait for the exiting goroutines, return whether there are no more than n
+func goroutinesNoMoreThan(n int) bool {
+	for i := 0; i < 100; i++ {
+		if runtime.NumGoroutine() <= n {
+			return true
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return false
+}
+
+func TestGetRpcResponseNoLeak(t *testing.T) {
+	Convey("GetRpcResponse", t, func() {
+		server, addr := startHealthServer()
+		defer server.Stop()
+		lis, conns := startBlackhole()
+		defer closeBlackhole(lis, conns)
+		holeAddr := lis.Addr().String()
+		before := runtime.NumGoroutine()
+
+		Convey("returns the first success and cancels the others", func() {
+			for i := 0; i < 10; i++ {
+				rpc := NewRpc([]string{holeAddr, addr, holeAddr}, 10*time.Second, 0, "Check")
+				res, err := GetRpcResponse(context.Background(), rpc, &healthRpc{})
+				So(err.TypeCode(), ShouldEqual, cmderror.CODE_SUCCESS)
+				So(res.(*healthpb.HealthCheckResponse).GetStatus(), ShouldEqual, healthpb.HealthCheckResponse_SERVING)
+			}
+			CloseConnPool()
+			So(goroutinesNoMoreThan(before), ShouldBeTrue)
+		})
+
+		Convey("stops when the context is canceled", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			rpc := NewRpc([]string{holeAddr, holeAddr}, 10*time.Second, 3, "Check")
+			start := time.Now()
+			_, err := GetRpcResponse(ctx, rpc, &healthRpc{})
+			So(time.Since(start), ShouldBeLessThan, 5*time.Second)
+			So(err.Code, ShouldEqual, cmderror.ErrCmdTimeout().Code)
+			CloseConnPool()
+			So(goroutinesNoMoreThan(before), ShouldBeTrue)
+		})
+
+		Convey("GetRpcListResponse waits for all rpc", func() {
+			var rpcs []*Rpc
+			var funcs []RpcFunc
+			for i := 0; i < 8; i++ {
+				rpcs = append(rpcs, NewRpc([]string{addr, holeAddr}, 10*time.Second, 0, "Check"))
+				funcs = append(funcs, &healthRpc{})
+			}
+			res, err := GetRpcListResponse(context.Background(), rpcs, funcs)
+			So(err.TypeCode(), ShouldEqual, cmderror.CODE_SUCCESS)
+			So(len(res), ShouldEqual, 8)
+			CloseConnPool()
+			So(goroutinesNoMoreThan(before), ShouldBeTrue)
+		})
+	})
+}
+
+func TestQueryMetricNoLeak(t *testing.T) {
+	Convey("QueryMetric", t, func() {
+		block := make(chan struct{})
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-block:
+			case <-r.Context().Done():
+			}
+		}))
+		defer slow.Close()
+		defer close(block)
+		fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("curvefs_mds_status : \"leader\""))
+		}))
+		defer fast.Close()
+		slowAddr := strings.TrimPrefix(slow.URL, "http://")
+		fastAddr := strings.TrimPrefix(fast.URL, "http://")
+		before := runtime.NumGoroutine()
+
+		Convey("returns the first success and cancels the others", func() {
+			for i := 0; i < 10; i++ {
+				metric := NewMetric([]string{slowAddr, fastAddr}, MDS_STATUS_SUBURI, 10*time.Second)
+				res, err := QueryMetric(context.Background(), *metric)
+				So(err.TypeCode(), ShouldEqual, cmderror.CODE_SUCCESS)
+				value, _ := GetMetricValue(res)
+				So(value, ShouldEqual, MDS_STATUS_LEADER)
+			}
+			http.DefaultTransport.(*http.Transport).CloseIdleConnections()
+			So(goroutinesNoMoreThan(before), ShouldBeTrue)
+		})
+
+		Convey("stops when the context is canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(100*time.Millisecond, cancel)
+			metric := NewMetric([]string{slowAddr}, MDS_STATUS_SUBURI, 10*time.Second)
+			_, err := QueryMetric(ctx, *metric)
+			So(err.Code, ShouldEqual, cmderror.ErrCmdCanceled().Code)
+			So(goroutinesNoMoreThan(before), ShouldBeTrue)
+		})
+	})
+}
+
+func TestGetKeyValueFromJsonMetric(t *testing.T) {
+	Convey("the value of key in json metric", t, func() {
+		value, err := GetKeyValueFromJsonMetric(`{"version": "3.4.0", "cluster": 1}`, "version")
+		So(err.TypeCode(), ShouldEqual, cmderror.CODE_SUCCESS)
+		So(value, ShouldEqual, "3.4.0")
+
+		for _, key := range []string{"leader", "cluster"} {
+			_, err = GetKeyValueFromJsonMetric(`{"version": "3.4.0", "cluster": 1}`, key)
+			So(err.TypeCode(), ShouldEqual, cmderror.CODE_INTERNAL)
+			So(err.Message, ShouldContainSubstring, "the value of "+key+" is not a string")
+		}
+	})
+}
@@ -0,0 +1,69 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package transport
+
+import (
+	"context"
+	"strings"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// endSpan ends the span with the error if it fails, the spans started here
+// always have a parent, so ending them does not write the trace
+func endSpan(span *tracing.Span, err *cmderror.CmdError) {
+	if err != nil && err.TypeCode() != cmderror.CODE_SUCCESS {
+		span.SetError(err.Message)
+	}
+	span.End()
+}
+
+// tracingInterceptor traces every rpc sent, including the retries
+func tracingInterceptor(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, span := tracing.Start(ctx, strings.TrimPrefix(method, "/"), tracing.SPAN_KIND_CLIENT)
+	if span == nil {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	// the method is like /curvefs.mds.MdsService/GetFsInfo
+	service, name := "", method
+	if i := strings.LastIndex(method, "/"); i >= 0 {
+		service, name = strings.TrimPrefix(method[:i], "/"), method[i+1:]
+	}
+	span.SetAttribute("rpc.system", "grpc")
+	span.SetAttribute("rpc.service", service)
+	span.SetAttribute("rpc.method", name)
+	span.SetAttribute("net.peer.name", cc.Target())
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	code := status.Code(err)
+	span.SetAttribute("rpc.grpc.status_code", int(code))
+	// the rpc canceled is not needed any more, e.g. another addr responds first
+	if err != nil && code != codes.Canceled {
+		span.SetError(status.Convert(err).Message())
+	}
+	span.End()
+	return err
+}
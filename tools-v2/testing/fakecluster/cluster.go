@@ -0,0 +1,276 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package fakecluster
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/copyset"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/mds"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/metaserver"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/topology"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	LOCAL_IP = "127.0.0.1"
+)
+
+// Cluster serves the mds, metaservers and etcd of the fixture on local ports,
+// the mutating rpc like CreateFs changes the cluster as the real one.
+type Cluster struct {
+	MdsAddr      string
+	MdsDummyAddr string
+	EtcdAddr     string // empty if the fixture has no etcd
+
+	mutex       sync.Mutex
+	fixture     *Fixture
+	metaservers map[uint32]*metaserverAddr
+	calls       map[string]int
+	grpcServers []*grpc.Server
+	httpServers []*http.Server
+}
+
+type metaserverAddr struct {
+	internal *net.TCPAddr
+	external *net.TCPAddr
+}
+
+// Start serves the cluster of fixture, the fixture itself is not changed
+func Start(fixture *Fixture) (*Cluster, error) {
+	copied, err := copyFixture(fixture)
+	if err != nil {
+		return nil, err
+	}
+	if err := copied.validate(); err != nil {
+		return nil, err
+	}
+	c := &Cluster{
+		fixture:     copied,
+		metaservers: make(map[uint32]*metaserverAddr),
+		calls:       make(map[string]int),
+	}
+	if err := c.start(); err != nil {
+		c.Stop()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Cluster) start() error {
+	server, addr, err := c.serveGrpc(c.mdsVars, c.mdsLeaderInterceptor)
+	if err != nil {
+		return err
+	}
+	mds.RegisterMdsServiceServer(server, &mdsService{cluster: c})
+	topology.RegisterTopologyServiceServer(server, &topologyService{cluster: c})
+	c.MdsAddr = addr.String()
+
+	addr, err = c.serveHttp(varsHandler(c.mdsVars))
+	if err != nil {
+		return err
+	}
+	c.MdsDummyAddr = addr.String()
+
+	if c.fixture.Etcd != nil {
+		addr, err = c.serveHttp(etcdHandler(*c.fixture.Etcd))
+		if err != nil {
+			return err
+		}
+		c.EtcdAddr = addr.String()
+	}
+
+	for i := range c.fixture.Metaservers {
+		if err := c.startMetaserver(&c.fixture.Metaservers[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Cluster) startMetaserver(ms *Metaserver) error {
+	addrs := &metaserverAddr{}
+	c.metaservers[ms.Id] = addrs
+	if ms.Offline {
+		// reserve the ports, the connections to them are refused
+		var err error
+		if addrs.internal, err = closedAddr(); err != nil {
+			return err
+		}
+		addrs.external, err = closedAddr()
+		return err
+	}
+
+	vars := func() map[string]string {
+		return c.metaserverVars(ms.Vars)
+	}
+	server, addr, err := c.serveGrpc(vars, nil)
+	if err != nil {
+		return err
+	}
+	metaserver.RegisterMetaServerServiceServer(server, &metaserverService{cluster: c, id: ms.Id})
+	copyset.RegisterCopysetServiceServer(server, &copysetService{cluster: c, id: ms.Id})
+	addrs.internal = addr
+	addrs.external, err = c.serveHttp(varsHandler(vars))
+	return err
+}
+
+// serveGrpc serves the grpc and the bvars on the same port like brpc,
+// the rpc refused by accept are not recorded, nil accepts all
+func (c *Cluster) serveGrpc(vars func() map[string]string, accept grpc.UnaryServerInterceptor) (*grpc.Server, *net.TCPAddr, error) {
+	interceptors := []grpc.UnaryServerInterceptor{c.recordInterceptor, fillRequiredInterceptor}
+	if accept != nil {
+		interceptors = append([]grpc.UnaryServerInterceptor{accept}, interceptors...)
+	}
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+	c.grpcServers = append(c.grpcServers, server)
+	httpHandler := varsHandler(vars)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			server.ServeHTTP(w, r)
+			return
+		}
+		httpHandler.ServeHTTP(w, r)
+	})
+	addr, err := c.serveHttp(h2c.NewHandler(handler, &http2.Server{}))
+	return server, addr, err
+}
+
+func (c *Cluster) serveHttp(handler http.Handler) (*net.TCPAddr, error) {
+	listener, err := net.Listen("tcp", LOCAL_IP+":0")
+	if err != nil {
+		return nil, err
+	}
+	server := &http.Server{Handler: handler}
+	c.httpServers = append(c.httpServers, server)
+	go server.Serve(listener)
+	return listener.Addr().(*net.TCPAddr), nil
+}
+
+func closedAddr() (*net.TCPAddr, error) {
+	listener, err := net.Listen("tcp", LOCAL_IP+":0")
+	if err != nil {
+		return nil, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr), nil
+}
+
+// Stop stops all the servers of cluster
+func (c *Cluster) Stop() {
+	for _, server := range c.grpcServers {
+		server.Stop()
+	}
+	for _, server := range c.httpServers {
+		server.Close()
+	}
+}
+
+// Config returns the addresses of cluster as the config file, the commands
+// use them after viper.MergeConfigMap(cluster.Config()), including the
+// nested ones like status cluster which do not get all the flags
+func (c *Cluster) Config() map[string]interface{} {
+	curvefs := map[string]interface{}{
+		"mdsAddr":      c.MdsAddr,
+		"mdsDummyAddr": c.MdsDummyAddr,
+	}
+	if c.EtcdAddr != "" {
+		curvefs["etcdAddr"] = c.EtcdAddr
+	}
+	return map[string]interface{}{"curvefs": curvefs}
+}
+
+// MetaserverAddr returns the internal and external address of metaserver
+func (c *Cluster) MetaserverAddr(id uint32) (string, string) {
+	addrs := c.metaservers[id]
+	if addrs == nil {
+		return "", ""
+	}
+	return addrs.internal.String(), addrs.external.String()
+}
+
+// Fixture returns a copy of the current cluster
+func (c *Cluster) Fixture() *Fixture {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	copied, _ := copyFixture(c.fixture)
+	return copied
+}
+
+// Calls returns the times the rpc is called, e.g. CreateFs
+func (c *Cluster) Calls(method string) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.calls[method]
+}
+
+// SetMdsStatus changes the curvefs_mds_status of mds, e.g. follower,
+// so that the leader moves between the mds of clusters
+func (c *Cluster) SetMdsStatus(status string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.fixture.Mds.Status = status
+}
+
+// the mds which is not the leader does not serve the rpc like the real one
+func (c *Cluster) mdsLeaderInterceptor(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	c.mutex.Lock()
+	mdsStatus := c.fixture.Mds.Status
+	c.mutex.Unlock()
+	if mdsStatus != "" && mdsStatus != "leader" {
+		return nil, status.Errorf(codes.Unavailable, "mds is %s", mdsStatus)
+	}
+	return handler(ctx, request)
+}
+
+func (c *Cluster) recordInterceptor(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	c.mutex.Lock()
+	c.calls[methodName(info.FullMethod)]++
+	c.mutex.Unlock()
+	return handler(ctx, request)
+}
+
+// the full method is like /curvefs.mds.MdsService/CreateFs
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+func copyFixture(fixture *Fixture) (*Fixture, error) {
+	data, err := yaml.Marshal(fixture)
+	if err != nil {
+		return nil, err
+	}
+	copied := &Fixture{}
+	if err := yaml.Unmarshal(data, copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
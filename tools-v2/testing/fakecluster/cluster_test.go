@@ -0,0 +1,226 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package fakecluster
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	cmderror "github.com/opencurve/curve/tools-v2/internal/error"
+	"github.com/opencurve/curve/tools-v2/pkg/client"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/copyset"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/mds"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/metaserver"
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+)
+
+func startCluster(modify func(f *Fixture)) *Cluster {
+	fixture, err := LoadFixture("testdata/cluster.yaml")
+	So(err, ShouldBeNil)
+	if modify != nil {
+		modify(fixture)
+	}
+	cluster, err := Start(fixture)
+	So(err, ShouldBeNil)
+	return cluster
+}
+
+func httpGet(url string) (int, string) {
+	resp, err := http.Get(url)
+	So(err, ShouldBeNil)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	So(err, ShouldBeNil)
+	return resp.StatusCode, string(body)
+}
+
+func TestFixture(t *testing.T) {
+	Convey("the references in fixture are checked", t, func() {
+		fixture, err := LoadFixture("testdata/cluster.yaml")
+		So(err, ShouldBeNil)
+		So(fixture.Metaservers, ShouldHaveLength, 3)
+
+		fixture.Copysets[0].Peers = append(fixture.Copysets[0].Peers, 4)
+		_, err = Start(fixture)
+		So(err, ShouldNotBeNil)
+
+		fixture.Copysets[0].Peers = []uint32{1, 2, 3}
+		fixture.Copysets[0].States = map[uint32]string{1: "broken"}
+		_, err = Start(fixture)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestCluster(t *testing.T) {
+	Convey("the cluster is served by the fixture", t, func() {
+		cluster := startCluster(nil)
+		defer cluster.Stop()
+		c, err := client.New(client.Options{MdsAddrs: []string{cluster.MdsAddr}, RpcTimeout: time.Second})
+		So(err, ShouldBeNil)
+		ctx := context.Background()
+
+		fsInfo, err := c.ListFs(ctx)
+		So(err, ShouldBeNil)
+		So(fsInfo, ShouldHaveLength, 2)
+		So(fsInfo[0].GetMountpoints()[0].GetPort(), ShouldEqual, 9000)
+
+		topology, err := c.ListTopology(ctx)
+		So(err, ShouldBeNil)
+		So(topology.GetClusterId(), ShouldEqual, "8b6a2ba4-fake-cluster")
+		So(topology.GetMetaservers().GetMetaServerInfos(), ShouldHaveLength, 3)
+		So(topology.GetServers().GetServerInfos()[0].GetPoolName(), ShouldEqual, "pool1")
+
+		key := client.CopysetKey{PoolId: 1, CopysetId: 1}
+		copysets, err := c.GetCopysetStatus(ctx, client.CopysetOptions{Copysets: []client.CopysetKey{key}, PeerStatus: true})
+		So(err, ShouldBeNil)
+		So(copysets[key.Key()].Peer2Status, ShouldHaveLength, 3)
+		internalAddr, _ := cluster.MetaserverAddr(1)
+		So(copysets[key.Key()].Peer2Status[internalAddr].GetCopysetStatus().GetState(), ShouldEqual, 1)
+
+		inode, err := c.GetInode(ctx, client.InodeOptions{FsId: 1, InodeId: 2})
+		So(err, ShouldBeNil)
+		So(inode.GetType(), ShouldEqual, metaserver.FsFileType_TYPE_S3)
+		So(inode.GetLength(), ShouldEqual, 4096)
+		So(cluster.Calls("GetInode"), ShouldEqual, 1)
+	})
+
+	Convey("the mutating rpc changes the cluster", t, func() {
+		cluster := startCluster(nil)
+		defer cluster.Stop()
+		conn, err := grpc.Dial(cluster.MdsAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		So(err, ShouldBeNil)
+		defer conn.Close()
+		mdsClient := mds.NewMdsServiceClient(conn)
+		ctx := context.Background()
+
+		request := &mds.CreateFsRequest{FsName: proto.String("test3")}
+		fillRequired(request.ProtoReflect())
+		response, err := mdsClient.CreateFs(ctx, request)
+		So(err, ShouldBeNil)
+		So(response.GetStatusCode(), ShouldEqual, mds.FSStatusCode_OK)
+		So(response.GetFsInfo().GetFsId(), ShouldEqual, 3)
+		So(cluster.Fixture().Fs, ShouldHaveLength, 3)
+
+		response, err = mdsClient.CreateFs(ctx, request)
+		So(err, ShouldBeNil)
+		So(response.GetStatusCode(), ShouldEqual, mds.FSStatusCode_FS_EXIST)
+
+		deleteResponse, err := mdsClient.DeleteFs(ctx, &mds.DeleteFsRequest{FsName: proto.String("test1")})
+		So(err, ShouldBeNil)
+		So(deleteResponse.GetStatusCode(), ShouldEqual, mds.FSStatusCode_FS_BUSY)
+	})
+
+	Convey("the bvars are served by http", t, func() {
+		cluster := startCluster(nil)
+		defer cluster.Stop()
+		code, body := httpGet("http://" + cluster.MdsDummyAddr + "/vars/curvefs_mds_status")
+		So(code, ShouldEqual, http.StatusOK)
+		So(body, ShouldEqual, "curvefs_mds_status : leader\n")
+
+		_, body = httpGet("http://" + cluster.MdsDummyAddr + "/vars/topology_fs_id_1*inode_num")
+		So(body, ShouldEqual, "topology_fs_id_1_inode_num : 2\n")
+
+		code, _ = httpGet("http://" + cluster.MdsDummyAddr + "/vars/unknown")
+		So(code, ShouldEqual, http.StatusNotFound)
+
+		_, externalAddr := cluster.MetaserverAddr(2)
+		_, body = httpGet("http://" + externalAddr + "/vars/curve_version")
+		So(body, ShouldEqual, "curve_version : 2.5.0\n")
+
+		_, body = httpGet("http://" + cluster.EtcdAddr + "/v2/stats/self")
+		So(body, ShouldContainSubstring, "StateLeader")
+	})
+
+	Convey("the offline metaserver refuses connections", t, func() {
+		cluster := startCluster(func(f *Fixture) {
+			f.Metaservers[2].Offline = true
+			f.Copysets[0].States = map[uint32]string{2: "error"}
+		})
+		defer cluster.Stop()
+		c, err := client.New(client.Options{MdsAddrs: []string{cluster.MdsAddr}, RpcTimeout: time.Second, RpcRetryTimes: 1})
+		So(err, ShouldBeNil)
+		key := client.CopysetKey{PoolId: 1, CopysetId: 1}
+		copysets, err := c.GetCopysetStatus(context.Background(), client.CopysetOptions{Copysets: []client.CopysetKey{key}, PeerStatus: true})
+		So(err, ShouldNotBeNil)
+		var clientErr *client.Error
+		So(errors.As(err, &clientErr), ShouldBeTrue)
+		So(clientErr.ExitCode, ShouldEqual, cmderror.EXIT_PARTIAL)
+
+		internalAddr, _ := cluster.MetaserverAddr(2)
+		status := copysets[key.Key()].Peer2Status[internalAddr]
+		So(status.GetStatus(), ShouldEqual, copyset.COPYSET_OP_STATUS_COPYSET_OP_STATUS_SUCCESS)
+		So(status.GetCopysetStatus().GetState(), ShouldEqual, 5)
+		internalAddr, _ = cluster.MetaserverAddr(3)
+		So(copysets[key.Key()].Peer2Status[internalAddr], ShouldBeNil)
+	})
+}
+
+func TestMdsLeader(t *testing.T) {
+	Convey("the rpc follows the mds leader when it moves", t, func() {
+		first := startCluster(nil)
+		defer first.Stop()
+		second := startCluster(func(f *Fixture) {
+			f.Mds.Status = "follower"
+		})
+		defer second.Stop()
+		c, err := client.New(client.Options{
+			MdsAddrs:      []string{first.MdsAddr, second.MdsAddr},
+			MdsDummyAddrs: []string{first.MdsDummyAddr, second.MdsDummyAddr},
+			RpcTimeout:    time.Second,
+		})
+		So(err, ShouldBeNil)
+		ctx := context.Background()
+
+		_, err = c.ListFs(ctx)
+		So(err, ShouldBeNil)
+		So(first.Calls("ListClusterFsInfo"), ShouldEqual, 1)
+		So(second.Calls("ListClusterFsInfo"), ShouldEqual, 0)
+
+		first.SetMdsStatus("follower")
+		second.SetMdsStatus("leader")
+		_, err = c.ListFs(ctx)
+		So(err, ShouldBeNil)
+		So(first.Calls("ListClusterFsInfo"), ShouldEqual, 1)
+		So(second.Calls("ListClusterFsInfo"), ShouldEqual, 1)
+
+		first.SetMdsStatus("leader")
+		second.SetMdsStatus("follower")
+		_, err = c.ListFs(ctx)
+		So(err, ShouldBeNil)
+		So(first.Calls("ListClusterFsInfo"), ShouldEqual, 2)
+		So(second.Calls("ListClusterFsInfo"), ShouldEqual, 1)
+
+		// no leader, the rpc is sent to all mds and refused
+		first.SetMdsStatus("follower")
+		_, err = c.ListFs(ctx)
+		var clientErr *client.Error
+		So(errors.As(err, &clientErr), ShouldBeTrue)
+		So(clientErr.ExitCode, ShouldEqual, cmderror.EXIT_UNREACHABLE)
+	})
+}
@@ -0,0 +1,224 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package fakecluster
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	cobrautil "github.com/opencurve/curve/tools-v2/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Fixture declares the curvefs cluster served by the fake servers,
+// the addresses of mds and metaservers are assigned when it starts.
+type Fixture struct {
+	ClusterId   string       `yaml:"clusterId"`
+	Version     string       `yaml:"version"` // curve_version of mds and metaservers
+	Mds         Mds          `yaml:"mds"`
+	Etcd        *Etcd        `yaml:"etcd"` // no etcd is served if nil
+	Pools       []Pool       `yaml:"pools"`
+	Zones       []Zone       `yaml:"zones"`
+	Servers     []Server     `yaml:"servers"`
+	Metaservers []Metaserver `yaml:"metaservers"`
+	Fs          []Fs         `yaml:"fs"`
+	Copysets    []Copyset    `yaml:"copysets"`
+	Partitions  []Partition  `yaml:"partitions"`
+	Inodes      []Inode      `yaml:"inodes"`
+}
+
+type Mds struct {
+	Status string            `yaml:"status"` // curvefs_mds_status, default is leader
+	Vars   map[string]string `yaml:"vars"`   // the other bvars, e.g. topology_fs_id_1_file_type_file_inode_num
+}
+
+type Etcd struct {
+	State   string `yaml:"state"` // leader or follower, default is leader
+	Version string `yaml:"version"`
+}
+
+type Pool struct {
+	Id         uint32 `yaml:"id"`
+	Name       string `yaml:"name"`
+	ReplicaNum uint32 `yaml:"replicaNum"`
+	CopysetNum uint64 `yaml:"copysetNum"`
+	ZoneNum    uint32 `yaml:"zoneNum"`
+}
+
+type Zone struct {
+	Id     uint32 `yaml:"id"`
+	Name   string `yaml:"name"`
+	PoolId uint32 `yaml:"poolId"`
+}
+
+type Server struct {
+	Id           uint32 `yaml:"id"`
+	Name         string `yaml:"name"`
+	ZoneId       uint32 `yaml:"zoneId"`
+	InternalIp   string `yaml:"internalIp"`
+	InternalPort uint32 `yaml:"internalPort"`
+	ExternalIp   string `yaml:"externalIp"`
+	ExternalPort uint32 `yaml:"externalPort"`
+}
+
+type Metaserver struct {
+	Id       uint32 `yaml:"id"`
+	Hostname string `yaml:"hostname"`
+	ServerId uint32 `yaml:"serverId"`
+	// the offline metaserver is not served, its addresses refuse connections
+	Offline bool              `yaml:"offline"`
+	Total   uint64            `yaml:"total"` // metadata usage
+	Used    uint64            `yaml:"used"`
+	Vars    map[string]string `yaml:"vars"`
+}
+
+type Fs struct {
+	Id          uint32   `yaml:"id"`
+	Name        string   `yaml:"name"`
+	Type        string   `yaml:"type"`   // s3, volume or hybrid, default is s3
+	Status      string   `yaml:"status"` // new, inited or deleting, default is inited
+	Capacity    uint64   `yaml:"capacity"`
+	BlockSize   uint64   `yaml:"blockSize"`
+	Owner       string   `yaml:"owner"`
+	Mountpoints []string `yaml:"mountpoints"` // hostname:port:path
+}
+
+type Copyset struct {
+	PoolId uint32   `yaml:"poolId"`
+	Id     uint32   `yaml:"id"`
+	Peers  []uint32 `yaml:"peers"` // the metaserver ids
+	Leader uint32   `yaml:"leader"`
+	Epoch  uint64   `yaml:"epoch"`
+	// the raft state of the peers by metaserver id, e.g. error,
+	// default is leader for the leader and follower for the others
+	States map[uint32]string `yaml:"states"`
+}
+
+type Partition struct {
+	Id        uint32 `yaml:"id"`
+	FsId      uint32 `yaml:"fsId"`
+	PoolId    uint32 `yaml:"poolId"`
+	CopysetId uint32 `yaml:"copysetId"`
+	Start     uint64 `yaml:"start"`
+	End       uint64 `yaml:"end"`
+	InodeNum  uint64 `yaml:"inodeNum"`
+	DentryNum uint64 `yaml:"dentryNum"`
+}
+
+type Inode struct {
+	FsId   uint32 `yaml:"fsId"`
+	Id     uint64 `yaml:"id"`
+	Type   string `yaml:"type"` // directory, file, symlink or s3, default is file
+	Length uint64 `yaml:"length"`
+	Mode   uint32 `yaml:"mode"`
+	Uid    uint32 `yaml:"uid"`
+	Gid    uint32 `yaml:"gid"`
+	Nlink  uint32 `yaml:"nlink"`
+}
+
+// LoadFixture reads the fixture from the yaml file
+func LoadFixture(path string) (*Fixture, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	fixture := &Fixture{}
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(fixture); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", path, err)
+	}
+	return fixture, nil
+}
+
+func (f *Fixture) metaserver(id uint32) *Metaserver {
+	for i := range f.Metaservers {
+		if f.Metaservers[i].Id == id {
+			return &f.Metaservers[i]
+		}
+	}
+	return nil
+}
+
+func (f *Fixture) copyset(poolId, copysetId uint32) *Copyset {
+	for i := range f.Copysets {
+		if f.Copysets[i].PoolId == poolId && f.Copysets[i].Id == copysetId {
+			return &f.Copysets[i]
+		}
+	}
+	return nil
+}
+
+// validate checks the references between the items of fixture
+func (f *Fixture) validate() error {
+	for _, copyset := range f.Copysets {
+		for _, peer := range copyset.Peers {
+			if f.metaserver(peer) == nil {
+				return fmt.Errorf("metaserver %d of copyset (%d,%d) is not found", peer, copyset.PoolId, copyset.Id)
+			}
+		}
+		for _, state := range copyset.States {
+			if _, err := copysetState(state); err != nil {
+				return err
+			}
+		}
+	}
+	for _, partition := range f.Partitions {
+		if f.copyset(partition.PoolId, partition.CopysetId) == nil {
+			return fmt.Errorf("copyset (%d,%d) of partition %d is not found", partition.PoolId, partition.CopysetId, partition.Id)
+		}
+	}
+	for _, fs := range f.Fs {
+		for _, mountpoint := range fs.Mountpoints {
+			if _, _, _, err := splitMountpoint(mountpoint); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copysetState returns the raft state by name, e.g. leader
+func copysetState(name string) (uint32, error) {
+	for state, stateName := range cobrautil.CopysetState_name {
+		if stateName == name {
+			return state, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown copyset state: %s", name)
+}
+
+// splitMountpoint splits the mountpoint like host:9000:/mnt/test
+func splitMountpoint(mountpoint string) (string, uint32, string, error) {
+	items := strings.SplitN(mountpoint, ":", 3)
+	if len(items) != 3 {
+		return "", 0, "", fmt.Errorf("invalid mountpoint: %s", mountpoint)
+	}
+	port, err := strconv.ParseUint(items[1], 10, 32)
+	if err != nil {
+		return "", 0, "", fmt.Errorf("invalid mountpoint: %s", mountpoint)
+	}
+	return items[0], uint32(port), items[2], nil
+}
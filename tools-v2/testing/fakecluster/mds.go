@@ -0,0 +1,187 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package fakecluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/common"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/mds"
+)
+
+const (
+	ROOT_INODE_ID = 1
+)
+
+var (
+	fsTypeName = map[common.FSType]string{
+		common.FSType_TYPE_VOLUME: "volume",
+		common.FSType_TYPE_S3:     "s3",
+		common.FSType_TYPE_HYBRID: "hybrid",
+	}
+	fsStatusName = map[mds.FsStatus]string{
+		mds.FsStatus_NEW:      "new",
+		mds.FsStatus_INITED:   "inited",
+		mds.FsStatus_DELETING: "deleting",
+	}
+)
+
+type mdsService struct {
+	mds.UnimplementedMdsServiceServer
+	cluster *Cluster
+}
+
+func (fs *Fs) info() *mds.FsInfo {
+	fsType := common.FSType_TYPE_S3
+	for t, name := range fsTypeName {
+		if name == fs.Type {
+			fsType = t
+		}
+	}
+	status := mds.FsStatus_INITED
+	for s, name := range fsStatusName {
+		if name == fs.Status {
+			status = s
+		}
+	}
+	id := fs.Id
+	name := fs.Name
+	rootInodeId := uint64(ROOT_INODE_ID)
+	capacity := fs.Capacity
+	blockSize := fs.BlockSize
+	mountNum := uint32(len(fs.Mountpoints))
+	owner := fs.Owner
+	info := &mds.FsInfo{
+		FsId:        &id,
+		FsName:      &name,
+		Status:      &status,
+		RootInodeId: &rootInodeId,
+		Capacity:    &capacity,
+		BlockSize:   &blockSize,
+		MountNum:    &mountNum,
+		FsType:      &fsType,
+		Detail:      &mds.FsDetail{},
+		Owner:       &owner,
+	}
+	for _, mountpoint := range fs.Mountpoints {
+		hostname, port, path, _ := splitMountpoint(mountpoint)
+		info.Mountpoints = append(info.Mountpoints, &mds.Mountpoint{
+			Hostname: &hostname,
+			Port:     &port,
+			Path:     &path,
+		})
+	}
+	return info
+}
+
+// fs returns the index of fs by id or name, -1 if not found
+func (c *Cluster) fs(id *uint32, name *string) int {
+	for i, fs := range c.fixture.Fs {
+		if (id != nil && fs.Id == *id) || (name != nil && fs.Name == *name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *mdsService) ListClusterFsInfo(ctx context.Context, request *mds.ListClusterFsInfoRequest) (*mds.ListClusterFsInfoResponse, error) {
+	s.cluster.mutex.Lock()
+	defer s.cluster.mutex.Unlock()
+	response := &mds.ListClusterFsInfoResponse{}
+	for i := range s.cluster.fixture.Fs {
+		response.FsInfo = append(response.FsInfo, s.cluster.fixture.Fs[i].info())
+	}
+	return response, nil
+}
+
+func (s *mdsService) GetFsInfo(ctx context.Context, request *mds.GetFsInfoRequest) (*mds.GetFsInfoResponse, error) {
+	s.cluster.mutex.Lock()
+	defer s.cluster.mutex.Unlock()
+	index := s.cluster.fs(request.FsId, request.FsName)
+	if index < 0 {
+		return &mds.GetFsInfoResponse{StatusCode: mds.FSStatusCode_NOT_FOUND.Enum()}, nil
+	}
+	return &mds.GetFsInfoResponse{
+		StatusCode: mds.FSStatusCode_OK.Enum(),
+		FsInfo:     s.cluster.fixture.Fs[index].info(),
+	}, nil
+}
+
+func (s *mdsService) CreateFs(ctx context.Context, request *mds.CreateFsRequest) (*mds.CreateFsResponse, error) {
+	s.cluster.mutex.Lock()
+	defer s.cluster.mutex.Unlock()
+	if s.cluster.fs(nil, request.FsName) >= 0 {
+		return &mds.CreateFsResponse{StatusCode: mds.FSStatusCode_FS_EXIST.Enum()}, nil
+	}
+	fs := Fs{
+		Name:      request.GetFsName(),
+		Type:      fsTypeName[request.GetFsType()],
+		Status:    fsStatusName[mds.FsStatus_INITED],
+		Capacity:  request.GetCapacity(),
+		BlockSize: request.GetBlockSize(),
+		Owner:     request.GetOwner(),
+	}
+	for _, other := range s.cluster.fixture.Fs {
+		if other.Id >= fs.Id {
+			fs.Id = other.Id + 1
+		}
+	}
+	s.cluster.fixture.Fs = append(s.cluster.fixture.Fs, fs)
+	return &mds.CreateFsResponse{
+		StatusCode: mds.FSStatusCode_OK.Enum(),
+		FsInfo:     fs.info(),
+	}, nil
+}
+
+func (s *mdsService) DeleteFs(ctx context.Context, request *mds.DeleteFsRequest) (*mds.DeleteFsResponse, error) {
+	s.cluster.mutex.Lock()
+	defer s.cluster.mutex.Unlock()
+	index := s.cluster.fs(nil, request.FsName)
+	if index < 0 {
+		return &mds.DeleteFsResponse{StatusCode: mds.FSStatusCode_NOT_FOUND.Enum()}, nil
+	}
+	if len(s.cluster.fixture.Fs[index].Mountpoints) > 0 {
+		return &mds.DeleteFsResponse{StatusCode: mds.FSStatusCode_FS_BUSY.Enum()}, nil
+	}
+	s.cluster.fixture.Fs = append(s.cluster.fixture.Fs[:index], s.cluster.fixture.Fs[index+1:]...)
+	return &mds.DeleteFsResponse{StatusCode: mds.FSStatusCode_OK.Enum()}, nil
+}
+
+func (s *mdsService) UmountFs(ctx context.Context, request *mds.UmountFsRequest) (*mds.UmountFsResponse, error) {
+	s.cluster.mutex.Lock()
+	defer s.cluster.mutex.Unlock()
+	index := s.cluster.fs(nil, request.FsName)
+	if index < 0 {
+		return &mds.UmountFsResponse{StatusCode: mds.FSStatusCode_NOT_FOUND.Enum()}, nil
+	}
+	fs := &s.cluster.fixture.Fs[index]
+	point := request.GetMountpoint()
+	target := fmt.Sprintf("%s:%d:%s", point.GetHostname(), point.GetPort(), point.GetPath())
+	for i, mountpoint := range fs.Mountpoints {
+		if mountpoint == target {
+			fs.Mountpoints = append(fs.Mountpoints[:i], fs.Mountpoints[i+1:]...)
+			return &mds.UmountFsResponse{StatusCode: mds.FSStatusCode_OK.Enum()}, nil
+		}
+	}
+	return &mds.UmountFsResponse{StatusCode: mds.FSStatusCode_MOUNT_POINT_NOT_EXIST.Enum()}, nil
+}
@@ -0,0 +1,146 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package fakecluster
+
+import (
+	"context"
+
+	cobrautil "github.com/opencurve/curve/tools-v2/internal/utils"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/copyset"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/metaserver"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	inodeTypeName = map[metaserver.FsFileType]string{
+		metaserver.FsFileType_TYPE_DIRECTORY: "directory",
+		metaserver.FsFileType_TYPE_FILE:      "file",
+		metaserver.FsFileType_TYPE_SYM_LINK:  "symlink",
+		metaserver.FsFileType_TYPE_S3:        "s3",
+	}
+)
+
+type metaserverService struct {
+	metaserver.UnimplementedMetaServerServiceServer
+	cluster *Cluster
+	id      uint32
+}
+
+type copysetService struct {
+	copyset.UnimplementedCopysetServiceServer
+	cluster *Cluster
+	id      uint32
+}
+
+func (inode *Inode) info() *metaserver.Inode {
+	inodeType := metaserver.FsFileType_TYPE_FILE
+	for t, name := range inodeTypeName {
+		if name == inode.Type {
+			inodeType = t
+		}
+	}
+	return &metaserver.Inode{
+		InodeId: proto.Uint64(inode.Id),
+		FsId:    proto.Uint32(inode.FsId),
+		Length:  proto.Uint64(inode.Length),
+		Uid:     proto.Uint32(inode.Uid),
+		Gid:     proto.Uint32(inode.Gid),
+		Mode:    proto.Uint32(inode.Mode),
+		Nlink:   proto.Uint32(inode.Nlink),
+		Type:    &inodeType,
+	}
+}
+
+func (s *metaserverService) GetInode(ctx context.Context, request *metaserver.GetInodeRequest) (*metaserver.GetInodeResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	cs := c.fixture.copyset(request.GetPoolId(), request.GetCopysetId())
+	if cs == nil {
+		return &metaserver.GetInodeResponse{StatusCode: metaserver.MetaStatusCode_COPYSET_NOTEXIST.Enum()}, nil
+	}
+	if cs.Leader != s.id {
+		return &metaserver.GetInodeResponse{StatusCode: metaserver.MetaStatusCode_REDIRECTED.Enum()}, nil
+	}
+	for i := range c.fixture.Inodes {
+		inode := &c.fixture.Inodes[i]
+		if inode.FsId == request.GetFsId() && inode.Id == request.GetInodeId() {
+			return &metaserver.GetInodeResponse{
+				StatusCode: metaserver.MetaStatusCode_OK.Enum(),
+				Inode:      inode.info(),
+			}, nil
+		}
+	}
+	return &metaserver.GetInodeResponse{StatusCode: metaserver.MetaStatusCode_NOT_FOUND.Enum()}, nil
+}
+
+// copysetStatus returns the status of copyset on this metaserver
+func (s *copysetService) copysetStatus(request *copyset.CopysetStatusRequest) *copyset.CopysetStatusResponse {
+	c := s.cluster
+	cs := c.fixture.copyset(request.GetPoolId(), request.GetCopysetId())
+	isPeer := false
+	if cs != nil {
+		for _, peer := range cs.Peers {
+			isPeer = isPeer || peer == s.id
+		}
+	}
+	if !isPeer {
+		return &copyset.CopysetStatusResponse{
+			Status: copyset.COPYSET_OP_STATUS_COPYSET_OP_STATUS_COPYSET_NOTEXIST.Enum(),
+		}
+	}
+
+	state := uint32(cobrautil.STATE_FOLLOWER)
+	if cs.Leader == s.id {
+		state = uint32(cobrautil.STATE_LEADER)
+	}
+	if name, ok := cs.States[s.id]; ok {
+		state, _ = copysetState(name)
+	}
+	return &copyset.CopysetStatusResponse{
+		Status: copyset.COPYSET_OP_STATUS_COPYSET_OP_STATUS_SUCCESS.Enum(),
+		CopysetStatus: &copyset.CopysetStatus{
+			State:    proto.Uint32(state),
+			Peer:     c.peer(s.id),
+			Leader:   c.peer(cs.Leader),
+			Readonly: proto.Bool(false),
+			Term:     proto.Int64(1),
+			Epoch:    proto.Uint64(cs.Epoch),
+		},
+	}
+}
+
+func (s *copysetService) GetCopysetStatus(ctx context.Context, request *copyset.CopysetStatusRequest) (*copyset.CopysetStatusResponse, error) {
+	s.cluster.mutex.Lock()
+	defer s.cluster.mutex.Unlock()
+	return s.copysetStatus(request), nil
+}
+
+func (s *copysetService) GetCopysetsStatus(ctx context.Context, request *copyset.CopysetsStatusRequest) (*copyset.CopysetsStatusResponse, error) {
+	s.cluster.mutex.Lock()
+	defer s.cluster.mutex.Unlock()
+	response := &copyset.CopysetsStatusResponse{}
+	for _, copysetRequest := range request.GetCopysets() {
+		response.Status = append(response.Status, s.copysetStatus(copysetRequest))
+	}
+	return response, nil
+}
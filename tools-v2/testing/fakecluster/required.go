@@ -0,0 +1,72 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package fakecluster
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fillRequired sets the unset required fields of message to their default
+// values, otherwise the proto2 response can not be marshaled
+func fillRequired(message protoreflect.Message) {
+	fields := message.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Cardinality() == protoreflect.Required && !message.Has(field) {
+			if field.Message() != nil {
+				message.Set(field, message.NewField(field))
+			} else {
+				message.Set(field, field.Default())
+			}
+		}
+	}
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.IsList() && field.Message() != nil:
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				fillRequired(list.Get(i).Message())
+			}
+		case field.IsMap() && field.MapValue().Message() != nil:
+			value.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+				fillRequired(value.Message())
+				return true
+			})
+		case !field.IsList() && !field.IsMap() && field.Message() != nil:
+			fillRequired(value.Message())
+		}
+		return true
+	})
+}
+
+// fillRequiredInterceptor fills the required fields of every response
+func fillRequiredInterceptor(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	response, err := handler(ctx, request)
+	if message, ok := response.(proto.Message); ok && err == nil {
+		fillRequired(message.ProtoReflect())
+	}
+	return response, err
+}
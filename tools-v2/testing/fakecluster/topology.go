@@ -0,0 +1,518 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package fakecluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/common"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/heartbeat"
+	"github.com/opencurve/curve/tools-v2/proto/curvefs/proto/topology"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DEFAULT_CLUSTER_ID = "fake"
+)
+
+// the redundanceAndPlaceMentPolicy of pool
+type policy struct {
+	ReplicaNum uint32 `json:"replicaNum"`
+	CopysetNum uint64 `json:"copysetNum"`
+	ZoneNum    uint32 `json:"zoneNum"`
+}
+
+type topologyService struct {
+	topology.UnimplementedTopologyServiceServer
+	cluster *Cluster
+}
+
+func ok() *topology.TopoStatusCode {
+	return topology.TopoStatusCode_TOPO_OK.Enum()
+}
+
+func (c *Cluster) poolInfo(pool Pool) *topology.PoolInfo {
+	data, _ := json.Marshal(policy{ReplicaNum: pool.ReplicaNum, CopysetNum: pool.CopysetNum, ZoneNum: pool.ZoneNum})
+	return &topology.PoolInfo{
+		PoolID:                       proto.Uint32(pool.Id),
+		PoolName:                     proto.String(pool.Name),
+		CreateTime:                   proto.Uint64(0),
+		RedundanceAndPlaceMentPolicy: data,
+	}
+}
+
+func (c *Cluster) zoneInfo(zone Zone) *topology.ZoneInfo {
+	info := &topology.ZoneInfo{
+		ZoneID:   proto.Uint32(zone.Id),
+		ZoneName: proto.String(zone.Name),
+		PoolID:   proto.Uint32(zone.PoolId),
+	}
+	if pool := c.pool(zone.PoolId, ""); pool >= 0 {
+		info.PoolName = proto.String(c.fixture.Pools[pool].Name)
+	}
+	return info
+}
+
+func (c *Cluster) serverInfo(server Server) *topology.ServerInfo {
+	info := &topology.ServerInfo{
+		ServerID:     proto.Uint32(server.Id),
+		HostName:     proto.String(server.Name),
+		InternalIp:   proto.String(server.InternalIp),
+		InternalPort: proto.Uint32(server.InternalPort),
+		ExternalIp:   proto.String(server.ExternalIp),
+		ExternalPort: proto.Uint32(server.ExternalPort),
+		ZoneID:       proto.Uint32(server.ZoneId),
+	}
+	if info.GetInternalIp() == "" {
+		info.InternalIp = proto.String(LOCAL_IP)
+	}
+	if info.GetExternalIp() == "" {
+		info.ExternalIp = proto.String(LOCAL_IP)
+	}
+	if zone := c.zone(server.ZoneId, "", ""); zone >= 0 {
+		zoneInfo := c.zoneInfo(c.fixture.Zones[zone])
+		info.ZoneName = zoneInfo.ZoneName
+		info.PoolID = zoneInfo.PoolID
+		info.PoolName = zoneInfo.PoolName
+	}
+	return info
+}
+
+func (c *Cluster) metaserverInfo(ms Metaserver) *topology.MetaServerInfo {
+	addrs := c.metaservers[ms.Id]
+	state := topology.OnlineState_ONLINE
+	if ms.Offline {
+		state = topology.OnlineState_OFFLINE
+	}
+	return &topology.MetaServerInfo{
+		MetaServerID: proto.Uint32(ms.Id),
+		Hostname:     proto.String(ms.Hostname),
+		InternalIp:   proto.String(addrs.internal.IP.String()),
+		InternalPort: proto.Uint32(uint32(addrs.internal.Port)),
+		ExternalIp:   proto.String(addrs.external.IP.String()),
+		ExternalPort: proto.Uint32(uint32(addrs.external.Port)),
+		OnlineState:  &state,
+		ServerId:     proto.Uint32(ms.ServerId),
+	}
+}
+
+// peer returns the peer of metaserver like 127.0.0.1:6800:0
+func (c *Cluster) peer(id uint32) *common.Peer {
+	peer := &common.Peer{Id: proto.Uint64(uint64(id))}
+	if addrs := c.metaservers[id]; addrs != nil {
+		peer.Address = proto.String(fmt.Sprintf("%s:0", addrs.internal))
+	}
+	return peer
+}
+
+func (c *Cluster) copysetInfo(copyset Copyset) *heartbeat.CopySetInfo {
+	info := &heartbeat.CopySetInfo{
+		PoolId:     proto.Uint32(copyset.PoolId),
+		CopysetId:  proto.Uint32(copyset.Id),
+		Epoch:      proto.Uint64(copyset.Epoch),
+		LeaderPeer: c.peer(copyset.Leader),
+	}
+	for _, id := range copyset.Peers {
+		info.Peers = append(info.Peers, c.peer(id))
+	}
+	for _, partition := range c.fixture.Partitions {
+		if partition.PoolId == copyset.PoolId && partition.CopysetId == copyset.Id {
+			info.PartitionInfoList = append(info.PartitionInfoList, partitionInfo(partition))
+		}
+	}
+	return info
+}
+
+func partitionInfo(partition Partition) *common.PartitionInfo {
+	return &common.PartitionInfo{
+		FsId:        proto.Uint32(partition.FsId),
+		PoolId:      proto.Uint32(partition.PoolId),
+		CopysetId:   proto.Uint32(partition.CopysetId),
+		PartitionId: proto.Uint32(partition.Id),
+		Start:       proto.Uint64(partition.Start),
+		End:         proto.Uint64(partition.End),
+		TxId:        proto.Uint64(0),
+		Status:      common.PartitionStatus_READWRITE.Enum(),
+		InodeNum:    proto.Uint64(partition.InodeNum),
+		DentryNum:   proto.Uint64(partition.DentryNum),
+	}
+}
+
+// pool returns the index of pool by id or name, -1 if not found
+func (c *Cluster) pool(id uint32, name string) int {
+	for i, pool := range c.fixture.Pools {
+		if (name == "" && pool.Id == id) || (name != "" && pool.Name == name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// zone returns the index of zone by id or name in the pool, -1 if not found
+func (c *Cluster) zone(id uint32, name string, poolName string) int {
+	for i, zone := range c.fixture.Zones {
+		if name == "" && zone.Id == id {
+			return i
+		}
+		if name != "" && zone.Name == name {
+			if pool := c.pool(0, poolName); pool >= 0 && c.fixture.Pools[pool].Id == zone.PoolId {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (s *topologyService) ListTopology(ctx context.Context, request *topology.ListTopologyRequest) (*topology.ListTopologyResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	clusterId := c.fixture.ClusterId
+	if clusterId == "" {
+		clusterId = DEFAULT_CLUSTER_ID
+	}
+	response := &topology.ListTopologyResponse{
+		ClusterId:   proto.String(clusterId),
+		Pools:       &topology.ListPoolResponse{StatusCode: ok()},
+		Zones:       &topology.ListZoneResponse{StatusCode: ok()},
+		Servers:     &topology.ListServerResponse{StatusCode: ok()},
+		Metaservers: &topology.ListMetaServerResponse{StatusCode: ok()},
+	}
+	for _, pool := range c.fixture.Pools {
+		response.Pools.PoolInfos = append(response.Pools.PoolInfos, c.poolInfo(pool))
+	}
+	for _, zone := range c.fixture.Zones {
+		response.Zones.ZoneInfos = append(response.Zones.ZoneInfos, c.zoneInfo(zone))
+	}
+	for _, server := range c.fixture.Servers {
+		response.Servers.ServerInfos = append(response.Servers.ServerInfos, c.serverInfo(server))
+	}
+	for _, ms := range c.fixture.Metaservers {
+		response.Metaservers.MetaServerInfos = append(response.Metaservers.MetaServerInfos, c.metaserverInfo(ms))
+	}
+	return response, nil
+}
+
+func (s *topologyService) ListPool(ctx context.Context, request *topology.ListPoolRequest) (*topology.ListPoolResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	response := &topology.ListPoolResponse{StatusCode: ok()}
+	for _, pool := range c.fixture.Pools {
+		response.PoolInfos = append(response.PoolInfos, c.poolInfo(pool))
+	}
+	return response, nil
+}
+
+func (s *topologyService) ListPoolZone(ctx context.Context, request *topology.ListPoolZoneRequest) (*topology.ListPoolZoneResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.pool(request.GetPoolID(), "") < 0 {
+		return &topology.ListPoolZoneResponse{StatusCode: topology.TopoStatusCode_TOPO_POOL_NOT_FOUND.Enum()}, nil
+	}
+	response := &topology.ListPoolZoneResponse{StatusCode: ok()}
+	for _, zone := range c.fixture.Zones {
+		if zone.PoolId == request.GetPoolID() {
+			response.Zones = append(response.Zones, c.zoneInfo(zone))
+		}
+	}
+	return response, nil
+}
+
+func (s *topologyService) ListZoneServer(ctx context.Context, request *topology.ListZoneServerRequest) (*topology.ListZoneServerResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	zone := c.zone(request.GetZoneID(), request.GetZoneName(), request.GetPoolName())
+	if zone < 0 {
+		return &topology.ListZoneServerResponse{StatusCode: topology.TopoStatusCode_TOPO_ZONE_NOT_FOUND.Enum()}, nil
+	}
+	response := &topology.ListZoneServerResponse{StatusCode: ok()}
+	for _, server := range c.fixture.Servers {
+		if server.ZoneId == c.fixture.Zones[zone].Id {
+			response.ServerInfo = append(response.ServerInfo, c.serverInfo(server))
+		}
+	}
+	return response, nil
+}
+
+func (s *topologyService) ListMetaServer(ctx context.Context, request *topology.ListMetaServerRequest) (*topology.ListMetaServerResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	response := &topology.ListMetaServerResponse{StatusCode: ok()}
+	for _, ms := range c.fixture.Metaservers {
+		if ms.ServerId == request.GetServerID() {
+			response.MetaServerInfos = append(response.MetaServerInfos, c.metaserverInfo(ms))
+		}
+	}
+	return response, nil
+}
+
+// GetMetaServer finds the metaserver by id, or by its internal or external address
+func (s *topologyService) GetMetaServer(ctx context.Context, request *topology.GetMetaServerInfoRequest) (*topology.GetMetaServerInfoResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, ms := range c.fixture.Metaservers {
+		info := c.metaserverInfo(ms)
+		matched := request.MetaServerID != nil && ms.Id == request.GetMetaServerID()
+		if request.HostIp != nil {
+			matched = (info.GetInternalIp() == request.GetHostIp() && info.GetInternalPort() == request.GetPort()) ||
+				(info.GetExternalIp() == request.GetHostIp() && info.GetExternalPort() == request.GetPort())
+		}
+		if matched {
+			return &topology.GetMetaServerInfoResponse{StatusCode: ok(), MetaServerInfo: info}, nil
+		}
+	}
+	return &topology.GetMetaServerInfoResponse{StatusCode: topology.TopoStatusCode_TOPO_METASERVER_NOT_FOUND.Enum()}, nil
+}
+
+func (s *topologyService) ListPartition(ctx context.Context, request *topology.ListPartitionRequest) (*topology.ListPartitionResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	response := &topology.ListPartitionResponse{StatusCode: ok()}
+	for _, partition := range c.fixture.Partitions {
+		if partition.FsId == request.GetFsId() {
+			response.PartitionInfoList = append(response.PartitionInfoList, partitionInfo(partition))
+		}
+	}
+	return response, nil
+}
+
+func (s *topologyService) GetCopysetOfPartition(ctx context.Context, request *topology.GetCopysetOfPartitionRequest) (*topology.GetCopysetOfPartitionResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	response := &topology.GetCopysetOfPartitionResponse{
+		StatusCode: ok(),
+		CopysetMap: make(map[uint32]*topology.Copyset),
+	}
+	for _, id := range request.GetPartitionId() {
+		found := false
+		for _, partition := range c.fixture.Partitions {
+			if partition.Id != id {
+				continue
+			}
+			found = true
+			copyset := c.fixture.copyset(partition.PoolId, partition.CopysetId)
+			value := &topology.Copyset{
+				PoolId:    proto.Uint32(copyset.PoolId),
+				CopysetId: proto.Uint32(copyset.Id),
+			}
+			for _, peer := range copyset.Peers {
+				value.Peers = append(value.Peers, c.peer(peer))
+			}
+			response.CopysetMap[id] = value
+		}
+		if !found {
+			return &topology.GetCopysetOfPartitionResponse{StatusCode: topology.TopoStatusCode_TOPO_PARTITION_NOT_FOUND.Enum()}, nil
+		}
+	}
+	return response, nil
+}
+
+func (s *topologyService) GetCopysetsInfo(ctx context.Context, request *topology.GetCopysetsInfoRequest) (*topology.GetCopysetsInfoResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	response := &topology.GetCopysetsInfoResponse{}
+	for _, key := range request.GetCopysetKeys() {
+		copyset := c.fixture.copyset(key.GetPoolId(), key.GetCopysetId())
+		if copyset == nil {
+			response.CopysetValues = append(response.CopysetValues, &topology.CopysetValue{
+				StatusCode: topology.TopoStatusCode_TOPO_COPYSET_NOT_FOUND.Enum(),
+			})
+			continue
+		}
+		response.CopysetValues = append(response.CopysetValues, &topology.CopysetValue{
+			StatusCode:  ok(),
+			CopysetInfo: c.copysetInfo(*copyset),
+		})
+	}
+	return response, nil
+}
+
+func (s *topologyService) ListCopysetInfo(ctx context.Context, request *topology.ListCopysetInfoRequest) (*topology.ListCopysetInfoResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	response := &topology.ListCopysetInfoResponse{}
+	for _, copyset := range c.fixture.Copysets {
+		response.CopysetValues = append(response.CopysetValues, &topology.CopysetValue{
+			StatusCode:  ok(),
+			CopysetInfo: c.copysetInfo(copyset),
+		})
+	}
+	return response, nil
+}
+
+func (s *topologyService) StatMetadataUsage(ctx context.Context, request *topology.StatMetadataUsageRequest) (*topology.StatMetadataUsageResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	response := &topology.StatMetadataUsageResponse{}
+	for _, ms := range c.fixture.Metaservers {
+		response.MetadataUsages = append(response.MetadataUsages, &topology.MetadataUsage{
+			MetaserverAddr: proto.String(c.metaservers[ms.Id].internal.String()),
+			Total:          proto.Uint64(ms.Total),
+			Used:           proto.Uint64(ms.Used),
+		})
+	}
+	return response, nil
+}
+
+func (s *topologyService) CreatePool(ctx context.Context, request *topology.CreatePoolRequest) (*topology.CreatePoolResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.pool(0, request.GetPoolName()) >= 0 {
+		return &topology.CreatePoolResponse{StatusCode: topology.TopoStatusCode_TOPO_NAME_DUPLICATED.Enum()}, nil
+	}
+	var p policy
+	if err := json.Unmarshal(request.GetRedundanceAndPlaceMentPolicy(), &p); err != nil {
+		return &topology.CreatePoolResponse{StatusCode: topology.TopoStatusCode_TOPO_INVALID_PARAM.Enum()}, nil
+	}
+	pool := Pool{
+		Name:       request.GetPoolName(),
+		ReplicaNum: p.ReplicaNum,
+		CopysetNum: p.CopysetNum,
+		ZoneNum:    p.ZoneNum,
+	}
+	for _, other := range c.fixture.Pools {
+		if other.Id >= pool.Id {
+			pool.Id = other.Id + 1
+		}
+	}
+	c.fixture.Pools = append(c.fixture.Pools, pool)
+	info := c.poolInfo(pool)
+	info.CreateTime = proto.Uint64(uint64(time.Now().Unix()))
+	return &topology.CreatePoolResponse{StatusCode: ok(), PoolInfo: info}, nil
+}
+
+func (s *topologyService) CreateZone(ctx context.Context, request *topology.CreateZoneRequest) (*topology.CreateZoneResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	pool := c.pool(0, request.GetPoolName())
+	if pool < 0 {
+		return &topology.CreateZoneResponse{StatusCode: topology.TopoStatusCode_TOPO_POOL_NOT_FOUND.Enum()}, nil
+	}
+	if c.zone(0, request.GetZoneName(), request.GetPoolName()) >= 0 {
+		return &topology.CreateZoneResponse{StatusCode: topology.TopoStatusCode_TOPO_NAME_DUPLICATED.Enum()}, nil
+	}
+	zone := Zone{Name: request.GetZoneName(), PoolId: c.fixture.Pools[pool].Id}
+	for _, other := range c.fixture.Zones {
+		if other.Id >= zone.Id {
+			zone.Id = other.Id + 1
+		}
+	}
+	c.fixture.Zones = append(c.fixture.Zones, zone)
+	return &topology.CreateZoneResponse{StatusCode: ok(), ZoneInfo: c.zoneInfo(zone)}, nil
+}
+
+func (s *topologyService) RegistServer(ctx context.Context, request *topology.ServerRegistRequest) (*topology.ServerRegistResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	zone := c.zone(0, request.GetZoneName(), request.GetPoolName())
+	if zone < 0 {
+		return &topology.ServerRegistResponse{StatusCode: topology.TopoStatusCode_TOPO_ZONE_NOT_FOUND.Enum()}, nil
+	}
+	for _, other := range c.fixture.Servers {
+		if other.Name == request.GetHostName() {
+			return &topology.ServerRegistResponse{StatusCode: topology.TopoStatusCode_TOPO_NAME_DUPLICATED.Enum()}, nil
+		}
+	}
+	server := Server{
+		Name:         request.GetHostName(),
+		ZoneId:       c.fixture.Zones[zone].Id,
+		InternalIp:   request.GetInternalIp(),
+		InternalPort: request.GetInternalPort(),
+		ExternalIp:   request.GetExternalIp(),
+		ExternalPort: request.GetExternalPort(),
+	}
+	for _, other := range c.fixture.Servers {
+		if other.Id >= server.Id {
+			server.Id = other.Id + 1
+		}
+	}
+	c.fixture.Servers = append(c.fixture.Servers, server)
+	return &topology.ServerRegistResponse{StatusCode: ok(), ServerID: proto.Uint32(server.Id)}, nil
+}
+
+func (s *topologyService) DeletePool(ctx context.Context, request *topology.DeletePoolRequest) (*topology.DeletePoolResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	pool := c.pool(request.GetPoolID(), "")
+	if pool < 0 {
+		return &topology.DeletePoolResponse{StatusCode: topology.TopoStatusCode_TOPO_POOL_NOT_FOUND.Enum()}, nil
+	}
+	for _, zone := range c.fixture.Zones {
+		if zone.PoolId == request.GetPoolID() {
+			return &topology.DeletePoolResponse{StatusCode: topology.TopoStatusCode_TOPO_CANNOT_REMOVE_WHEN_NOT_EMPTY.Enum()}, nil
+		}
+	}
+	c.fixture.Pools = append(c.fixture.Pools[:pool], c.fixture.Pools[pool+1:]...)
+	return &topology.DeletePoolResponse{StatusCode: ok()}, nil
+}
+
+func (s *topologyService) DeleteZone(ctx context.Context, request *topology.DeleteZoneRequest) (*topology.DeleteZoneResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	zone := c.zone(request.GetZoneID(), "", "")
+	if zone < 0 {
+		return &topology.DeleteZoneResponse{StatusCode: topology.TopoStatusCode_TOPO_ZONE_NOT_FOUND.Enum()}, nil
+	}
+	for _, server := range c.fixture.Servers {
+		if server.ZoneId == request.GetZoneID() {
+			return &topology.DeleteZoneResponse{StatusCode: topology.TopoStatusCode_TOPO_CANNOT_REMOVE_WHEN_NOT_EMPTY.Enum()}, nil
+		}
+	}
+	c.fixture.Zones = append(c.fixture.Zones[:zone], c.fixture.Zones[zone+1:]...)
+	return &topology.DeleteZoneResponse{StatusCode: ok()}, nil
+}
+
+func (s *topologyService) DeleteServer(ctx context.Context, request *topology.DeleteServerRequest) (*topology.DeleteServerResponse, error) {
+	c := s.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, ms := range c.fixture.Metaservers {
+		if ms.ServerId == request.GetServerID() {
+			return &topology.DeleteServerResponse{StatusCode: topology.TopoStatusCode_TOPO_CANNOT_REMOVE_WHEN_NOT_EMPTY.Enum()}, nil
+		}
+	}
+	for i, server := range c.fixture.Servers {
+		if server.Id == request.GetServerID() {
+			c.fixture.Servers = append(c.fixture.Servers[:i], c.fixture.Servers[i+1:]...)
+			return &topology.DeleteServerResponse{StatusCode: ok()}, nil
+		}
+	}
+	return &topology.DeleteServerResponse{StatusCode: topology.TopoStatusCode_TOPO_SERVER_NOT_FOUND.Enum()}, nil
+}
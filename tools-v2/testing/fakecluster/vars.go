@@ -0,0 +1,129 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+package fakecluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	VARS_PREFIX     = "/vars/"
+	DEFAULT_VERSION = "fake"
+)
+
+// varsHandler serves the bvars like brpc, e.g. /vars/curve_version or
+// /vars/topology_fs_id_1*inode_num, one "name : value" every line
+func varsHandler(vars func() map[string]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, VARS_PREFIX) {
+			http.NotFound(w, r)
+			return
+		}
+		patterns := strings.FieldsFunc(strings.TrimPrefix(r.URL.Path, VARS_PREFIX), func(c rune) bool {
+			return c == ',' || c == ';'
+		})
+		var lines []string
+		for name, value := range vars() {
+			for _, pattern := range patterns {
+				if matched, _ := path.Match(pattern, name); matched {
+					lines = append(lines, fmt.Sprintf("%s : %s", name, value))
+					break
+				}
+			}
+		}
+		if len(lines) == 0 {
+			http.Error(w, fmt.Sprintf("Fail to find any bvar by `%s'", strings.Join(patterns, ",")), http.StatusNotFound)
+			return
+		}
+		sort.Strings(lines)
+		fmt.Fprintln(w, strings.Join(lines, "\n"))
+	})
+}
+
+// etcdHandler serves the status and version of etcd
+func etcdHandler(etcd Etcd) http.Handler {
+	state := "StateLeader"
+	if etcd.State == "follower" {
+		state = "StateFollower"
+	}
+	version := etcd.Version
+	if version == "" {
+		version = DEFAULT_VERSION
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/stats/self", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"state": state})
+	})
+	mux.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"etcdserver": version, "etcdcluster": version})
+	})
+	return mux
+}
+
+func (c *Cluster) version() string {
+	if c.fixture.Version == "" {
+		return DEFAULT_VERSION
+	}
+	return c.fixture.Version
+}
+
+// mdsVars returns the bvars of mds, the inode number of every fs is
+// summed up by its partitions
+func (c *Cluster) mdsVars() map[string]string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	vars := map[string]string{
+		"curvefs_mds_status": "leader",
+		"curve_version":      c.version(),
+	}
+	if c.fixture.Mds.Status != "" {
+		vars["curvefs_mds_status"] = c.fixture.Mds.Status
+	}
+	fsId2InodeNum := make(map[uint32]uint64)
+	for _, partition := range c.fixture.Partitions {
+		fsId2InodeNum[partition.FsId] += partition.InodeNum
+	}
+	for fsId, inodeNum := range fsId2InodeNum {
+		vars[fmt.Sprintf("topology_fs_id_%d_inode_num", fsId)] = fmt.Sprintf("%d", inodeNum)
+	}
+	for name, value := range c.fixture.Mds.Vars {
+		vars[name] = value
+	}
+	return vars
+}
+
+func (c *Cluster) metaserverVars(extra map[string]string) map[string]string {
+	vars := map[string]string{
+		"pid":           fmt.Sprintf("%d", os.Getpid()),
+		"curve_version": c.version(),
+	}
+	for name, value := range extra {
+		vars[name] = value
+	}
+	return vars
+}
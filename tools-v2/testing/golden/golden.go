@@ -0,0 +1,52 @@
+/*
+ *  Copyright (c) 2022 NetEase Inc.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+/*
+ * Project: CurveCli
+ * Created Date: 2026-10-17
+ */
+
+// Package golden compares the output of commands with the files checked in
+// testdata, run the tests with -update to write the files after the output
+// is changed on purpose.
+package golden
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+)
+
+var update = flag.Bool("update", false, "update the golden files instead of comparing with them")
+
+// Updating reports whether the tests are run with -update
+func Updating() bool {
+	return *update
+}
+
+// Read returns the content of golden file to compare with got,
+// the file is written with got first if the tests are run with -update
+func Read(path string, got []byte) ([]byte, error) {
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			return nil, err
+		}
+	}
+	return os.ReadFile(path)
+}